@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/cloudevents/sdk-go/pkg/cloudevents"
@@ -92,3 +96,56 @@ func mapDeploymentStrategies(deploymentStrategies map[string]string) (map[string
 func getServiceInternalError(err error) *service.PostProjectProjectNameServiceDefault {
 	return service.NewPostProjectProjectNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
 }
+
+type mergeConflict struct {
+	Message   string   `json:"message"`
+	Conflicts []string `json:"conflicts"`
+}
+
+// PostServicePromoteHandlerFunc promotes the configuration of a service from another stage
+func PostServicePromoteHandlerFunc(params service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteParams, principal *models.Principal) middleware.Responder {
+
+	l := keptnutils.NewLogger("", "", "api")
+	l.Info("API received promotion of service " + params.ServiceName + " to stage " + params.StageName)
+
+	body, err := json.Marshal(params.Promotion)
+	if err != nil {
+		return getServicePromoteInternalError(err)
+	}
+	promoteURL := getConfigurationServiceURL() + "/v1/project/" + url.PathEscape(params.ProjectName) +
+		"/stage/" + url.PathEscape(params.StageName) + "/service/" + url.PathEscape(params.ServiceName) + "/promote"
+	resp, err := http.Post(promoteURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		l.Error(fmt.Sprintf("Error promoting service %s", err.Error()))
+		return getServicePromoteInternalError(err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated()
+	case http.StatusConflict:
+		conflict := &mergeConflict{}
+		if err := json.NewDecoder(resp.Body).Decode(conflict); err != nil {
+			return getServicePromoteInternalError(err)
+		}
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteConflict().WithPayload(&models.Error{
+			Code:    http.StatusConflict,
+			Message: swag.String(conflict.Message),
+			Fields:  strings.Join(conflict.Conflicts, ","),
+		})
+	}
+
+	errorObj := &models.Error{}
+	if err := json.NewDecoder(resp.Body).Decode(errorObj); err != nil || errorObj.Message == nil {
+		errorObj = &models.Error{Code: int64(resp.StatusCode), Message: swag.String("Could not promote service")}
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteBadRequest().WithPayload(errorObj)
+	}
+	return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteDefault(resp.StatusCode).WithPayload(errorObj)
+}
+
+func getServicePromoteInternalError(err error) *service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteDefault {
+	return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
+}
@@ -76,6 +76,7 @@ func configureAPI(api *operations.EmptyAPI) http.Handler {
 
 	// Service endpoints
 	api.ServicePostProjectProjectNameServiceHandler = service.PostProjectProjectNameServiceHandlerFunc(handlers.PostServiceHandlerFunc)
+	api.ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler = service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandlerFunc(handlers.PostServicePromoteHandlerFunc)
 
 	// Resource endpoints
 	api.ProjectResourcePostProjectProjectNameResourceHandler =
@@ -299,6 +299,53 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/promote": {
+      "post": {
+        "tags": [
+          "Service"
+        ],
+        "summary": "Promotes the configuration of the service from another stage by merging the changes of the service into this stage",
+        "parameters": [
+          {
+            "$ref": "#/parameters/promotion"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. Service has been promoted"
+          },
+          "400": {
+            "description": "Failed. Service could not be promoted",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Failed. Resources of the service have been changed in both stages",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "response_model.yaml#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        },
+        {
+          "$ref": "#/parameters/serviceName"
+        }
+      ]
+    },
     "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource": {
       "put": {
         "tags": [
@@ -385,6 +432,14 @@ func init() {
       "in": "path",
       "required": true
     },
+    "promotion": {
+      "description": "Promotion of a service",
+      "name": "promotion",
+      "in": "body",
+      "schema": {
+        "$ref": "promotion_model.yaml#/definitions/promotion"
+      }
+    },
     "resource": {
       "description": "Resource",
       "name": "resource",
@@ -787,6 +842,70 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/promote": {
+      "post": {
+        "tags": [
+          "Service"
+        ],
+        "summary": "Promotes the configuration of the service from another stage by merging the changes of the service into this stage",
+        "parameters": [
+          {
+            "description": "Promotion of a service",
+            "name": "promotion",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/promotion"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. Service has been promoted"
+          },
+          "400": {
+            "description": "Failed. Service could not be promoted",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Failed. Resources of the service have been changed in both stages",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "serviceName",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource": {
       "put": {
         "tags": [
@@ -1016,6 +1135,15 @@ func init() {
         }
       }
     },
+    "promotion": {
+      "type": "object",
+      "properties": {
+        "sourceStage": {
+          "description": "Name of the stage the service is promoted from",
+          "type": "string"
+        }
+      }
+    },
     "resource": {
       "type": "object",
       "required": [
@@ -1084,6 +1212,14 @@ func init() {
       "in": "path",
       "required": true
     },
+    "promotion": {
+      "description": "Promotion of a service",
+      "name": "promotion",
+      "in": "body",
+      "schema": {
+        "$ref": "#/definitions/promotion"
+      }
+    },
     "resource": {
       "description": "Resource",
       "name": "resource",
@@ -68,6 +68,9 @@ func NewEmptyAPI(spec *loads.Document) *EmptyAPI {
 		StageResourcePostProjectProjectNameStageStageNameResourceHandler: stage_resource.PostProjectProjectNameStageStageNameResourceHandlerFunc(func(params stage_resource.PostProjectProjectNameStageStageNameResourceParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation stage_resource.PostProjectProjectNameStageStageNameResource has not yet been implemented")
 		}),
+		ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler: service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandlerFunc(func(params service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation service.PostProjectProjectNameStageStageNameServiceServiceNamePromote has not yet been implemented")
+		}),
 		ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler: service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(func(params service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResource has not yet been implemented")
 		}),
@@ -136,6 +139,8 @@ type EmptyAPI struct {
 	ServicePostProjectProjectNameServiceHandler service.PostProjectProjectNameServiceHandler
 	// StageResourcePostProjectProjectNameStageStageNameResourceHandler sets the operation handler for the post project project name stage stage name resource operation
 	StageResourcePostProjectProjectNameStageStageNameResourceHandler stage_resource.PostProjectProjectNameStageStageNameResourceHandler
+	// ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler sets the operation handler for the post project project name stage stage name service service name promote operation
+	ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler
 	// ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler sets the operation handler for the post project project name stage stage name service service name resource operation
 	ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceHandler
 	// ServiceResourcePutProjectProjectNameStageStageNameServiceServiceNameResourceHandler sets the operation handler for the put project project name stage stage name service service name resource operation
@@ -240,6 +245,10 @@ func (o *EmptyAPI) Validate() error {
 		unregistered = append(unregistered, "StageResource.PostProjectProjectNameStageStageNameResourceHandler")
 	}
 
+	if o.ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler == nil {
+		unregistered = append(unregistered, "Service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler")
+	}
+
 	if o.ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler == nil {
 		unregistered = append(unregistered, "ServiceResource.PostProjectProjectNameStageStageNameServiceServiceNameResourceHandler")
 	}
@@ -394,6 +403,11 @@ func (o *EmptyAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/project/{projectName}/stage/{stageName}/resource"] = stage_resource.NewPostProjectProjectNameStageStageNameResource(o.context, o.StageResourcePostProjectProjectNameStageStageNameResourceHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/project/{projectName}/stage/{stageName}/service/{serviceName}/promote"] = service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromote(o.context, o.ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -18,16 +18,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 
 	apiutils "github.com/keptn/go-utils/pkg/api/utils"
 	keptnevents "github.com/keptn/go-utils/pkg/events"
 	"github.com/keptn/keptn/cli/pkg/logging"
 	"github.com/keptn/keptn/cli/utils/credentialmanager"
+	"github.com/keptn/keptn/lighthouse-service/pkg/report"
 	"github.com/spf13/cobra"
 )
 
 type evaluationDoneStruct struct {
 	KeptnContext *string `json:"keptnContext"`
+	Output       *string `json:"output"`
 }
 
 var evaluationDone evaluationDoneStruct
@@ -38,11 +41,19 @@ var evaluationDoneCmd = &cobra.Command{
 	Short: "Returns the latest Keptn sh.keptn.events.evaluation-done event from a specific Keptn context",
 	Long: `Returns the latest Keptn sh.keptn.events.evaluation-done event from a specific Keptn context.
 	
+By default, the event is printed as JSON. With --output, the evaluation result is rendered as Markdown, standalone HTML
+or JUnit XML report (one testcase per SLI, violated targets as failures), e.g. to display it in a CI system.
+
 Example:
-	keptn get event evaluation-done --keptn-context=1234-5678-90ab-cdef`,
+	keptn get event evaluation-done --keptn-context=1234-5678-90ab-cdef
+	keptn get event evaluation-done --keptn-context=1234-5678-90ab-cdef --output=junit > evaluation.xml`,
 	SilenceUsage: true,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		return nil
+		if *evaluationDone.Output == "json" {
+			return nil
+		}
+		_, err := report.ParseFormat(*evaluationDone.Output)
+		return err
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		endPoint, apiToken, err := credentialmanager.GetCreds()
@@ -68,7 +79,11 @@ Example:
 			}
 
 			event, _ := json.Marshal(evaluationDoneEvt)
-			fmt.Println(string(event))
+			if *evaluationDone.Output == "json" {
+				fmt.Println(string(event))
+				return nil
+			}
+			return printEvaluationReport(event, *evaluationDone.Output)
 
 		} else {
 			fmt.Println("Skipping send evaluation-start due to mocking flag set to true")
@@ -77,10 +92,24 @@ Example:
 	},
 }
 
+func printEvaluationReport(event []byte, output string) error {
+	format, err := report.ParseFormat(output)
+	if err != nil {
+		return err
+	}
+	evaluation, err := report.ParseEvaluationDoneEvent(event)
+	if err != nil {
+		return err
+	}
+	return report.Render(os.Stdout, evaluation, format)
+}
+
 func init() {
 	getEventCmd.AddCommand(evaluationDoneCmd)
 
 	evaluationDone.KeptnContext = evaluationDoneCmd.Flags().StringP("keptn-context", "", "",
 		"The ID of a Keptn context from which to retrieve an evaluation-done event")
 	evaluationDoneCmd.MarkFlagRequired("keptn-context")
+	evaluationDone.Output = evaluationDoneCmd.Flags().StringP("output", "o", "json",
+		"The output format: json, markdown, html or junit")
 }
@@ -35,3 +35,27 @@ func TestEvaluationDoneGetEvent(t *testing.T) {
 		t.Errorf("An error occured: %v", err)
 	}
 }
+
+// TestEvaluationDoneGetEventWithUnknownOutput tests that unknown report formats are rejected
+func TestEvaluationDoneGetEventWithUnknownOutput(t *testing.T) {
+
+	credentialmanager.MockAuthCreds = true
+	buf := new(bytes.Buffer)
+	rootCmd.SetOutput(buf)
+
+	args := []string{
+		"get",
+		"event",
+		"evaluation-done",
+		fmt.Sprintf("--keptn-context=%s", "8929e5e5-3826-488f-9257-708bfa974909"),
+		"--output=pdf",
+		"--mock",
+	}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+
+	if err == nil {
+		t.Errorf("Expected an error for output format pdf")
+	}
+	*evaluationDone.Output = "json"
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// promoteCmd represents the promote command
+var promoteCmd = &cobra.Command{
+	Use:   "promote [service]",
+	Short: "promote is the parent command of \"promote service\"",
+	Long:  `promote is the parent command of \"promote service\". \"promote\" without subcommand cannot be used.`,
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+}
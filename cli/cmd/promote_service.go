@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/keptn/keptn/cli/utils/credentialmanager"
+	"github.com/spf13/cobra"
+)
+
+type promoteServiceCmdParams struct {
+	Project     *string
+	Stage       *string
+	SourceStage *string
+}
+
+var promoteServiceParams *promoteServiceCmdParams
+
+var promoteServiceCmd = &cobra.Command{
+	Use:   "service SERVICENAME --project=PROJECTNAME --stage=STAGE --source-stage=STAGE",
+	Short: "Promotes the configuration of a service from one stage to another",
+	Long: `Promotes the configuration of a service from one stage to another. The changes of the service
+in the source stage since its last promotion are merged into the stage and recorded as a new commit in the
+Git repository of the project. If the configuration of the service has been changed in both stages,
+nothing is promoted and the conflicting resources are listed.
+
+Example:
+	keptn promote service carts --project=sockshop --stage=staging --source-stage=dev`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		_, _, err := credentialmanager.GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if len(args) != 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument SERVICENAME not set")
+		}
+		if *promoteServiceParams.Stage == *promoteServiceParams.SourceStage {
+			return errors.New("stage and source stage have to be different")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.GetCreds()
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+		logging.PrintLog("Starting to promote service "+args[0]+" from stage "+*promoteServiceParams.SourceStage+
+			" to stage "+*promoteServiceParams.Stage, logging.InfoLevel)
+
+		// the resource handler provides the authentication and transport settings of the api
+		resourceHandler := apiutils.NewAuthenticatedResourceHandler(endPoint.String(), apiToken, "x-token", nil, "https")
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if !mocking {
+			errorObj := promoteService(resourceHandler, *promoteServiceParams.Project, *promoteServiceParams.Stage, args[0], *promoteServiceParams.SourceStage)
+			if errorObj != nil {
+				if errorObj.Code == http.StatusConflict && errorObj.Fields != "" {
+					return fmt.Errorf("Promote service was unsuccessful. %s: %s", *errorObj.Message, errorObj.Fields)
+				}
+				return fmt.Errorf("Promote service was unsuccessful. %s", *errorObj.Message)
+			}
+
+			logging.PrintLog("Service has been promoted.", logging.InfoLevel)
+			return nil
+		}
+
+		fmt.Println("Skipping promote service due to mocking flag set to true")
+		return nil
+	},
+}
+
+func promoteService(resourceHandler *apiutils.ResourceHandler, project string, stage string, service string, sourceStage string) *apimodels.Error {
+	body, err := json.Marshal(map[string]string{"sourceStage": sourceStage})
+	if err != nil {
+		return buildPromoteServiceError(err.Error())
+	}
+	uri := resourceHandler.Scheme + "://" + resourceHandler.BaseURL + "/v1/project/" + url.PathEscape(project) +
+		"/stage/" + url.PathEscape(stage) + "/service/" + url.PathEscape(service) + "/promote"
+
+	req, err := http.NewRequest("POST", uri, bytes.NewBuffer(body))
+	if err != nil {
+		return buildPromoteServiceError(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(resourceHandler.AuthHeader, resourceHandler.AuthToken)
+	req.Host = "api.keptn"
+
+	resp, err := resourceHandler.HTTPClient.Do(req)
+	if err != nil {
+		return buildPromoteServiceError(err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode <= 204 {
+		return nil
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return buildPromoteServiceError(err.Error())
+	}
+	respErr := &apimodels.Error{}
+	if err := json.Unmarshal(respBody, respErr); err != nil || respErr.Message == nil {
+		return buildPromoteServiceError(fmt.Sprintf("Received status code %d", resp.StatusCode))
+	}
+	respErr.Code = int64(resp.StatusCode)
+	return respErr
+}
+
+func buildPromoteServiceError(message string) *apimodels.Error {
+	return &apimodels.Error{Code: 500, Message: &message}
+}
+
+func init() {
+	promoteCmd.AddCommand(promoteServiceCmd)
+	promoteServiceParams = &promoteServiceCmdParams{}
+	promoteServiceParams.Project = promoteServiceCmd.Flags().StringP("project", "p", "", "The project containing the service")
+	promoteServiceCmd.MarkFlagRequired("project")
+	promoteServiceParams.Stage = promoteServiceCmd.Flags().StringP("stage", "s", "", "The stage the service is promoted to")
+	promoteServiceCmd.MarkFlagRequired("stage")
+	promoteServiceParams.SourceStage = promoteServiceCmd.Flags().StringP("source-stage", "", "", "The stage the service is promoted from")
+	promoteServiceCmd.MarkFlagRequired("source-stage")
+}
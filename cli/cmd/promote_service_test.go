@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/keptn/keptn/cli/utils/credentialmanager"
+)
+
+func init() {
+	logging.InitLoggers(os.Stdout, os.Stdout, os.Stderr)
+}
+
+// TestPromoteServiceCmd tests the default use of the promote service command
+func TestPromoteServiceCmd(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	args := []string{
+		"promote",
+		"service",
+		"carts",
+		fmt.Sprintf("--project=%s", "sockshop"),
+		fmt.Sprintf("--stage=%s", "staging"),
+		fmt.Sprintf("--source-stage=%s", "dev"),
+		"--mock",
+	}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+
+	if err != nil {
+		t.Errorf("An error occured: %v", err)
+	}
+}
+
+// TestPromoteServiceToSourceStage tests that a service cannot be promoted to its source stage
+func TestPromoteServiceToSourceStage(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	args := []string{
+		"promote",
+		"service",
+		"carts",
+		fmt.Sprintf("--project=%s", "sockshop"),
+		fmt.Sprintf("--stage=%s", "dev"),
+		fmt.Sprintf("--source-stage=%s", "dev"),
+		"--mock",
+	}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+
+	if err == nil {
+		t.Error("An error was expected")
+	}
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate [slo]",
+	Short: `validate in combination with the subcommand "slo" allows to validate a Keptn configuration file`,
+	Long:  `validate in combination with the subcommand "slo" allows to validate a Keptn configuration file. Validate without subcommand cannot be used.`,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
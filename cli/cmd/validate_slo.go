@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/keptn/keptn/lighthouse-service/pkg/slo"
+	"github.com/spf13/cobra"
+)
+
+type validateSLOCommandParameters struct {
+	File *string
+}
+
+var validateSLOParams *validateSLOCommandParameters
+
+// validateSLOCmd represents the validate slo command
+var validateSLOCmd = &cobra.Command{
+	Use:   "slo --file=FILEPATH",
+	Short: "Validates a slo.yaml file before it is added to a service",
+	Long: `Validates a slo.yaml file before it is added to a service. The criteria, weights, total score and comparison settings
+are checked with the same rules the lighthouse-service applies when evaluating the file.
+
+Example:
+	keptn validate slo --file=./slo.yaml`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		*validateSLOParams.File = keptnutils.ExpandTilde(*validateSLOParams.File)
+		if !fileExists(*validateSLOParams.File) {
+			return errors.New("File " + *validateSLOParams.File + " not found on local file system")
+		}
+
+		content, err := ioutil.ReadFile(*validateSLOParams.File)
+		if err != nil {
+			return errors.New("File " + *validateSLOParams.File + " could not be read")
+		}
+
+		_, validationErrors := slo.ParseAndValidate(content)
+		if len(validationErrors) > 0 {
+			for _, validationError := range validationErrors {
+				logging.PrintLog(validationError.Error(), logging.QuietLevel)
+			}
+			return fmt.Errorf("SLO file %s is invalid: %d problem(s) found", *validateSLOParams.File, len(validationErrors))
+		}
+
+		logging.PrintLog("SLO file "+*validateSLOParams.File+" is valid", logging.InfoLevel)
+		return nil
+	},
+}
+
+func init() {
+	validateCmd.AddCommand(validateSLOCmd)
+	validateSLOParams = &validateSLOCommandParameters{}
+
+	validateSLOParams.File = validateSLOCmd.Flags().StringP("file", "f", "", "Path pointing to the slo.yaml file on your local file system")
+	validateSLOCmd.MarkFlagRequired("file")
+}
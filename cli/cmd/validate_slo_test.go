@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/logging"
+)
+
+func init() {
+	logging.InitLoggers(os.Stdout, os.Stdout, os.Stderr)
+}
+
+// TestValidateValidSLO
+func TestValidateValidSLO(t *testing.T) {
+
+	sloFileName := "testSLO.yaml"
+	defer testResource(t, sloFileName, `---
+spec_version: '1.0'
+comparison:
+  compare_with: "several_results"
+  number_of_comparison_results: 3
+  aggregate_function: p90
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+total_score:
+  pass: "90%"
+  warning: "75%"`)()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOutput(buf)
+
+	args := []string{
+		"validate",
+		"slo",
+		fmt.Sprintf("--file=%s", sloFileName),
+	}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+
+	if err != nil {
+		t.Errorf("An error occured: %v", err)
+	}
+}
+
+// TestValidateInvalidSLO
+func TestValidateInvalidSLO(t *testing.T) {
+
+	sloFileName := "testSLO.yaml"
+	defer testResource(t, sloFileName, `---
+spec_version: '1.0'
+comparison:
+  aggregate_function: average
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "=<600"`)()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOutput(buf)
+
+	args := []string{
+		"validate",
+		"slo",
+		fmt.Sprintf("--file=%s", sloFileName),
+	}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+
+	if err == nil {
+		t.Errorf("Expected an error for an invalid SLO file")
+	}
+}
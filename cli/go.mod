@@ -12,6 +12,7 @@ require (
 	github.com/google/uuid v1.1.1
 	github.com/gorilla/websocket v1.4.1
 	github.com/keptn/go-utils v0.6.0
+	github.com/keptn/keptn/lighthouse-service v0.0.0-00010101000000-000000000000
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/spf13/cobra v0.0.5
 	github.com/spf13/viper v1.4.0
@@ -22,3 +23,5 @@ require (
 	k8s.io/apimachinery v0.0.0-20190313205120-d7deff9243b1
 	k8s.io/helm v2.14.3+incompatible
 )
+
+replace github.com/keptn/keptn/lighthouse-service => ../lighthouse-service
@@ -0,0 +1,149 @@
+package common
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/keptn/keptn/configuration-service/config"
+)
+
+// askPassScript answers the prompts of git for the user name and the password with the environment variables of the
+// git process, so that credentials neither appear in remote URLs nor in command lines
+const askPassScript = `#!/bin/sh
+case "$1" in
+	Username*) printf '%s\n' "$GIT_USERNAME" ;;
+	*) printf '%s\n' "$GIT_PASSWORD" ;;
+esac
+`
+
+// Valid checks if the credentials are sufficient to access the upstream, i.e., a remote URI with either a user and
+// token (HTTPS) or a private key (SSH). The host key of an SSH upstream has to be verifiable, hence a private key
+// requires the known hosts of the upstream, unless new host keys are accepted explicitly
+func (c *GitCredentials) Valid() bool {
+	if c == nil || c.RemoteURI == "" {
+		return false
+	}
+	if c.PrivateKey != "" {
+		return c.KnownHosts != "" || c.AcceptNewHostKey
+	}
+	return c.User != "" && c.Token != ""
+}
+
+// scrub removes the secrets of the credentials from a message, e.g., an error returned by git
+func (c *GitCredentials) scrub(message string) string {
+	if c == nil {
+		return message
+	}
+	for _, secret := range []string{c.Token, url.QueryEscape(c.Token), url.PathEscape(c.Token)} {
+		if len(secret) > 0 {
+			message = strings.Replace(message, secret, "***", -1)
+		}
+	}
+	return message
+}
+
+// remoteURI returns the remote URI of the credentials without any user info contained in it
+func (c *GitCredentials) remoteURI() string {
+	uri, err := url.Parse(c.RemoteURI)
+	if err != nil || uri.User == nil || (uri.Scheme != "http" && uri.Scheme != "https") {
+		return c.RemoteURI
+	}
+	uri.User = nil
+	return uri.String()
+}
+
+// PublicRemoteURI returns the remote URI of the credentials without any user info contained in it, which can be
+// shown to users
+func (c *GitCredentials) PublicRemoteURI() string {
+	return c.remoteURI()
+}
+
+// setRemoteURL sets the URL of the origin remote of a project to the remote URI of the credentials. Clones created by
+// earlier versions contain the user and token of the credentials in the URL of origin, which is stored unencrypted in
+// the git config
+func setRemoteURL(project string, credentials *GitCredentials) error {
+	if _, err := runGit(project, "remote", "get-url", "origin"); err != nil {
+		_, err = runGit(project, "remote", "add", "origin", credentials.remoteURI())
+		return err
+	}
+	_, err := runGit(project, "remote", "set-url", "origin", credentials.remoteURI())
+	return err
+}
+
+// runGitWithCredentials executes a git command that accesses the upstream of a project. The credentials are passed
+// to git via GIT_ASKPASS, GIT_SSH_COMMAND and http.sslCAInfo using temporary files, and are removed from errors
+func runGitWithCredentials(project string, credentials *GitCredentials, args ...string) ([]byte, error) {
+	if !credentials.Valid() {
+		return nil, errors.New("Invalid git credentials")
+	}
+	dir, err := ioutil.TempDir("", "git-credentials")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	gitArgs := []string{"-c", "credential.helper="}
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if credentials.Token != "" {
+		askPass := filepath.Join(dir, "askpass.sh")
+		if err := ioutil.WriteFile(askPass, []byte(askPassScript), 0700); err != nil {
+			return nil, err
+		}
+		env = append(env, "GIT_ASKPASS="+askPass, "GIT_USERNAME="+credentials.User, "GIT_PASSWORD="+credentials.Token)
+	}
+	if credentials.PrivateKey != "" {
+		sshCommand, err := getSSHCommand(project, dir, credentials)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, "GIT_SSH_COMMAND="+sshCommand)
+	}
+	if credentials.CACertificate != "" {
+		caCertificate := filepath.Join(dir, "ca.crt")
+		if err := ioutil.WriteFile(caCertificate, []byte(credentials.CACertificate), 0600); err != nil {
+			return nil, err
+		}
+		gitArgs = append(gitArgs, "-c", "http.sslCAInfo="+caCertificate)
+	}
+	gitArgs = append(gitArgs, args...)
+
+	cmd := exec.Command("git", gitArgs...)
+	cmd.Dir = config.ConfigDir + "/" + project
+	cmd.Env = env
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, errors.New(credentials.scrub("Error executing command git " + strings.Join(args, " ") + ": " + err.Error() + " " + strings.TrimSpace(string(out))))
+	}
+	return out, nil
+}
+
+// getSSHCommand writes the private key and the known hosts of the credentials to dir, and returns the ssh command
+// using them. The host key of the upstream has to match one of the known hosts. Only if new host keys are accepted
+// explicitly, an unknown host key is trusted on first use and pinned in the git directory of the project, where it is
+// verified by all later commands
+func getSSHCommand(project string, dir string, credentials *GitCredentials) (string, error) {
+	privateKey := filepath.Join(dir, "id_key")
+	if err := ioutil.WriteFile(privateKey, []byte(strings.TrimSpace(credentials.PrivateKey)+"\n"), 0600); err != nil {
+		return "", err
+	}
+	knownHosts := filepath.Join(dir, "known_hosts")
+	if err := ioutil.WriteFile(knownHosts, []byte(strings.TrimSpace(credentials.KnownHosts)+"\n"), 0600); err != nil {
+		return "", err
+	}
+	if !credentials.AcceptNewHostKey {
+		return "ssh -i " + privateKey + " -o IdentitiesOnly=yes -o StrictHostKeyChecking=yes -o UserKnownHostsFile=" + knownHosts, nil
+	}
+
+	// ssh adds the accepted host keys to the first of the user known hosts files
+	pinnedHosts := filepath.Join(dir, "pinned_hosts")
+	if gitDir := filepath.Join(config.ConfigDir, project, ".git"); project != "" && FileExists(gitDir) {
+		pinnedHosts = filepath.Join(gitDir, "known_hosts")
+	}
+	return "ssh -i " + privateKey + " -o IdentitiesOnly=yes -o StrictHostKeyChecking=accept-new -o UserKnownHostsFile='" +
+		pinnedHosts + " " + knownHosts + "'", nil
+}
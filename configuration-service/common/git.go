@@ -1,15 +1,23 @@
 package common
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
-	"net/url"
+	"fmt"
 	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/keptn/go-utils/pkg/utils"
 	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/models"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -19,57 +27,48 @@ type GitCredentials struct {
 	User      string `json:"user,omitempty"`
 	Token     string `json:"token,omitempty"`
 	RemoteURI string `json:"remoteURI,omitempty"`
+	// PrivateKey is an SSH deploy key, used instead of the user and token for SSH upstreams
+	PrivateKey string `json:"privateKey,omitempty"`
+	// KnownHosts contains the known_hosts entries of the SSH upstream, which its host key is verified against
+	KnownHosts string `json:"knownHosts,omitempty"`
+	// AcceptNewHostKey trusts the host key of an SSH upstream that is not contained in KnownHosts on first use, and
+	// pins it in the git directory of the project. It must only be set if the network to the upstream is trusted
+	AcceptNewHostKey bool `json:"acceptNewHostKey,omitempty"`
+	// CACertificate is a PEM encoded CA bundle used to verify HTTPS upstreams
+	CACertificate string `json:"caCertificate,omitempty"`
 }
 
-// CloneRepo clones an upstream repository into a local folder "project"
-func CloneRepo(project string, user string, token string, uri string) error {
-	uri = getRepoURI(uri, user, token)
-
-	_, err := utils.ExecuteCommandInDirectory("git", []string{"clone", uri, project}, config.ConfigDir)
-
+// cloneRepo clones an upstream repository into a local folder "project"
+func cloneRepo(project string, credentials *GitCredentials) error {
+	_, err := runGitWithCredentials("", credentials, "clone", credentials.remoteURI(), project)
 	return err
 }
 
-func getRepoURI(uri string, user string, token string) string {
-	if strings.Contains(user, "@") {
-		// username contains an @, probably an e-mail; need to encode it
-		// see https://stackoverflow.com/a/29356143
-		user = url.QueryEscape(user)
-	}
-
-	if strings.Contains(uri, user+"@") {
-		uri = strings.Replace(uri, "https://"+user+"@", "https://"+user+":"+token+"@", 1)
-	}
-
-	if !strings.Contains(uri, user+":"+token+"@") {
-		uri = strings.Replace(uri, "https://", "https://"+user+":"+token+"@", 1)
-	}
-
-	return uri
+// verifyCredentials checks that the upstream of the credentials can be accessed with them
+func verifyCredentials(credentials *GitCredentials) error {
+	_, err := runGitWithCredentials("", credentials, "ls-remote", "--heads", credentials.remoteURI())
+	return err
 }
 
-// CheckoutBranch checks out the given branch
-func CheckoutBranch(project string, branch string) error {
+// checkoutBranch checks out the given branch and merges the changes of the upstream, if one has been defined. If the
+// branch and the upstream changed the same files, the branch is left unchanged and a *MergeConflictError is returned
+func checkoutBranch(project string, branch string) error {
 	projectConfigPath := config.ConfigDir + "/" + project
 	_, err := utils.ExecuteCommandInDirectory("git", []string{"checkout", branch}, projectConfigPath)
 	if err != nil {
 		return err
 	}
-	credentials, err := GetCredentials(project)
+	credentials, err := getCredentials(project)
 	if err == nil && credentials != nil {
-		repoURI := getRepoURI(credentials.RemoteURI, credentials.User, credentials.Token)
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"pull", "-s", "recursive", "-X", "theirs", repoURI}, projectConfigPath)
-		if err != nil {
-			return err
-		}
+		return syncBranch(project, branch, credentials)
 	}
 	return nil
 }
 
-// CreateBranch creates a new branch
-func CreateBranch(project string, branch string, sourceBranch string) error {
+// createBranch creates a new branch
+func createBranch(project string, branch string, sourceBranch string) error {
 	projectConfigPath := config.ConfigDir + "/" + project
-	err := CheckoutBranch(project, sourceBranch)
+	err := checkoutBranch(project, sourceBranch)
 	if err != nil {
 		return err
 	}
@@ -79,10 +78,9 @@ func CreateBranch(project string, branch string, sourceBranch string) error {
 	}
 
 	// if an upstream has been defined, push the new branch
-	credentials, err := GetCredentials(project)
+	credentials, err := getCredentials(project)
 	if err == nil && credentials != nil {
-		repoURI := getRepoURI(credentials.RemoteURI, credentials.User, credentials.Token)
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"push", "--set-upstream", repoURI, branch}, projectConfigPath)
+		_, err = runGitWithCredentials(project, credentials, "push", "--set-upstream", credentials.remoteURI(), branch)
 		if err != nil {
 			return errors.New("Could not push to upstream")
 		}
@@ -91,33 +89,99 @@ func CreateBranch(project string, branch string, sourceBranch string) error {
 	return nil
 }
 
-// StageAndCommitAll stages all current changes and commits them to the current branch
-func StageAndCommitAll(project string, message string) error {
+// deleteBranch deletes a branch of the project and removes it from the upstream, if one has been defined. The branch
+// is deleted from the upstream first, hence it is kept if the upstream cannot be updated
+func deleteBranch(project string, branch string) error {
 	projectConfigPath := config.ConfigDir + "/" + project
-	_, err := utils.ExecuteCommandInDirectory("git", []string{"add", "."}, projectConfigPath)
+	err := checkoutBranch(project, "master")
 	if err != nil {
 		return err
 	}
 
-	_, err = utils.ExecuteCommandInDirectory("git", []string{"commit", "-m", message}, projectConfigPath)
-	// in this case, ignore errors since the only instance when this can occur at this stage is when there is nothin to commit (no delta)
-	credentials, err := GetCredentials(project)
+	credentials, err := getCredentials(project)
 	if err == nil && credentials != nil {
-		repoURI := getRepoURI(credentials.RemoteURI, credentials.User, credentials.Token)
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"pull", "-s", "recursive", "-X", "theirs", repoURI}, projectConfigPath)
-		if err != nil {
+		_, err = runGitWithCredentials(project, credentials, "push", credentials.remoteURI(), "--delete", branch)
+		// a branch that has never been pushed does not need to be deleted from the upstream
+		if err != nil && !strings.Contains(err.Error(), "remote ref does not exist") {
+			return errors.New("Could not delete branch in upstream")
+		}
+	}
+	// a push to the remote URI does not update the remote-tracking branch, which would still resolve the branch
+	_, err = utils.ExecuteCommandInDirectory("git", []string{"update-ref", "-d", "refs/remotes/origin/" + branch}, projectConfigPath)
+	if err != nil {
+		return err
+	}
+	_, err = utils.ExecuteCommandInDirectory("git", []string{"branch", "-D", branch}, projectConfigPath)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// pushBranches pushes all branches of the project to the given upstream
+func pushBranches(project string, credentials *GitCredentials) error {
+	_, err := runGitWithCredentials(project, credentials, "push", "--all", credentials.remoteURI())
+	if err != nil {
+		return errors.New("Could not push to upstream")
+	}
+	// a push to the remote URI does not update the remote-tracking branches, which the sync status is based on
+	branches, err := getBranches(project)
+	if err != nil {
+		return err
+	}
+	for _, branch := range branches {
+		if branch == "" {
+			continue
+		}
+		if _, err := runGit(project, "update-ref", "refs/remotes/origin/"+branch, "refs/heads/"+branch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stageAndCommitAll stages all current changes, commits them to the current branch and pushes them to the upstream,
+// if one has been defined. If the upstream contains conflicting changes, the commit is reverted and a
+// *MergeConflictError is returned. If the push fails for another reason, the commit is kept and the failure is
+// reported by the sync status of the project, which shows the commit as pending
+func stageAndCommitAll(project string, message string) error {
+	if _, err := runGit(project, "add", "."); err != nil {
+		return err
+	}
+	out, err := runGit(project, "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	previousVersion := strings.TrimSpace(string(out))
+	if _, err := runGit(project, "diff", "--cached", "--quiet"); err != nil {
+		// there are staged changes
+		if _, err := runGit(project, "commit", "-m", message); err != nil {
 			return err
 		}
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"push", repoURI}, projectConfigPath)
+	}
+
+	credentials, err := getCredentials(project)
+	if err == nil && credentials != nil {
+		out, err := runGit(project, "rev-parse", "--abbrev-ref", "HEAD")
 		if err != nil {
 			return err
 		}
+		err = pushBranch(project, strings.TrimSpace(string(out)), credentials)
+		if conflict, ok := err.(*MergeConflictError); ok {
+			// do not keep changes that cannot be pushed
+			if _, resetErr := runGit(project, "reset", "--hard", previousVersion); resetErr != nil {
+				return resetErr
+			}
+			return conflict
+		}
+		// the commit has been made, hence it must not be reported as failed. The changes are pushed with the next
+		// change of the branch, and pushBranch has recorded the error in the sync status until then
 	}
 	return nil
 }
 
-// GetCurrentVersion gets the latest version (i.e. commit hash) of the currently checked out branch
-func GetCurrentVersion(project string) (string, error) {
+// getCurrentVersion gets the latest version (i.e. commit hash) of the currently checked out branch
+func getCurrentVersion(project string) (string, error) {
 	projectConfigPath := config.ConfigDir + "/" + project
 	out, err := utils.ExecuteCommandInDirectory("git", []string{"rev-parse", "HEAD"}, projectConfigPath)
 	if err != nil {
@@ -126,64 +190,59 @@ func GetCurrentVersion(project string) (string, error) {
 	return strings.TrimSuffix(out, "\n"), nil
 }
 
-// ProjectExists checks if a project exists
-func ProjectExists(project string) bool {
-	projectConfigPath := config.ConfigDir + "/" + project
-	// check if the project exists
-	_, err := os.Stat(projectConfigPath)
-	// create file if not exists
-	if os.IsNotExist(err) {
-		return false
-	}
-	return true
+// CredentialsStore stores the credentials of the upstreams of the projects
+type CredentialsStore interface {
+	// StoreCredentials stores the credentials of a project, replacing existing ones
+	StoreCredentials(project string, credentials *GitCredentials) error
+	// GetCredentials returns the credentials of a project, or nil if no upstream has been defined
+	GetCredentials(project string) (*GitCredentials, error)
+	// DeleteCredentials deletes the credentials of a project
+	DeleteCredentials(project string) error
 }
 
-// StageExists checks if a stage in a given project exists
-func StageExists(project string, stage string) bool {
-	if !ProjectExists(project) {
-		return false
-	}
-	// try to checkout the branch containing the stage config
-	err := CheckoutBranch(project, stage)
-	if err != nil {
-		return false
+// secretCredentialsStore stores the credentials as secrets in the cluster
+type secretCredentialsStore struct{}
+
+var credentialsStore CredentialsStore = secretCredentialsStore{}
+
+// SetCredentialsStore sets the store of the credentials used by the GitStorage. nil restores the default store, which
+// keeps the credentials as secrets in the cluster
+func SetCredentialsStore(s CredentialsStore) {
+	if s == nil {
+		s = secretCredentialsStore{}
 	}
-	return true
+	credentialsStore = s
 }
 
-// ServiceExists checks if a service exists in a given stage of a project
-func ServiceExists(project string, stage string, service string) bool {
-	if !ProjectExists(project) {
-		return false
-	}
-	// try to checkout the branch containing the stage config
-	err := CheckoutBranch(project, stage)
-	if err != nil {
-		return false
+// storeGitCredentials stores the specified git credentials, replacing existing credentials of the project
+func storeGitCredentials(project string, credentials *GitCredentials) error {
+	if err := credentialsStore.StoreCredentials(project, credentials); err != nil {
+		return err
 	}
-	serviceConfigPath := config.ConfigDir + "/" + project + "/" + service
-	_, err = os.Stat(serviceConfigPath)
-	// create file if not exists
-	if os.IsNotExist(err) {
-		return false
+	if !FileExists(config.ConfigDir + "/" + project + "/.git") {
+		return nil
 	}
-	return true
+	return setRemoteURL(project, credentials)
+}
+
+// getCredentials returns the credentials for a given project, if available
+func getCredentials(project string) (*GitCredentials, error) {
+	return credentialsStore.GetCredentials(project)
 }
 
-// StoreGitCredentials stores the specified git credentials as a secret in the cluster
-func StoreGitCredentials(project string, user string, token string, remoteURI string) error {
+// deleteCredentials deletes the credentials of a given project
+func deleteCredentials(project string) error {
+	return credentialsStore.DeleteCredentials(project)
+}
+
+// StoreCredentials stores the specified git credentials as a secret in the cluster, replacing existing credentials of the project
+func (secretCredentialsStore) StoreCredentials(project string, credentials *GitCredentials) error {
 
 	clientSet, err := getK8sClient()
 	if err != nil {
 		return err
 	}
 
-	credentials := &GitCredentials{
-		User:      user,
-		Token:     token,
-		RemoteURI: remoteURI,
-	}
-
 	credsEncoded, err := json.Marshal(credentials)
 	if err != nil {
 		return err
@@ -203,6 +262,10 @@ func StoreGitCredentials(project string, user string, token string, remoteURI st
 		Type: "Opaque",
 	}
 	_, err = clientSet.CoreV1().Secrets("keptn").Create(secret)
+	if k8serrors.IsAlreadyExists(err) {
+		// replace the credentials of the project
+		_, err = clientSet.CoreV1().Secrets("keptn").Update(secret)
+	}
 	if err != nil {
 		return err
 	}
@@ -210,7 +273,7 @@ func StoreGitCredentials(project string, user string, token string, remoteURI st
 }
 
 // GetCredentials returns the credentials for a given project, if available
-func GetCredentials(project string) (*GitCredentials, error) {
+func (secretCredentialsStore) GetCredentials(project string) (*GitCredentials, error) {
 	clientSet, err := getK8sClient()
 	if err != nil {
 		return nil, err
@@ -226,7 +289,7 @@ func GetCredentials(project string) (*GitCredentials, error) {
 	if err != nil {
 		return nil, err
 	}
-	if credentials.User != "" && credentials.Token != "" && credentials.RemoteURI != "" {
+	if credentials.Valid() {
 		return &credentials, nil
 	}
 	return nil, nil
@@ -248,7 +311,7 @@ func getK8sClient() (*kubernetes.Clientset, error) {
 }
 
 // DeleteCredentials deletes the credentials of a given project
-func DeleteCredentials(project string) error {
+func (secretCredentialsStore) DeleteCredentials(project string) error {
 	clientSet, err := getK8sClient()
 	if err != nil {
 		return err
@@ -261,8 +324,8 @@ func DeleteCredentials(project string) error {
 	return nil
 }
 
-// GetBranches returns a list of branches within the project
-func GetBranches(project string) ([]string, error) {
+// getBranches returns a list of branches within the project
+func getBranches(project string) ([]string, error) {
 	projectConfigPath := config.ConfigDir + "/" + project
 	out, err := utils.ExecuteCommandInDirectory("git", []string{"for-each-ref", `--format=%(refname:short)`, "refs/heads/*"}, projectConfigPath)
 	if err != nil {
@@ -272,3 +335,469 @@ func GetBranches(project string) ([]string, error) {
 
 	return branches, nil
 }
+
+// ErrFileNotFound is returned if a file does not exist in a branch
+var ErrFileNotFound = errors.New("file not found")
+
+// runGit executes a git command in the working copy of a project and returns its standard output. Unlike
+// utils.ExecuteCommandInDirectory, the output does not contain the messages git writes to the standard error
+func runGit(project string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = config.ConfigDir + "/" + project
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Error executing command git %s: %s %s", strings.Join(args, " "), err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// resolveBranch returns the latest commit of a branch. Branches that have not been checked out yet are resolved to
+// the branch of the upstream
+func resolveBranch(project string, branch string) (string, error) {
+	for _, ref := range []string{"refs/heads/" + branch, "refs/remotes/origin/" + branch} {
+		if out, err := runGit(project, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err == nil {
+			return strings.TrimSpace(string(out)), nil
+		}
+	}
+	return "", fmt.Errorf("branch %s does not exist", branch)
+}
+
+// ErrVersionNotFound is returned if a version does not exist in a branch
+var ErrVersionNotFound = errors.New("version not found")
+
+var versionPattern = regexp.MustCompile("^[0-9a-fA-F]{4,40}$")
+
+// resolveVersion returns the commit of a version of a branch. An empty version resolves to the latest commit of the
+// branch; other versions have to be (abbreviated) hashes of commits in the history of the branch
+func resolveVersion(project string, branch string, version string) (string, error) {
+	commit, err := resolveBranch(project, branch)
+	if err != nil || version == "" {
+		return commit, err
+	}
+	if !versionPattern.MatchString(version) {
+		return "", ErrVersionNotFound
+	}
+	out, err := runGit(project, "rev-parse", "--verify", "--quiet", version+"^{commit}")
+	if err != nil {
+		return "", ErrVersionNotFound
+	}
+	version = strings.TrimSpace(string(out))
+	if _, err := runGit(project, "merge-base", "--is-ancestor", version, commit); err != nil {
+		return "", ErrVersionNotFound
+	}
+	return version, nil
+}
+
+// branchExists checks if a branch exists in the project, without checking it out
+func branchExists(project string, branch string) bool {
+	_, err := resolveBranch(project, branch)
+	return err == nil
+}
+
+// getObject returns the name (<commit>:<path>) and the type of an object in a version of a branch
+func getObject(project string, branch string, version string, path string) (string, string, error) {
+	commit, err := resolveVersion(project, branch, version)
+	if err != nil {
+		return "", "", err
+	}
+	object := commit + ":" + strings.TrimPrefix(path, "/")
+	out, err := runGit(project, "cat-file", "-t", object)
+	if err != nil {
+		return "", "", ErrFileNotFound
+	}
+	return object, strings.TrimSpace(string(out)), nil
+}
+
+// directoryExistsInBranch checks if a directory exists in a branch, without checking it out
+func directoryExistsInBranch(project string, branch string, path string) bool {
+	_, objectType, err := getObject(project, branch, "", path)
+	return err == nil && objectType == "tree"
+}
+
+// getFileAtVersion reads a file from a version of a branch; an empty version reads the latest version. ErrVersionNotFound
+// is returned if the version is not part of the branch, ErrFileNotFound if the file does not exist in the version
+func getFileAtVersion(project string, branch string, version string, path string) ([]byte, error) {
+	object, objectType, err := getObject(project, branch, version, path)
+	if err != nil {
+		return nil, err
+	}
+	if objectType != "blob" {
+		return nil, ErrFileNotFound
+	}
+	return runGit(project, "cat-file", "blob", object)
+}
+
+// listFilesInBranch returns the paths of all files within a directory of a branch, relative to the directory,
+// without checking the branch out. An empty directory lists all files of the branch
+func listFilesInBranch(project string, branch string, directory string) ([]string, error) {
+	ref, err := resolveBranch(project, branch)
+	if err != nil {
+		return nil, err
+	}
+	object := ref
+	if directory = strings.Trim(directory, "/"); directory != "" {
+		object = ref + ":" + directory
+	}
+	out, err := runGit(project, "ls-tree", "-r", "--name-only", "-z", object)
+	if err != nil {
+		return nil, ErrFileNotFound
+	}
+	files := []string{}
+	for _, file := range strings.Split(string(out), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// getFileMetadata returns the metadata of all files within a directory of a branch by their path relative to the
+// directory. The content hash is the hash of the git blob, the version the latest commit that changed the file
+func getFileMetadata(project string, branch string, directory string) (map[string]*models.ResourceMetadata, error) {
+	ref, err := resolveBranch(project, branch)
+	if err != nil {
+		return nil, err
+	}
+	object := ref
+	if directory = strings.Trim(directory, "/"); directory != "" {
+		object = ref + ":" + directory
+	}
+	// entries have the format "<mode> <type> <hash> <size>\t<path>"
+	out, err := runGit(project, "ls-tree", "-r", "-l", "-z", object)
+	if err != nil {
+		return nil, ErrFileNotFound
+	}
+	metadata := map[string]*models.ResourceMetadata{}
+	for _, entry := range strings.Split(string(out), "\x00") {
+		tab := strings.Index(entry, "\t")
+		if tab < 0 {
+			continue
+		}
+		fields := strings.Fields(entry[:tab])
+		if len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		metadata[entry[tab+1:]] = &models.ResourceMetadata{Size: size, ContentHash: fields[2]}
+	}
+
+	if err := setFileVersions(project, ref, directory, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// setFileVersions sets the version of the files of a directory to the latest commit of ref that changed them. The
+// first commit listing a file is the latest one that changed it, hence the log is only read until each file has a
+// version, which usually requires a few recent commits instead of the whole history
+func setFileVersions(project string, ref string, directory string, metadata map[string]*models.ResourceMetadata) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+	args := []string{"-c", "core.quotePath=false", "log", "--format=%x1e%H", "--name-only", ref}
+	if directory != "" {
+		args = append(args, "--", directory)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = config.ConfigDir + "/" + project
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	remaining := len(metadata)
+	version := ""
+	scanner := bufio.NewScanner(stdout)
+	for remaining > 0 && scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "\x1e") {
+			version = line[1:]
+			continue
+		}
+		if directory != "" {
+			line = strings.TrimPrefix(line, directory+"/")
+		}
+		if m, ok := metadata[line]; ok && m.Version == "" {
+			m.Version = version
+			remaining--
+		}
+	}
+	if remaining == 0 {
+		// the remaining history is not needed
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("Error executing command git %s: %s %s", strings.Join(args, " "), err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// archiveDirectoryAtVersion returns a directory of a version of a branch as .tar.gz archive
+func archiveDirectoryAtVersion(project string, branch string, version string, directory string) ([]byte, error) {
+	directory = strings.Trim(directory, "/")
+	object, objectType, err := getObject(project, branch, version, directory)
+	if err != nil {
+		return nil, err
+	}
+	if objectType != "tree" {
+		return nil, ErrFileNotFound
+	}
+	prefix := directory[strings.LastIndex(directory, "/")+1:] + "/"
+	return runGit(project, "archive", "--format=tar.gz", "--prefix="+prefix, object)
+}
+
+// getFileHistory returns the versions of a branch that changed a file (or the files within a directory), starting
+// with the latest one. ErrFileNotFound is returned if the file has never been part of the branch
+func getFileHistory(project string, branch string, path string) ([]*models.ResourceVersion, error) {
+	commit, err := resolveBranch(project, branch)
+	if err != nil {
+		return nil, err
+	}
+	// fields are separated by the unit separator, commits by the record separator
+	out, err := runGit(project, "log", "--format=%H%x1f%an%x1f%aI%x1f%s%x1e", commit, "--", strings.Trim(path, "/"))
+	if err != nil {
+		return nil, err
+	}
+	versions := []*models.ResourceVersion{}
+	for _, record := range strings.Split(string(out), "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		timestamp, err := strfmt.ParseDateTime(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, &models.ResourceVersion{
+			Version:   fields[0],
+			Author:    fields[1],
+			Timestamp: timestamp,
+			Message:   fields[3],
+		})
+	}
+	if len(versions) == 0 {
+		return nil, ErrFileNotFound
+	}
+	return versions, nil
+}
+
+// getFileDiff returns the unified diff of a file (or the files within a directory) between two versions of a branch.
+// An empty toVersion compares with the latest version. ErrFileNotFound is returned if the file exists in neither
+// of the versions
+func getFileDiff(project string, branch string, fromVersion string, toVersion string, path string) (*models.ResourceDiff, error) {
+	if fromVersion == "" {
+		return nil, ErrVersionNotFound
+	}
+	from, err := resolveVersion(project, branch, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveVersion(project, branch, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	path = strings.Trim(path, "/")
+	_, errFrom := runGit(project, "cat-file", "-e", from+":"+path)
+	_, errTo := runGit(project, "cat-file", "-e", to+":"+path)
+	if errFrom != nil && errTo != nil {
+		return nil, ErrFileNotFound
+	}
+	out, err := runGit(project, "diff", "--no-color", "--no-ext-diff", from, to, "--", path)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ResourceDiff{
+		ResourceURI: path,
+		FromVersion: from,
+		ToVersion:   to,
+		Diff:        string(out),
+	}, nil
+}
+
+// updateFromUpstream fetches the branches of the upstream repository of a project and fast-forwards the local
+// branches to them. The caller has to hold the lock of the project; each branch is locked while it is updated
+func updateFromUpstream(project string) error {
+	credentials, err := getCredentials(project)
+	if err != nil || credentials == nil {
+		// no upstream has been defined for the project
+		return nil
+	}
+	if err := fetchUpstream(project, credentials); err != nil {
+		recordSync(project, "", err)
+		return err
+	}
+
+	branches, err := getBranches(project)
+	if err != nil {
+		return err
+	}
+	out, err := runGit(project, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return err
+	}
+	currentBranch := strings.TrimSpace(string(out))
+
+	var failed []string
+	for _, branch := range branches {
+		if branch == "" {
+			continue
+		}
+		if _, err := runGit(project, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch); err != nil {
+			// the branch has not been pushed to the upstream yet
+			continue
+		}
+		if err := fastForwardBranch(project, branch, branch == currentBranch); err != nil {
+			failed = append(failed, branch)
+		}
+	}
+	if len(failed) > 0 {
+		// diverged branches are merged with the upstream the next time they are changed
+		err = fmt.Errorf("Could not fast-forward branches %s to the upstream", strings.Join(failed, ", "))
+	}
+	recordSync(project, "", err)
+	return err
+}
+
+func fastForwardBranch(project string, branch string, checkedOut bool) error {
+	LockBranch(project, branch)
+	defer UnlockBranch(project, branch)
+	if checkedOut {
+		_, err := runGit(project, "merge", "--ff-only", "refs/remotes/origin/"+branch)
+		return err
+	}
+	_, err := runGit(project, "fetch", ".", "refs/remotes/origin/"+branch+":refs/heads/"+branch)
+	return err
+}
+
+// GitStorage stores each project in a git repository in config.ConfigDir, with a branch per stage, and the
+// credentials of its upstream as secret in the cluster
+type GitStorage struct{}
+
+// CreateProject initializes a new git repository for the project
+func (s *GitStorage) CreateProject(project string) error {
+	projectConfigPath := config.ConfigDir + "/" + project
+	if err := os.MkdirAll(projectConfigPath, os.ModePerm); err != nil {
+		return err
+	}
+	_, err := utils.ExecuteCommandInDirectory("git", []string{"init"}, projectConfigPath)
+	return err
+}
+
+// CloneProject clones the upstream repository of the project
+func (s *GitStorage) CloneProject(project string, credentials *GitCredentials) error {
+	return cloneRepo(project, credentials)
+}
+
+// DeleteProject removes the git repository of the project
+func (s *GitStorage) DeleteProject(project string) error {
+	if err := os.RemoveAll(config.ConfigDir + "/" + project); err != nil {
+		return err
+	}
+	DeleteSyncStatus(project)
+	return nil
+}
+
+func (s *GitStorage) GetBranches(project string) ([]string, error) {
+	return getBranches(project)
+}
+
+func (s *GitStorage) BranchExists(project string, branch string) bool {
+	return branchExists(project, branch)
+}
+
+func (s *GitStorage) CheckoutBranch(project string, branch string) error {
+	return checkoutBranch(project, branch)
+}
+
+func (s *GitStorage) CreateBranch(project string, branch string, sourceBranch string) error {
+	return createBranch(project, branch, sourceBranch)
+}
+
+func (s *GitStorage) DeleteBranch(project string, branch string) error {
+	return deleteBranch(project, branch)
+}
+
+func (s *GitStorage) StageAndCommitAll(project string, message string) error {
+	return stageAndCommitAll(project, message)
+}
+
+func (s *GitStorage) GetCurrentVersion(project string) (string, error) {
+	return getCurrentVersion(project)
+}
+
+func (s *GitStorage) PromoteDirectory(project string, sourceBranch string, targetBranch string, directory string, message string) error {
+	return promoteDirectory(project, sourceBranch, targetBranch, directory, message)
+}
+
+func (s *GitStorage) DirectoryExistsInBranch(project string, branch string, path string) bool {
+	return directoryExistsInBranch(project, branch, path)
+}
+
+func (s *GitStorage) GetFileAtVersion(project string, branch string, version string, path string) ([]byte, error) {
+	return getFileAtVersion(project, branch, version, path)
+}
+
+func (s *GitStorage) ListFilesInBranch(project string, branch string, directory string) ([]string, error) {
+	return listFilesInBranch(project, branch, directory)
+}
+
+func (s *GitStorage) GetFileMetadata(project string, branch string, directory string) (map[string]*models.ResourceMetadata, error) {
+	return getFileMetadata(project, branch, directory)
+}
+
+func (s *GitStorage) ArchiveDirectoryAtVersion(project string, branch string, version string, directory string) ([]byte, error) {
+	return archiveDirectoryAtVersion(project, branch, version, directory)
+}
+
+func (s *GitStorage) GetFileHistory(project string, branch string, path string) ([]*models.ResourceVersion, error) {
+	return getFileHistory(project, branch, path)
+}
+
+func (s *GitStorage) GetFileDiff(project string, branch string, fromVersion string, toVersion string, path string) (*models.ResourceDiff, error) {
+	return getFileDiff(project, branch, fromVersion, toVersion, path)
+}
+
+func (s *GitStorage) VerifyCredentials(credentials *GitCredentials) error {
+	return verifyCredentials(credentials)
+}
+
+func (s *GitStorage) PushBranches(project string, credentials *GitCredentials) error {
+	return pushBranches(project, credentials)
+}
+
+func (s *GitStorage) UpdateFromUpstream(project string) error {
+	return updateFromUpstream(project)
+}
+
+func (s *GitStorage) GetSyncStatus(project string) (*models.SyncStatus, error) {
+	return getSyncStatus(project)
+}
+
+func (s *GitStorage) StoreCredentials(project string, credentials *GitCredentials) error {
+	return storeGitCredentials(project, credentials)
+}
+
+func (s *GitStorage) GetCredentials(project string) (*GitCredentials, error) {
+	return getCredentials(project)
+}
+
+func (s *GitStorage) DeleteCredentials(project string) error {
+	return deleteCredentials(project)
+}
@@ -0,0 +1,673 @@
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/models"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// errUpstreamNotSupported is returned by the MemoryStorage for all operations that require an upstream repository
+var errUpstreamNotSupported = errors.New("upstream repositories are not supported by the memory storage")
+
+// MemoryStorage keeps the versions of all projects in memory, hence it neither requires git nor a cluster and is
+// intended for tests and local development. The working copies of the projects are stored in config.ConfigDir like
+// for the GitStorage. Upstream repositories are not supported, and promotions fail if both branches changed the same
+// file, even if they changed different lines
+type MemoryStorage struct {
+	mutex    sync.Mutex
+	projects map[string]*memoryProject
+}
+
+type memoryProject struct {
+	// latest version by branch
+	branches   map[string]string
+	versions   map[string]*memoryVersion
+	checkedOut string
+}
+
+type memoryVersion struct {
+	id        string
+	parent    string
+	message   string
+	timestamp time.Time
+	// file contents by path
+	files map[string][]byte
+}
+
+// NewMemoryStorage returns an empty MemoryStorage
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{projects: map[string]*memoryProject{}}
+}
+
+func (s *MemoryStorage) getProject(project string) (*memoryProject, error) {
+	p, ok := s.projects[project]
+	if !ok {
+		return nil, fmt.Errorf("project %s does not exist", project)
+	}
+	return p, nil
+}
+
+// resolveVersion returns a version of a branch. An empty version resolves to the latest version of the branch; other
+// versions have to be (abbreviated) identifiers of versions in the history of the branch
+func (s *MemoryStorage) resolveVersion(project string, branch string, version string) (*memoryVersion, error) {
+	p, err := s.getProject(project)
+	if err != nil {
+		return nil, err
+	}
+	latest, ok := p.branches[branch]
+	if !ok {
+		return nil, fmt.Errorf("branch %s does not exist", branch)
+	}
+	if version == "" {
+		return p.versions[latest], nil
+	}
+	if !versionPattern.MatchString(version) {
+		return nil, ErrVersionNotFound
+	}
+	version = strings.ToLower(version)
+	for v := p.versions[latest]; v != nil; v = p.versions[v.parent] {
+		if strings.HasPrefix(v.id, version) {
+			return v, nil
+		}
+	}
+	return nil, ErrVersionNotFound
+}
+
+// CreateProject creates an empty working copy for the project
+func (s *MemoryStorage) CreateProject(project string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := os.MkdirAll(config.ConfigDir+"/"+project, os.ModePerm); err != nil {
+		return err
+	}
+	s.projects[project] = &memoryProject{
+		branches:   map[string]string{},
+		versions:   map[string]*memoryVersion{},
+		checkedOut: "master",
+	}
+	return nil
+}
+
+func (s *MemoryStorage) CloneProject(project string, credentials *GitCredentials) error {
+	return errUpstreamNotSupported
+}
+
+// DeleteProject removes the working copy and all versions of the project
+func (s *MemoryStorage) DeleteProject(project string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := os.RemoveAll(config.ConfigDir + "/" + project); err != nil {
+		return err
+	}
+	delete(s.projects, project)
+	return nil
+}
+
+func (s *MemoryStorage) GetBranches(project string) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.getProject(project)
+	if err != nil {
+		return nil, err
+	}
+	branches := []string{}
+	for branch := range p.branches {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+	return branches, nil
+}
+
+func (s *MemoryStorage) BranchExists(project string, branch string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, err := s.resolveVersion(project, branch, "")
+	return err == nil
+}
+
+// CheckoutBranch replaces the files of the working copy with the latest version of the branch
+func (s *MemoryStorage) CheckoutBranch(project string, branch string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.checkoutBranch(project, branch)
+}
+
+func (s *MemoryStorage) checkoutBranch(project string, branch string) error {
+	version, err := s.resolveVersion(project, branch, "")
+	if err != nil {
+		return err
+	}
+	projectConfigPath := config.ConfigDir + "/" + project
+	entries, err := ioutil.ReadDir(projectConfigPath)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(projectConfigPath, entry.Name())); err != nil {
+			return err
+		}
+	}
+	for path, content := range version.files {
+		if err := WriteFile(projectConfigPath+"/"+path, content); err != nil {
+			return err
+		}
+	}
+	s.projects[project].checkedOut = branch
+	return nil
+}
+
+func (s *MemoryStorage) CreateBranch(project string, branch string, sourceBranch string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.checkoutBranch(project, sourceBranch); err != nil {
+		return err
+	}
+	p := s.projects[project]
+	if _, ok := p.branches[branch]; ok {
+		return fmt.Errorf("branch %s already exists", branch)
+	}
+	p.branches[branch] = p.branches[sourceBranch]
+	p.checkedOut = branch
+	return nil
+}
+
+func (s *MemoryStorage) DeleteBranch(project string, branch string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.checkoutBranch(project, "master"); err != nil {
+		return err
+	}
+	p := s.projects[project]
+	if _, ok := p.branches[branch]; !ok {
+		return fmt.Errorf("branch %s does not exist", branch)
+	}
+	delete(p.branches, branch)
+	return nil
+}
+
+// StageAndCommitAll creates a new version of the checked out branch containing the files of the working copy, if
+// they have been changed
+func (s *MemoryStorage) StageAndCommitAll(project string, message string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.stageAndCommitAll(project, message)
+}
+
+func (s *MemoryStorage) stageAndCommitAll(project string, message string) error {
+	p, err := s.getProject(project)
+	if err != nil {
+		return err
+	}
+	projectConfigPath := config.ConfigDir + "/" + project
+	files := map[string][]byte{}
+	err = filepath.Walk(projectConfigPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(projectConfigPath, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)] = content
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	parent := p.branches[p.checkedOut]
+	if latest, ok := p.versions[parent]; ok && equalFiles(latest.files, files) {
+		// nothing has been changed
+		return nil
+	}
+	version := &memoryVersion{parent: parent, message: message, timestamp: time.Now(), files: files}
+	version.id = version.hash()
+	p.versions[version.id] = version
+	p.branches[p.checkedOut] = version.id
+	return nil
+}
+
+// hash returns an identifier of the version that is derived from its content, its parent and its creation time
+func (v *memoryVersion) hash() string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00", v.parent, v.message, v.timestamp.UnixNano())
+	for _, path := range sortedPaths(v.files) {
+		fmt.Fprintf(h, "%s\x00%d\x00", path, len(v.files[path]))
+		h.Write(v.files[path])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *MemoryStorage) GetCurrentVersion(project string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.getProject(project)
+	if err != nil {
+		return "", err
+	}
+	latest, ok := p.branches[p.checkedOut]
+	if !ok {
+		return "", fmt.Errorf("branch %s does not contain any versions", p.checkedOut)
+	}
+	return latest, nil
+}
+
+// PromoteDirectory merges the changes of a directory in the source branch into the target branch file by file,
+// relative to the version of the source branch that has been promoted last (or the common ancestor of both branches)
+func (s *MemoryStorage) PromoteDirectory(project string, sourceBranch string, targetBranch string, directory string, message string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	directory = strings.Trim(directory, "/")
+	sourceVersion, err := s.resolveVersion(project, sourceBranch, "")
+	if err != nil {
+		return err
+	}
+	targetVersion, err := s.resolveVersion(project, targetBranch, "")
+	if err != nil {
+		return err
+	}
+	p := s.projects[project]
+	baseVersion := s.getPromotionBase(p, sourceBranch, targetVersion, sourceVersion, directory)
+
+	base := map[string][]byte{}
+	if baseVersion != nil {
+		base = filesInDirectory(baseVersion.files, directory)
+	}
+	target := filesInDirectory(targetVersion.files, directory)
+	source := filesInDirectory(sourceVersion.files, directory)
+
+	paths := map[string]bool{}
+	for _, files := range []map[string][]byte{base, target, source} {
+		for path := range files {
+			paths[path] = true
+		}
+	}
+
+	changes := map[string][]byte{}
+	deletions := []string{}
+	conflicts := []string{}
+	for path := range paths {
+		baseFile, inBase := base[path]
+		targetFile, inTarget := target[path]
+		sourceFile, inSource := source[path]
+		switch {
+		case inSource == inBase && bytes.Equal(sourceFile, baseFile),
+			inSource == inTarget && bytes.Equal(sourceFile, targetFile):
+			// no changes in the source branch, or the same changes in both branches
+		case inTarget == inBase && bytes.Equal(targetFile, baseFile):
+			// changes in the source branch only
+			if inSource {
+				changes[path] = sourceFile
+			} else {
+				deletions = append(deletions, path)
+			}
+		default:
+			conflicts = append(conflicts, path)
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return &MergeConflictError{Paths: conflicts}
+	}
+
+	projectConfigPath := config.ConfigDir + "/" + project
+	for path, content := range changes {
+		if err := WriteFile(projectConfigPath+"/"+path, content); err != nil {
+			return err
+		}
+	}
+	for _, path := range deletions {
+		if err := DeleteFile(projectConfigPath + "/" + path); err != nil {
+			return err
+		}
+	}
+	return s.stageAndCommitAll(project, getPromotionMessage(message, directory, sourceBranch, sourceVersion.id))
+}
+
+// getPromotionBase returns the version of the source branch that has been promoted to the target version last, or
+// the common ancestor of both versions
+func (s *MemoryStorage) getPromotionBase(p *memoryProject, sourceBranch string, targetVersion *memoryVersion, sourceVersion *memoryVersion, directory string) *memoryVersion {
+	for v := targetVersion; v != nil; v = p.versions[v.parent] {
+		if promoted, ok := getPromotedVersion(v.message, directory, sourceBranch); ok {
+			return p.versions[promoted]
+		}
+	}
+	ancestors := map[string]bool{}
+	for v := sourceVersion; v != nil; v = p.versions[v.parent] {
+		ancestors[v.id] = true
+	}
+	for v := targetVersion; v != nil; v = p.versions[v.parent] {
+		if ancestors[v.id] {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) DirectoryExistsInBranch(project string, branch string, path string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	version, err := s.resolveVersion(project, branch, "")
+	if err != nil {
+		return false
+	}
+	path = strings.Trim(path, "/")
+	for file := range version.files {
+		if path == "" || strings.HasPrefix(file, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MemoryStorage) GetFileAtVersion(project string, branch string, version string, path string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	v, err := s.resolveVersion(project, branch, version)
+	if err != nil {
+		return nil, err
+	}
+	content, ok := v.files[strings.TrimPrefix(path, "/")]
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	return content, nil
+}
+
+func (s *MemoryStorage) ListFilesInBranch(project string, branch string, directory string) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	version, err := s.resolveVersion(project, branch, "")
+	if err != nil {
+		return nil, err
+	}
+	directory = strings.Trim(directory, "/")
+	files := []string{}
+	for _, path := range sortedPaths(filesInDirectory(version.files, directory)) {
+		if directory != "" {
+			path = strings.TrimPrefix(path, directory+"/")
+		}
+		files = append(files, path)
+	}
+	if directory != "" && len(files) == 0 {
+		return nil, ErrFileNotFound
+	}
+	return files, nil
+}
+
+func (s *MemoryStorage) GetFileMetadata(project string, branch string, directory string) (map[string]*models.ResourceMetadata, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	version, err := s.resolveVersion(project, branch, "")
+	if err != nil {
+		return nil, err
+	}
+	directory = strings.Trim(directory, "/")
+	files := filesInDirectory(version.files, directory)
+	if directory != "" && len(files) == 0 {
+		return nil, ErrFileNotFound
+	}
+	p := s.projects[project]
+	metadata := map[string]*models.ResourceMetadata{}
+	for path, content := range files {
+		// the file has been changed last by the oldest of the preceding versions that contain its current content
+		latest := version
+		for v := p.versions[version.parent]; v != nil; v = p.versions[v.parent] {
+			if previous, ok := v.files[path]; !ok || !bytes.Equal(previous, content) {
+				break
+			}
+			latest = v
+		}
+		relativePath := path
+		if directory != "" {
+			relativePath = strings.TrimPrefix(path, directory+"/")
+		}
+		metadata[relativePath] = &models.ResourceMetadata{
+			Size:        int64(len(content)),
+			ContentHash: blobHash(content),
+			Version:     latest.id,
+		}
+	}
+	return metadata, nil
+}
+
+func (s *MemoryStorage) ArchiveDirectoryAtVersion(project string, branch string, version string, directory string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	v, err := s.resolveVersion(project, branch, version)
+	if err != nil {
+		return nil, err
+	}
+	directory = strings.Trim(directory, "/")
+	files := filesInDirectory(v.files, directory)
+	if len(files) == 0 {
+		return nil, ErrFileNotFound
+	}
+	prefix := directory[strings.LastIndex(directory, "/")+1:] + "/"
+
+	archive := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	directories := map[string]bool{}
+	for _, path := range sortedPaths(files) {
+		name := prefix + strings.TrimPrefix(path, directory+"/")
+		// add the parent directories before the file
+		for i := 0; i < len(name); i++ {
+			if name[i] != '/' || directories[name[:i+1]] {
+				continue
+			}
+			directories[name[:i+1]] = true
+			header := &tar.Header{Typeflag: tar.TypeDir, Name: name[:i+1], Mode: 0755, ModTime: v.timestamp}
+			if err := tarWriter.WriteHeader(header); err != nil {
+				return nil, err
+			}
+		}
+		header := &tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(files[path])), ModTime: v.timestamp}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tarWriter.Write(files[path]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return archive.Bytes(), nil
+}
+
+func (s *MemoryStorage) GetFileHistory(project string, branch string, path string) ([]*models.ResourceVersion, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	version, err := s.resolveVersion(project, branch, "")
+	if err != nil {
+		return nil, err
+	}
+	path = strings.Trim(path, "/")
+	p := s.projects[project]
+	versions := []*models.ResourceVersion{}
+	for v := version; v != nil; v = p.versions[v.parent] {
+		previous := map[string][]byte{}
+		if parent, ok := p.versions[v.parent]; ok {
+			previous = filesInDirectory(parent.files, path)
+		}
+		if equalFiles(filesInDirectory(v.files, path), previous) {
+			continue
+		}
+		versions = append(versions, &models.ResourceVersion{
+			Version:   v.id,
+			Author:    "keptn",
+			Timestamp: strfmt.DateTime(v.timestamp),
+			Message:   strings.SplitN(v.message, "\n", 2)[0],
+		})
+	}
+	if len(versions) == 0 {
+		return nil, ErrFileNotFound
+	}
+	return versions, nil
+}
+
+func (s *MemoryStorage) GetFileDiff(project string, branch string, fromVersion string, toVersion string, path string) (*models.ResourceDiff, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if fromVersion == "" {
+		return nil, ErrVersionNotFound
+	}
+	from, err := s.resolveVersion(project, branch, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.resolveVersion(project, branch, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	path = strings.Trim(path, "/")
+	fromFiles := filesInDirectory(from.files, path)
+	toFiles := filesInDirectory(to.files, path)
+	if len(fromFiles) == 0 && len(toFiles) == 0 {
+		return nil, ErrFileNotFound
+	}
+
+	paths := map[string][]byte{}
+	for _, files := range []map[string][]byte{fromFiles, toFiles} {
+		for file := range files {
+			paths[file] = nil
+		}
+	}
+	diff := &strings.Builder{}
+	for _, file := range sortedPaths(paths) {
+		fromFile, inFrom := fromFiles[file]
+		toFile, inTo := toFiles[file]
+		if inFrom == inTo && bytes.Equal(fromFile, toFile) {
+			continue
+		}
+		unifiedDiff := difflib.UnifiedDiff{
+			A:        splitLines(fromFile),
+			B:        splitLines(toFile),
+			FromFile: "a/" + file,
+			ToFile:   "b/" + file,
+			Context:  3,
+		}
+		if !inFrom {
+			unifiedDiff.FromFile = "/dev/null"
+		}
+		if !inTo {
+			unifiedDiff.ToFile = "/dev/null"
+		}
+		fmt.Fprintf(diff, "diff --git a/%s b/%s\n", file, file)
+		if err := difflib.WriteUnifiedDiff(diff, unifiedDiff); err != nil {
+			return nil, err
+		}
+	}
+	return &models.ResourceDiff{
+		ResourceURI: path,
+		FromVersion: from.id,
+		ToVersion:   to.id,
+		Diff:        diff.String(),
+	}, nil
+}
+
+func (s *MemoryStorage) VerifyCredentials(credentials *GitCredentials) error {
+	return errUpstreamNotSupported
+}
+
+func (s *MemoryStorage) PushBranches(project string, credentials *GitCredentials) error {
+	return errUpstreamNotSupported
+}
+
+func (s *MemoryStorage) UpdateFromUpstream(project string) error {
+	return nil
+}
+
+func (s *MemoryStorage) GetSyncStatus(project string) (*models.SyncStatus, error) {
+	return &models.SyncStatus{Status: models.SyncStatusStatusNoUpstream}, nil
+}
+
+func (s *MemoryStorage) StoreCredentials(project string, credentials *GitCredentials) error {
+	return errUpstreamNotSupported
+}
+
+func (s *MemoryStorage) GetCredentials(project string) (*GitCredentials, error) {
+	return nil, nil
+}
+
+func (s *MemoryStorage) DeleteCredentials(project string) error {
+	return nil
+}
+
+// filesInDirectory returns the files of a version that are located within a directory, or the file itself if the
+// path does not denote a directory. An empty directory returns all files
+func filesInDirectory(files map[string][]byte, directory string) map[string][]byte {
+	result := map[string][]byte{}
+	for path, content := range files {
+		if directory == "" || path == directory || strings.HasPrefix(path, directory+"/") {
+			result[path] = content
+		}
+	}
+	return result
+}
+
+func equalFiles(a map[string][]byte, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, content := range a {
+		if other, ok := b[path]; !ok || !bytes.Equal(content, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// blobHash returns the hash git uses for a file with the given content, hence both storages return the same hashes
+func blobHash(content []byte) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "blob %d\x00", len(content))
+	hash.Write(content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func sortedPaths(files map[string][]byte) []string {
+	paths := []string{}
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// splitLines splits the content of a file into lines for a diff, each ending with a line break
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return []string{}
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
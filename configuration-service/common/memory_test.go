@@ -0,0 +1,182 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/models"
+)
+
+// setupMemoryStorage creates a memory storage with a project sockshop, whose master branch contains the given files.
+// It returns the storage and a function restoring config.ConfigDir
+func setupMemoryStorage(t *testing.T, files map[string]string) (*MemoryStorage, func()) {
+	dir, err := ioutil.TempDir("", "configuration-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir := config.ConfigDir
+	config.ConfigDir = dir
+
+	s := NewMemoryStorage()
+	if err := s.CreateProject("sockshop"); err != nil {
+		t.Fatal(err)
+	}
+	commitMemoryFiles(t, s, files)
+	return s, func() {
+		config.ConfigDir = configDir
+		os.RemoveAll(dir)
+	}
+}
+
+// commitMemoryFiles commits files to the checked out branch of project sockshop
+func commitMemoryFiles(t *testing.T, s *MemoryStorage, files map[string]string) string {
+	for path, content := range files {
+		if err := WriteFile(filepath.Join(config.ConfigDir, "sockshop", path), []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.StageAndCommitAll("sockshop", "Changed files"); err != nil {
+		t.Fatal(err)
+	}
+	version, err := s.GetCurrentVersion("sockshop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
+
+func TestMemoryStorageBranches(t *testing.T) {
+	s, teardown := setupMemoryStorage(t, map[string]string{"shipyard.yaml": "stages: []\n"})
+	defer teardown()
+
+	if err := s.CreateBranch("sockshop", "dev", "master"); err != nil {
+		t.Fatal(err)
+	}
+	commitMemoryFiles(t, s, map[string]string{"carts/values.yaml": "replicas: 1\n"})
+	if branches, _ := s.GetBranches("sockshop"); !reflect.DeepEqual(branches, []string{"dev", "master"}) {
+		t.Errorf("Expected branches dev and master, got %v", branches)
+	}
+	if !s.DirectoryExistsInBranch("sockshop", "dev", "carts") || s.DirectoryExistsInBranch("sockshop", "master", "carts") {
+		t.Errorf("Expected carts to exist in dev only")
+	}
+
+	// checking out a branch replaces the working copy
+	if err := s.CheckoutBranch("sockshop", "master"); err != nil {
+		t.Fatal(err)
+	}
+	if FileExists(filepath.Join(config.ConfigDir, "sockshop", "carts", "values.yaml")) {
+		t.Errorf("Expected the files of dev to be removed from the working copy")
+	}
+
+	if err := s.DeleteBranch("sockshop", "dev"); err != nil {
+		t.Fatal(err)
+	}
+	if s.BranchExists("sockshop", "dev") {
+		t.Errorf("Expected dev to be deleted")
+	}
+	if err := s.CheckoutBranch("sockshop", "dev"); err == nil {
+		t.Errorf("Expected the deleted branch not to be checked out")
+	}
+}
+
+func TestMemoryStorageVersions(t *testing.T) {
+	s, teardown := setupMemoryStorage(t, map[string]string{"carts/values.yaml": "replicas: 1\nimage: carts:0.1\n"})
+	defer teardown()
+	first, _ := s.GetCurrentVersion("sockshop")
+
+	second := commitMemoryFiles(t, s, map[string]string{"carts/values.yaml": "replicas: 1\nimage: carts:0.2\n"})
+	third := commitMemoryFiles(t, s, map[string]string{"carts/slo.yaml": "objectives: []\n"})
+	// unchanged files do not create a new version
+	if version := commitMemoryFiles(t, s, map[string]string{}); version != third {
+		t.Errorf("Expected no new version without changes")
+	}
+
+	content, err := s.GetFileAtVersion("sockshop", "master", first[:8], "carts/values.yaml")
+	if err != nil || string(content) != "replicas: 1\nimage: carts:0.1\n" {
+		t.Errorf("Expected the first version of values.yaml, got %s %v", content, err)
+	}
+	if _, err := s.GetFileAtVersion("sockshop", "master", first, "carts/slo.yaml"); err != ErrFileNotFound {
+		t.Errorf("Expected slo.yaml not to exist in the first version, got %v", err)
+	}
+	if _, err := s.GetFileAtVersion("sockshop", "master", "0123abcd", "carts/values.yaml"); err != ErrVersionNotFound {
+		t.Errorf("Expected an unknown version not to be found, got %v", err)
+	}
+
+	history, err := s.GetFileHistory("sockshop", "master", "carts/values.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].Version != second || history[1].Version != first {
+		t.Errorf("Expected two versions of values.yaml, got %+v", history)
+	}
+	if history, _ := s.GetFileHistory("sockshop", "master", "carts"); len(history) != 3 {
+		t.Errorf("Expected three versions of carts, got %d", len(history))
+	}
+
+	diff, err := s.GetFileDiff("sockshop", "master", first, second, "carts/values.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDiff := "diff --git a/carts/values.yaml b/carts/values.yaml\n--- a/carts/values.yaml\n+++ b/carts/values.yaml\n" +
+		"@@ -1,2 +1,2 @@\n replicas: 1\n-image: carts:0.1\n+image: carts:0.2\n"
+	if diff.Diff != expectedDiff {
+		t.Errorf("Unexpected diff:\n%s", diff.Diff)
+	}
+
+	files, err := s.ListFilesInBranch("sockshop", "master", "carts")
+	if err != nil || !reflect.DeepEqual(files, []string{"slo.yaml", "values.yaml"}) {
+		t.Errorf("Expected slo.yaml and values.yaml, got %v %v", files, err)
+	}
+	metadata, err := s.GetFileMetadata("sockshop", "master", "carts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the content hash equals the hash of the git blob
+	expectedSLO := models.ResourceMetadata{Size: 15, Version: third, ContentHash: "9c3ee566766f2c57a87713d069d6eb5f610ea5a2"}
+	if len(metadata) != 2 || *metadata["slo.yaml"] != expectedSLO || metadata["values.yaml"].Version != second {
+		t.Errorf("Unexpected metadata of carts: %+v", metadata)
+	}
+	if _, err := s.ArchiveDirectoryAtVersion("sockshop", "master", "", "orders"); err != ErrFileNotFound {
+		t.Errorf("Expected orders not to be archived, got %v", err)
+	}
+}
+
+func TestMemoryStoragePromoteDirectory(t *testing.T) {
+	s, teardown := setupMemoryStorage(t, map[string]string{
+		"carts/values.yaml": "replicas: 1\n",
+		"carts/slo.yaml":    "objectives: []\n",
+	})
+	defer teardown()
+	s.CreateBranch("sockshop", "staging", "master")
+	s.CreateBranch("sockshop", "dev", "master")
+	commitMemoryFiles(t, s, map[string]string{"carts/values.yaml": "replicas: 2\n"})
+	s.CheckoutBranch("sockshop", "staging")
+	commitMemoryFiles(t, s, map[string]string{"carts/slo.yaml": "objectives: [response_time]\n"})
+
+	if err := s.PromoteDirectory("sockshop", "dev", "staging", "carts", "Promoted carts"); err != nil {
+		t.Fatalf("Expected carts to be promoted, got %v", err)
+	}
+	content, _ := s.GetFileAtVersion("sockshop", "staging", "", "carts/values.yaml")
+	if string(content) != "replicas: 2\n" {
+		t.Errorf("Expected values.yaml of dev, got %s", content)
+	}
+	content, _ = s.GetFileAtVersion("sockshop", "staging", "", "carts/slo.yaml")
+	if string(content) != "objectives: [response_time]\n" {
+		t.Errorf("Expected slo.yaml of staging, got %s", content)
+	}
+
+	// only the changes since the last promotion are merged, hence changing the same file in both branches conflicts
+	commitMemoryFiles(t, s, map[string]string{"carts/values.yaml": "replicas: 3\n"})
+	s.CheckoutBranch("sockshop", "dev")
+	commitMemoryFiles(t, s, map[string]string{"carts/values.yaml": "replicas: 4\n"})
+	s.CheckoutBranch("sockshop", "staging")
+	err := s.PromoteDirectory("sockshop", "dev", "staging", "carts", "Promoted carts")
+	if conflict, ok := err.(*MergeConflictError); !ok || strings.Join(conflict.Paths, ",") != "carts/values.yaml" {
+		t.Errorf("Expected values.yaml to conflict, got %v", err)
+	}
+}
@@ -2,14 +2,74 @@ package common
 
 import "sync"
 
-var mutex = &sync.Mutex{}
+// projectLock coordinates the access to the git repository of a project. Operations modifying the repository are
+// serialized by the lock of the working copy; reads do not use the working copy and only wait for operations
+// modifying the branch they read from
+type projectLock struct {
+	workingCopy sync.Mutex
+	branches    map[string]*sync.RWMutex
+}
+
+var projectLocks = map[string]*projectLock{}
+var projectLocksMutex = &sync.Mutex{}
+
+func getProjectLock(project string) *projectLock {
+	lock, ok := projectLocks[project]
+	if !ok {
+		lock = &projectLock{branches: map[string]*sync.RWMutex{}}
+		projectLocks[project] = lock
+	}
+	return lock
+}
+
+func getBranchLock(project string, branch string) *sync.RWMutex {
+	projectLocksMutex.Lock()
+	defer projectLocksMutex.Unlock()
+	lock := getProjectLock(project)
+	branchLock, ok := lock.branches[branch]
+	if !ok {
+		branchLock = &sync.RWMutex{}
+		lock.branches[branch] = branchLock
+	}
+	return branchLock
+}
+
+// LockProject locks the working copy of a project. The lock has to be held by all operations that modify the
+// repository of the project, e.g. by checking out a branch, committing changes or pulling from the upstream.
+// Branches that are modified have to be locked with LockBranch in addition
+func LockProject(project string) {
+	projectLocksMutex.Lock()
+	lock := getProjectLock(project)
+	projectLocksMutex.Unlock()
+	lock.workingCopy.Lock()
+}
+
+// UnlockProject releases the lock of the working copy of a project
+func UnlockProject(project string) {
+	projectLocksMutex.Lock()
+	lock := getProjectLock(project)
+	projectLocksMutex.Unlock()
+	lock.workingCopy.Unlock()
+}
+
+// LockBranch locks a branch of a project exclusively while it is modified. It must only be acquired while holding
+// the lock of the project
+func LockBranch(project string, branch string) {
+	getBranchLock(project, branch).Lock()
+}
+
+// UnlockBranch releases the exclusive lock of a branch
+func UnlockBranch(project string, branch string) {
+	getBranchLock(project, branch).Unlock()
+}
 
-// Lock locks the mutex
-func Lock() {
-	mutex.Lock()
+// RLockBranch locks a branch of a project for reading. Reads of a branch run concurrently with each other and with
+// operations modifying other branches of the project
+func RLockBranch(project string, branch string) {
+	getBranchLock(project, branch).RLock()
 }
 
-// Unlock unlocks the mutex
-func UnLock() {
-	mutex.Unlock()
+// RUnlockBranch releases the read lock of a branch
+func RUnlockBranch(project string, branch string) {
+	getBranchLock(project, branch).RUnlock()
 }
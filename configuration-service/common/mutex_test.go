@@ -0,0 +1,65 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func acquiredWithin(lock func(), timeout time.Duration) bool {
+	acquired := make(chan bool)
+	go func() {
+		lock()
+		acquired <- true
+	}()
+	select {
+	case <-acquired:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// TestProjectLocks checks that the lock of a project does not block other projects
+func TestProjectLocks(t *testing.T) {
+	LockProject("sockshop")
+
+	if !acquiredWithin(func() { LockProject("simpleproject") }, time.Second) {
+		t.Fatal("Expected lock of project simpleproject not to be blocked by project sockshop")
+	}
+	UnlockProject("simpleproject")
+	UnlockProject("sockshop")
+}
+
+// TestBranchLocks checks that reads of a branch wait for writers of the same branch only and that reads of the same
+// branch can run concurrently
+func TestBranchLocks(t *testing.T) {
+	LockProject("sockshop")
+	LockBranch("sockshop", "staging")
+
+	if !acquiredWithin(func() { RLockBranch("sockshop", "dev") }, time.Second) {
+		t.Fatal("Expected read lock of branch dev not to be blocked by a writer of branch staging")
+	}
+	RUnlockBranch("sockshop", "dev")
+
+	readLocked := make(chan bool)
+	go func() {
+		RLockBranch("sockshop", "staging")
+		readLocked <- true
+	}()
+	select {
+	case <-readLocked:
+		t.Fatal("Expected read lock of branch staging to wait for the exclusive lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+	UnlockBranch("sockshop", "staging")
+	UnlockProject("sockshop")
+	<-readLocked
+	RUnlockBranch("sockshop", "staging")
+
+	RLockBranch("sockshop", "staging")
+	if !acquiredWithin(func() { RLockBranch("sockshop", "staging") }, time.Second) {
+		t.Fatal("Expected concurrent read locks of branch staging")
+	}
+	RUnlockBranch("sockshop", "staging")
+	RUnlockBranch("sockshop", "staging")
+}
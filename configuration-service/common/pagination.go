@@ -1,96 +1,127 @@
 package common
 
 import (
-	"math"
-	"os"
-	"path/filepath"
-	"strconv"
-	"strings"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
 
 	"github.com/keptn/keptn/configuration-service/models"
 )
 
+// ErrInvalidPageKey is returned if a next page key has not been returned by a previous request of the same listing
+var ErrInvalidPageKey = errors.New("invalid next page key")
+
+// lastPageKey is returned as next page key of the last page. Like an empty key, it requests the first page
+const lastPageKey = "0"
+
+// pageToken is the content of a next page key. A page starts after the key (e.g., the resource URI) of the last item
+// of the previous page, hence adding or removing items does not shift the following pages
+type pageToken struct {
+	After string `json:"after"`
+}
+
 // PaginationResult contains pagination info
 type PaginationResult struct {
 	// Pointer to next page, base64 encoded
 	NewNextPageKey string
-	NextPageKey    int64
-	// Size of returned page
-	PageSize float64
-
-	// End Index
+	// Index of the first item of the page
+	StartIndex int64
+	// Index after the last item of the page
 	EndIndex int64
 }
 
-// Paginate paginates an array
-func Paginate(totalCount int, pageSize *int64, nextPageKeyString *string) *PaginationResult {
+// encodePageKey returns the opaque next page key of a page starting after the given key
+func encodePageKey(after string) string {
+	token, _ := json.Marshal(pageToken{After: after})
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// decodePageKey returns the key after which the page of the next page key starts
+func decodePageKey(nextPageKey string) (string, error) {
+	token, err := base64.RawURLEncoding.DecodeString(nextPageKey)
+	if err != nil {
+		return "", ErrInvalidPageKey
+	}
+	var result pageToken
+	if err := json.Unmarshal(token, &result); err != nil || result.After == "" {
+		return "", ErrInvalidPageKey
+	}
+	return result.After, nil
+}
+
+// Paginate returns the page of the items with the given keys that is referenced by the next page key. The keys have
+// to be unique; if they are sorted, a page still starts at the right position if the last item of the previous page
+// has been removed in the meantime. ErrInvalidPageKey is returned for keys that cannot be resolved
+func Paginate(keys []string, pageSize *int64, nextPageKey *string) (*PaginationResult, error) {
 	var result = &PaginationResult{}
-	var newNextPageKey int64
-	var nextPageKey int64
-
-	if nextPageKeyString != nil {
-		tmpNextPageKey, _ := strconv.Atoi(*nextPageKeyString)
-		nextPageKey = int64(tmpNextPageKey)
-		newNextPageKey = nextPageKey + *pageSize
-	} else {
-		newNextPageKey = *pageSize
+
+	if nextPageKey != nil && *nextPageKey != "" && *nextPageKey != lastPageKey {
+		after, err := decodePageKey(*nextPageKey)
+		if err != nil {
+			return nil, err
+		}
+		result.StartIndex = -1
+		for i, key := range keys {
+			if key == after {
+				result.StartIndex = int64(i + 1)
+				break
+			}
+		}
+		if result.StartIndex < 0 {
+			if !sort.StringsAreSorted(keys) {
+				return nil, ErrInvalidPageKey
+			}
+			result.StartIndex = int64(sort.SearchStrings(keys, after))
+		}
 	}
-	pagesize := *pageSize
-
-	upperLimit := int64(math.Floor(math.Min(float64(totalCount), float64(nextPageKey+pagesize))))
-	result.NextPageKey = nextPageKey
-	if newNextPageKey < int64(totalCount) {
-		nextPageKey = newNextPageKey
-	} else {
-		nextPageKey = 0
+
+	result.EndIndex = int64(len(keys))
+	if pageSize != nil && *pageSize > 0 && result.StartIndex+*pageSize < result.EndIndex {
+		result.EndIndex = result.StartIndex + *pageSize
 	}
 
-	result.NewNextPageKey = strconv.FormatInt(nextPageKey, 10)
-	result.EndIndex = upperLimit
-	return result
+	result.NewNextPageKey = lastPageKey
+	if result.EndIndex < int64(len(keys)) {
+		result.NewNextPageKey = encodePageKey(keys[result.EndIndex-1])
+	}
+	return result, nil
 }
 
-// GetPaginatedResources returns a paginates resources set
-func GetPaginatedResources(dir string, pageSize *int64, nextPageKey *string) *models.Resources {
-	var result = &models.Resources{
-		PageSize:    0,
-		NextPageKey: "0",
-		TotalCount:  0,
-		Resources:   []*models.Resource{},
+// PaginateResourceList returns a page of the given resources, which have to be sorted by their resource URI
+func PaginateResourceList(resources []*models.Resource, pageSize *int64, nextPageKey *string) (*models.Resources, error) {
+	keys := []string{}
+	for _, resource := range resources {
+		keys = append(keys, *resource.ResourceURI)
 	}
-	var files = []string{}
-	err := filepath.Walk(dir,
-		func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-			if strings.Contains(path, ".git") {
-				return nil
-			}
-			// don't expose the internal directory structure of the container
-			cutPrefix := strings.TrimPrefix(strings.TrimPrefix(dir, "./"), "/")
-			path = strings.Replace(path, cutPrefix, "", 1)
-			if !info.IsDir() {
-				files = append(files, strings.TrimPrefix(path, "/"))
-			}
-			return nil
-		})
+	paginationInfo, err := Paginate(keys, pageSize, nextPageKey)
 	if err != nil {
-		return result
+		return nil, err
 	}
 
-	paginationInfo := Paginate(len(files), pageSize, nextPageKey)
+	return &models.Resources{
+		PageSize:    float64(paginationInfo.EndIndex - paginationInfo.StartIndex),
+		NextPageKey: paginationInfo.NewNextPageKey,
+		TotalCount:  float64(len(resources)),
+		Resources:   resources[paginationInfo.StartIndex:paginationInfo.EndIndex],
+	}, nil
+}
 
-	totalCount := len(files)
-	if paginationInfo.NextPageKey < int64(totalCount) {
-		for _, resourceURI := range files[paginationInfo.NextPageKey:paginationInfo.EndIndex] {
-			var tmp = resourceURI
-			var resource = &models.Resource{ResourceURI: &tmp}
-			result.Resources = append(result.Resources, resource)
-		}
+// PaginateVersions returns a page of the given versions of a resource
+func PaginateVersions(versions []*models.ResourceVersion, pageSize *int64, nextPageKey *string) (*models.ResourceHistory, error) {
+	keys := []string{}
+	for _, version := range versions {
+		keys = append(keys, version.Version)
+	}
+	paginationInfo, err := Paginate(keys, pageSize, nextPageKey)
+	if err != nil {
+		return nil, err
 	}
 
-	result.TotalCount = float64(totalCount)
-	result.NextPageKey = paginationInfo.NewNextPageKey
-	return result
+	return &models.ResourceHistory{
+		PageSize:    float64(paginationInfo.EndIndex - paginationInfo.StartIndex),
+		NextPageKey: paginationInfo.NewNextPageKey,
+		TotalCount:  float64(len(versions)),
+		Versions:    versions[paginationInfo.StartIndex:paginationInfo.EndIndex],
+	}, nil
 }
@@ -1,45 +1,115 @@
 package common
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/keptn/keptn/configuration-service/models"
 	"github.com/stretchr/testify/assert"
 )
 
+// testKeys returns the sorted keys key-00, key-01, ...
+func testKeys(count int) []string {
+	keys := []string{}
+	for i := 0; i < count; i++ {
+		keys = append(keys, fmt.Sprintf("key-%02d", i))
+	}
+	return keys
+}
+
 // TestPaginationOnePage checks whether Paginate returns a paginationInfo with a new next page
 // key set to 0 for one page
 func TestPaginationOnePage(t *testing.T) {
 	var pageSize int64 = 20
 	nextPageKey := "0"
-	paginationInfo := Paginate(18, &pageSize, &nextPageKey)
+	paginationInfo, err := Paginate(testKeys(18), &pageSize, &nextPageKey)
 
-	assert.Equal(t, paginationInfo.EndIndex, int64(18), "Expect end index to be set to 18")
-	assert.Equal(t, paginationInfo.NewNextPageKey, "0", "Expect new next page key to be set to 0")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), paginationInfo.StartIndex, "Expect start index to be set to 0")
+	assert.Equal(t, int64(18), paginationInfo.EndIndex, "Expect end index to be set to 18")
+	assert.Equal(t, "0", paginationInfo.NewNextPageKey, "Expect new next page key to be set to 0")
 }
 
-// TestPaginationGetTwoPages checks whether Paginate returns a paginationInfo with a new next page
-// key set to 0 when asking for the second out of two pages.
-func TestPaginationGetTwoPages(t *testing.T) {
+// TestPaginationThreePages checks whether the next page keys returned by Paginate reference the following pages
+// and whether the last page returns the next page key 0
+func TestPaginationThreePages(t *testing.T) {
 	var pageSize int64 = 20
-	nextPageKey := "20"
-	paginationInfo := Paginate(21, &pageSize, &nextPageKey)
+	keys := testKeys(41)
+	paginationInfo, err := Paginate(keys, &pageSize, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), paginationInfo.EndIndex, "Expect end index to be set to 20")
+	assert.NotEqual(t, "0", paginationInfo.NewNextPageKey, "Expect a next page key")
 
-	assert.Equal(t, paginationInfo.EndIndex, int64(21), "Expect end index to be set to 18")
-	assert.Equal(t, paginationInfo.NewNextPageKey, "0", "Expect new next page key to be set to 0")
+	paginationInfo, err = Paginate(keys, &pageSize, &paginationInfo.NewNextPageKey)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), paginationInfo.StartIndex, "Expect start index to be set to 20")
+	assert.Equal(t, int64(40), paginationInfo.EndIndex, "Expect end index to be set to 40")
+
+	paginationInfo, err = Paginate(keys, &pageSize, &paginationInfo.NewNextPageKey)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(40), paginationInfo.StartIndex, "Expect start index to be set to 40")
+	assert.Equal(t, int64(41), paginationInfo.EndIndex, "Expect end index to be set to 41")
+	assert.Equal(t, "0", paginationInfo.NewNextPageKey, "Expect new next page key to be set to 0")
 }
 
-// TestPaginationGetTwoPages checks whether Paginate returns a paginationInfo with a new next page
-// key set to 40 when asking for the second out of three pages.
-func TestPaginationThreePages(t *testing.T) {
+// TestPaginationIsStable checks whether a page does not shift if items are added or removed in the meantime
+func TestPaginationIsStable(t *testing.T) {
+	var pageSize int64 = 2
+	paginationInfo, _ := Paginate([]string{"a", "b", "c", "d", "e"}, &pageSize, nil)
+
+	// an item has been added before the next page
+	next, err := Paginate([]string{"0", "a", "b", "c", "d", "e"}, &pageSize, &paginationInfo.NewNextPageKey)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), next.StartIndex, "Expect the next page to start at c")
+
+	// the last item of the previous page has been removed
+	next, err = Paginate([]string{"a", "c", "d", "e"}, &pageSize, &paginationInfo.NewNextPageKey)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), next.StartIndex, "Expect the next page to start at c")
+
+	// versions are not sorted, hence the last item of the previous page is required
+	_, err = Paginate([]string{"e", "c", "d"}, &pageSize, &paginationInfo.NewNextPageKey)
+	assert.Equal(t, ErrInvalidPageKey, err)
+}
+
+// TestPaginationInvalidPageKey checks whether Paginate rejects page keys it has not created
+func TestPaginationInvalidPageKey(t *testing.T) {
 	var pageSize int64 = 20
-	nextPageKey := "20"
-	paginationInfo := Paginate(41, &pageSize, &nextPageKey)
+	for _, nextPageKey := range []string{"20", "not base64!", encodePageKey("")} {
+		_, err := Paginate(testKeys(41), &pageSize, &nextPageKey)
+		assert.Equal(t, ErrInvalidPageKey, err, nextPageKey)
+	}
+}
+
+// TestFilterResources checks whether resources are filtered by the prefix and the glob pattern of their URI
+func TestFilterResources(t *testing.T) {
+	resources := []*models.Resource{}
+	for _, resourceURI := range []string{"helm/carts/values.yaml", "helm/carts/templates/deployment.yaml", "slo.yaml"} {
+		var tmp = resourceURI
+		resources = append(resources, &models.Resource{ResourceURI: &tmp})
+	}
+	resourceURIs := func(resources []*models.Resource) []string {
+		result := []string{}
+		for _, resource := range resources {
+			result = append(result, *resource.ResourceURI)
+		}
+		return result
+	}
 
-	assert.Equal(t, paginationInfo.EndIndex, int64(40), "Expect end index to be set to 40")
-	assert.Equal(t, paginationInfo.NewNextPageKey, "40", "Expect new next page key to be set to 40")
+	prefix := "helm/"
+	filtered, err := FilterResources(resources, &prefix, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"helm/carts/values.yaml", "helm/carts/templates/deployment.yaml"}, resourceURIs(filtered))
 
-	paginationInfo = Paginate(41, &pageSize, &paginationInfo.NewNextPageKey)
+	glob := "helm/*/*.yaml"
+	filtered, err = FilterResources(resources, &prefix, &glob)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"helm/carts/values.yaml"}, resourceURIs(filtered))
 
-	assert.Equal(t, paginationInfo.EndIndex, int64(41), "Expect end index to be set to 41")
-	assert.Equal(t, paginationInfo.NewNextPageKey, "0", "Expect new next page key to be set to 0")
+	glob = "helm/["
+	_, err = FilterResources([]*models.Resource{}, nil, &glob)
+	assert.NotNil(t, err)
 }
@@ -0,0 +1,282 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/keptn/keptn/configuration-service/config"
+)
+
+// MergeConflictError is returned if a directory cannot be promoted because both branches changed the same files
+type MergeConflictError struct {
+	Paths []string
+}
+
+func (e *MergeConflictError) Error() string {
+	return "conflicting changes in " + strings.Join(e.Paths, ", ")
+}
+
+const (
+	promotedDirectoryTrailer = "Promoted-Directory"
+	sourceStageTrailer       = "Source-Stage"
+	sourceVersionTrailer     = "Source-Version"
+)
+
+// promoteDirectory merges the changes of a directory in the source branch into the target branch, which has to be
+// checked out, and commits them with the given message. The changes are determined by a three-way merge of each file
+// with the version of the source branch that has been promoted last (or the version the branches forked from). The
+// source branch and version are recorded as trailers of the commit message, hence later promotions only merge newer
+// changes. The promotion is an ordinary commit of the target branch, as recording the source version as merge parent
+// would mark the changes of all other directories as merged, too. If both branches changed the same lines of a file,
+// nothing is changed and a *MergeConflictError is returned. Nothing is committed if the directory has not been changed
+// since the last promotion.
+func promoteDirectory(project string, sourceBranch string, targetBranch string, directory string, message string) error {
+	directory = strings.Trim(directory, "/")
+	sourceVersion, err := resolveBranch(project, sourceBranch)
+	if err != nil {
+		return err
+	}
+	targetVersion, err := resolveBranch(project, targetBranch)
+	if err != nil {
+		return err
+	}
+	baseVersion := getPromotionBase(project, sourceBranch, targetVersion, sourceVersion, directory)
+
+	base, err := listBlobs(project, baseVersion, directory)
+	if err != nil {
+		return err
+	}
+	target, err := listBlobs(project, targetVersion, directory)
+	if err != nil {
+		return err
+	}
+	source, err := listBlobs(project, sourceVersion, directory)
+	if err != nil {
+		return err
+	}
+
+	paths := map[string]bool{}
+	for _, blobs := range []map[string]string{base, target, source} {
+		for path := range blobs {
+			paths[path] = true
+		}
+	}
+
+	// merge all files before changing the working copy, so that conflicts do not leave partial promotions behind
+	changes := map[string][]byte{}
+	deletions := []string{}
+	conflicts := []string{}
+	for path := range paths {
+		baseBlob, targetBlob, sourceBlob := base[path], target[path], source[path]
+		if sourceBlob == baseBlob || sourceBlob == targetBlob {
+			// no changes in the source branch, or the same changes in both branches
+			continue
+		}
+		if targetBlob == baseBlob && sourceBlob == "" {
+			deletions = append(deletions, path)
+			continue
+		}
+		if targetBlob == baseBlob {
+			// changes in the source branch only
+			content, err := runGit(project, "cat-file", "blob", sourceBlob)
+			if err != nil {
+				return err
+			}
+			changes[path] = content
+			continue
+		}
+		if targetBlob == "" || sourceBlob == "" {
+			// the file has been deleted in one branch and changed in the other
+			conflicts = append(conflicts, path)
+			continue
+		}
+		content, merged, err := mergeBlobs(project, baseBlob, targetBlob, sourceBlob)
+		if err != nil {
+			return err
+		}
+		if !merged {
+			conflicts = append(conflicts, path)
+			continue
+		}
+		changes[path] = content
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return &MergeConflictError{Paths: conflicts}
+	}
+
+	changed, err := applyPromotion(project, directory, changes, deletions)
+	if err != nil {
+		// do not leave partial promotions behind
+		if _, resetErr := runGit(project, "reset", "--hard", "HEAD"); resetErr != nil {
+			return resetErr
+		}
+		if _, cleanErr := runGit(project, "clean", "-fd", "--", directory+"/"); cleanErr != nil {
+			return cleanErr
+		}
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	return stageAndCommitAll(project, getPromotionMessage(message, directory, sourceBranch, sourceVersion))
+}
+
+// applyPromotion writes the changed files of a promotion to the working copy and stages them. It returns whether the
+// directory has been changed
+func applyPromotion(project string, directory string, changes map[string][]byte, deletions []string) (bool, error) {
+	projectConfigPath := config.ConfigDir + "/" + project
+	for path, content := range changes {
+		if err := WriteFile(projectConfigPath+"/"+path, content); err != nil {
+			return false, err
+		}
+	}
+	for _, path := range deletions {
+		if err := DeleteFile(projectConfigPath + "/" + path); err != nil {
+			return false, err
+		}
+	}
+	if _, err := runGit(project, "add", "-A", "--", directory+"/"); err != nil {
+		return false, err
+	}
+	if _, err := runGit(project, "diff", "--cached", "--quiet"); err != nil {
+		return true, nil
+	}
+	return false, nil
+}
+
+// getPromotionMessage adds the promoted directory, the source branch and the source version as trailers to the
+// message of a promotion
+func getPromotionMessage(message string, directory string, sourceBranch string, sourceVersion string) string {
+	return fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s", message,
+		promotedDirectoryTrailer, directory, sourceStageTrailer, sourceBranch, sourceVersionTrailer, sourceVersion)
+}
+
+// getPromotedVersion returns the source version recorded in the message of a promotion of the directory from the
+// source branch. False is returned if the message does not belong to such a promotion
+func getPromotedVersion(message string, directory string, sourceBranch string) (string, bool) {
+	trailers := map[string]string{}
+	for _, line := range strings.Split(message, "\n") {
+		if separator := strings.Index(line, ": "); separator > 0 {
+			trailers[line[:separator]] = strings.TrimSpace(line[separator+2:])
+		}
+	}
+	if trailers[promotedDirectoryTrailer] != directory || trailers[sourceStageTrailer] != sourceBranch ||
+		trailers[sourceVersionTrailer] == "" {
+		return "", false
+	}
+	return trailers[sourceVersionTrailer], true
+}
+
+// getPromotionBase returns the version of the source branch that the directory has been promoted from to the target
+// version last. If the directory has not been promoted from the source branch yet, the version both branches forked
+// from is returned.
+func getPromotionBase(project string, sourceBranch string, targetVersion string, sourceVersion string, directory string) string {
+	out, err := runGit(project, "log", "-1", "--format=%B", "-E", "--all-match",
+		"--grep=^"+promotedDirectoryTrailer+": "+regexp.QuoteMeta(directory)+"$",
+		"--grep=^"+sourceStageTrailer+": "+regexp.QuoteMeta(sourceBranch)+"$",
+		targetVersion)
+	if err == nil {
+		if version, ok := getPromotedVersion(string(out), directory, sourceBranch); ok {
+			return version
+		}
+	}
+	return getForkPoint(project, targetVersion, sourceVersion)
+}
+
+// getForkPoint returns the latest version both branches share in their first-parent history, i.e., the version one
+// branch has been created from. Unlike git merge-base, the second parents of merges are not taken into account, hence
+// a merge of (another directory of) the source branch does not move the fork point. An empty version is returned if
+// the branches do not have a common history
+func getForkPoint(project string, targetVersion string, sourceVersion string) string {
+	out, err := runGit(project, "rev-list", "--first-parent", targetVersion)
+	if err != nil {
+		return ""
+	}
+	targetHistory := map[string]bool{}
+	for _, version := range strings.Fields(string(out)) {
+		targetHistory[version] = true
+	}
+	out, err = runGit(project, "rev-list", "--first-parent", sourceVersion)
+	if err != nil {
+		return ""
+	}
+	for _, version := range strings.Fields(string(out)) {
+		if targetHistory[version] {
+			return version
+		}
+	}
+	return ""
+}
+
+// listBlobs returns the blobs of the files within a directory of a version, by their path
+func listBlobs(project string, version string, directory string) (map[string]string, error) {
+	blobs := map[string]string{}
+	if version == "" {
+		return blobs, nil
+	}
+	out, err := runGit(project, "ls-tree", "-r", "-z", version, "--", directory+"/")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// <mode> SP <type> SP <object> TAB <path>
+		tab := strings.Index(entry, "\t")
+		if tab < 0 {
+			continue
+		}
+		fields := strings.Fields(entry[:tab])
+		if len(fields) == 3 && fields[1] == "blob" {
+			blobs[entry[tab+1:]] = fields[2]
+		}
+	}
+	return blobs, nil
+}
+
+// mergeBlobs merges the changes of two versions of a file relative to their base version. If the changes conflict,
+// false is returned
+func mergeBlobs(project string, baseBlob string, targetBlob string, sourceBlob string) ([]byte, bool, error) {
+	dir, err := ioutil.TempDir("", "promotion")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(dir)
+
+	files := []string{}
+	for _, blob := range []string{targetBlob, baseBlob, sourceBlob} {
+		content := []byte{}
+		if blob != "" {
+			if content, err = runGit(project, "cat-file", "blob", blob); err != nil {
+				return nil, false, err
+			}
+		}
+		file := filepath.Join(dir, fmt.Sprintf("%d", len(files)))
+		if err := ioutil.WriteFile(file, content, 0644); err != nil {
+			return nil, false, err
+		}
+		files = append(files, file)
+	}
+
+	cmd := exec.Command("git", "merge-file", "-p", files[0], files[1], files[2])
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		// git merge-file exits with the number of conflicts (at most 127), and with 255 if it fails. Binary files
+		// cannot be merged, hence they conflict if both branches changed them
+		if code := exitErr.ExitCode(); (code > 0 && code < 128) || strings.Contains(stderr.String(), "Cannot merge binary files") {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("Error executing command git merge-file: %s %s", err.Error(), strings.TrimSpace(stderr.String()))
+	} else if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
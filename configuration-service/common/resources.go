@@ -0,0 +1,52 @@
+package common
+
+import (
+	"path"
+	"strings"
+
+	"github.com/keptn/keptn/configuration-service/models"
+)
+
+// ListResourcesInBranch returns the files within a directory of a branch as resources with their metadata, sorted by
+// their resource URI, which is relative to the directory. The content of the resources is not read
+func ListResourcesInBranch(project string, branch string, directory string) ([]*models.Resource, error) {
+	metadata, err := GetFileMetadata(project, branch, directory)
+	if err != nil {
+		return nil, err
+	}
+	files, err := ListFilesInBranch(project, branch, directory)
+	if err != nil {
+		return nil, err
+	}
+	resources := []*models.Resource{}
+	for _, file := range files {
+		var resourceURI = file
+		resources = append(resources, &models.Resource{ResourceURI: &resourceURI, Metadata: metadata[file]})
+	}
+	return resources, nil
+}
+
+// FilterResources returns the resources whose URI starts with the prefix and matches the glob pattern, if they are
+// set. The pattern has the syntax of path.Match, hence a * does not match a /. path.ErrBadPattern is returned for
+// malformed patterns
+func FilterResources(resources []*models.Resource, prefix *string, glob *string) ([]*models.Resource, error) {
+	if glob != nil {
+		// check the pattern even if there are no resources
+		if _, err := path.Match(*glob, ""); err != nil {
+			return nil, err
+		}
+	}
+	filtered := []*models.Resource{}
+	for _, resource := range resources {
+		if prefix != nil && !strings.HasPrefix(*resource.ResourceURI, *prefix) {
+			continue
+		}
+		if glob != nil {
+			if matched, _ := path.Match(*glob, *resource.ResourceURI); !matched {
+				continue
+			}
+		}
+		filtered = append(filtered, resource)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,265 @@
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/models"
+)
+
+// Storage persists the projects of the configuration-service. The checked out branch (stage) of a project is
+// available as working copy in config.ConfigDir/<project>; handlers change the files of the working copy and commit
+// them to the storage afterwards. Every commit creates a new version of the branch
+type Storage interface {
+	// CreateProject creates an empty project with a master branch that does not contain any versions yet
+	CreateProject(project string) error
+	// CloneProject creates a project from an upstream repository
+	CloneProject(project string, credentials *GitCredentials) error
+	// DeleteProject deletes a project including all its versions
+	DeleteProject(project string) error
+
+	// GetBranches returns the branches of a project
+	GetBranches(project string) ([]string, error)
+	// BranchExists checks if a branch exists in a project, without checking it out
+	BranchExists(project string, branch string) bool
+	// CheckoutBranch checks out a branch. If the branch and the upstream changed the same files, the branch is left
+	// unchanged and a *MergeConflictError is returned
+	CheckoutBranch(project string, branch string) error
+	// CreateBranch creates a branch from the latest version of the source branch and checks it out
+	CreateBranch(project string, branch string, sourceBranch string) error
+	// DeleteBranch deletes a branch and checks out master
+	DeleteBranch(project string, branch string) error
+
+	// StageAndCommitAll commits all changes of the working copy to the checked out branch. If the upstream contains
+	// conflicting changes, the changes are reverted and a *MergeConflictError is returned
+	StageAndCommitAll(project string, message string) error
+	// GetCurrentVersion returns the latest version of the checked out branch
+	GetCurrentVersion(project string) (string, error)
+	// PromoteDirectory merges the changes of a directory in the source branch into the target branch, which has to be
+	// checked out, and commits them. A *MergeConflictError is returned if both branches changed the same files
+	PromoteDirectory(project string, sourceBranch string, targetBranch string, directory string, message string) error
+
+	// DirectoryExistsInBranch checks if a directory exists in a branch, without checking it out
+	DirectoryExistsInBranch(project string, branch string, path string) bool
+	// GetFileAtVersion reads a file from a version of a branch; an empty version reads the latest version.
+	// ErrVersionNotFound is returned if the version is not part of the branch, ErrFileNotFound if the file does not
+	// exist in the version
+	GetFileAtVersion(project string, branch string, version string, path string) ([]byte, error)
+	// ListFilesInBranch returns the paths of all files within a directory of a branch, relative to the directory. An
+	// empty directory lists all files of the branch
+	ListFilesInBranch(project string, branch string, directory string) ([]string, error)
+	// GetFileMetadata returns the size, content hash and latest version of all files within a directory of a branch by
+	// their path relative to the directory, without reading their content
+	GetFileMetadata(project string, branch string, directory string) (map[string]*models.ResourceMetadata, error)
+	// ArchiveDirectoryAtVersion returns a directory of a version of a branch as .tar.gz archive. The files in the
+	// archive are located in a folder named like the directory
+	ArchiveDirectoryAtVersion(project string, branch string, version string, directory string) ([]byte, error)
+	// GetFileHistory returns the versions of a branch that changed a file (or the files within a directory), starting
+	// with the latest one. ErrFileNotFound is returned if the file has never been part of the branch
+	GetFileHistory(project string, branch string, path string) ([]*models.ResourceVersion, error)
+	// GetFileDiff returns the unified diff of a file (or the files within a directory) between two versions of a
+	// branch. An empty toVersion compares with the latest version
+	GetFileDiff(project string, branch string, fromVersion string, toVersion string, path string) (*models.ResourceDiff, error)
+
+	// VerifyCredentials checks that the upstream of the credentials can be accessed with them
+	VerifyCredentials(credentials *GitCredentials) error
+	// PushBranches pushes all branches of a project to the upstream of the credentials
+	PushBranches(project string, credentials *GitCredentials) error
+	// UpdateFromUpstream updates the branches of a project with the changes of its upstream. The caller has to hold
+	// the lock of the project
+	UpdateFromUpstream(project string) error
+	// GetSyncStatus returns the synchronization status of a project with its upstream
+	GetSyncStatus(project string) (*models.SyncStatus, error)
+
+	// StoreCredentials stores the credentials of the upstream of a project, replacing existing ones
+	StoreCredentials(project string, credentials *GitCredentials) error
+	// GetCredentials returns the credentials of the upstream of a project, or nil if no upstream has been defined
+	GetCredentials(project string) (*GitCredentials, error)
+	// DeleteCredentials deletes the credentials of the upstream of a project
+	DeleteCredentials(project string) error
+}
+
+var storage Storage = &GitStorage{}
+
+// SetStorage sets the storage used for all projects
+func SetStorage(s Storage) {
+	storage = s
+}
+
+// NewStorage returns the storage with the given name, i.e., "git" (default) or "memory"
+func NewStorage(name string) (Storage, error) {
+	switch name {
+	case "", "git":
+		return &GitStorage{}, nil
+	case "memory":
+		return NewMemoryStorage(), nil
+	}
+	return nil, fmt.Errorf("unknown storage %s", name)
+}
+
+// CreateProject creates an empty project
+func CreateProject(project string) error {
+	return storage.CreateProject(project)
+}
+
+// CloneRepo clones an upstream repository into a local folder "project"
+func CloneRepo(project string, credentials *GitCredentials) error {
+	return storage.CloneProject(project, credentials)
+}
+
+// DeleteProject deletes a project including all its versions
+func DeleteProject(project string) error {
+	return storage.DeleteProject(project)
+}
+
+// ProjectExists checks if a project exists
+func ProjectExists(project string) bool {
+	projectConfigPath := config.ConfigDir + "/" + project
+	// check if the project exists
+	_, err := os.Stat(projectConfigPath)
+	// create file if not exists
+	if os.IsNotExist(err) {
+		return false
+	}
+	return true
+}
+
+// StageExists checks if a stage in a given project exists
+func StageExists(project string, stage string) bool {
+	if !ProjectExists(project) {
+		return false
+	}
+	return BranchExists(project, stage)
+}
+
+// ServiceExists checks if a service exists in a given stage of a project
+func ServiceExists(project string, stage string, service string) bool {
+	if !StageExists(project, stage) {
+		return false
+	}
+	return DirectoryExistsInBranch(project, stage, service)
+}
+
+// GetBranches returns a list of branches within the project
+func GetBranches(project string) ([]string, error) {
+	return storage.GetBranches(project)
+}
+
+// BranchExists checks if a branch exists in the project, without checking it out
+func BranchExists(project string, branch string) bool {
+	return storage.BranchExists(project, branch)
+}
+
+// CheckoutBranch checks out the given branch and merges the changes of the upstream, if one has been defined
+func CheckoutBranch(project string, branch string) error {
+	return storage.CheckoutBranch(project, branch)
+}
+
+// CreateBranch creates a new branch
+func CreateBranch(project string, branch string, sourceBranch string) error {
+	return storage.CreateBranch(project, branch, sourceBranch)
+}
+
+// DeleteBranch deletes a branch of the project and removes it from the upstream, if one has been defined
+func DeleteBranch(project string, branch string) error {
+	return storage.DeleteBranch(project, branch)
+}
+
+// StageAndCommitAll commits all current changes to the current branch and pushes them to the upstream, if one has
+// been defined
+func StageAndCommitAll(project string, message string) error {
+	return storage.StageAndCommitAll(project, message)
+}
+
+// GetCurrentVersion gets the latest version (i.e. commit hash) of the currently checked out branch
+func GetCurrentVersion(project string) (string, error) {
+	return storage.GetCurrentVersion(project)
+}
+
+// PromoteDirectory merges the changes of a directory in the source branch into the target branch
+func PromoteDirectory(project string, sourceBranch string, targetBranch string, directory string, message string) error {
+	return storage.PromoteDirectory(project, sourceBranch, targetBranch, directory, message)
+}
+
+// DirectoryExistsInBranch checks if a directory exists in a branch, without checking it out
+func DirectoryExistsInBranch(project string, branch string, path string) bool {
+	return storage.DirectoryExistsInBranch(project, branch, path)
+}
+
+// GetFileFromBranch reads a file from a branch, without checking it out. ErrFileNotFound is returned if the file
+// does not exist in the branch
+func GetFileFromBranch(project string, branch string, path string) ([]byte, error) {
+	return storage.GetFileAtVersion(project, branch, "", path)
+}
+
+// GetFileAtVersion reads a file from a version of a branch; an empty version reads the latest version
+func GetFileAtVersion(project string, branch string, version string, path string) ([]byte, error) {
+	return storage.GetFileAtVersion(project, branch, version, path)
+}
+
+// ListFilesInBranch returns the paths of all files within a directory of a branch, relative to the directory
+func ListFilesInBranch(project string, branch string, directory string) ([]string, error) {
+	return storage.ListFilesInBranch(project, branch, directory)
+}
+
+// GetFileMetadata returns the metadata of all files within a directory of a branch, relative to the directory
+func GetFileMetadata(project string, branch string, directory string) (map[string]*models.ResourceMetadata, error) {
+	return storage.GetFileMetadata(project, branch, directory)
+}
+
+// ArchiveDirectoryFromBranch returns a directory of a branch as .tar.gz archive, without checking the branch out.
+// The files in the archive are located in a folder named like the directory
+func ArchiveDirectoryFromBranch(project string, branch string, directory string) ([]byte, error) {
+	return storage.ArchiveDirectoryAtVersion(project, branch, "", directory)
+}
+
+// ArchiveDirectoryAtVersion returns a directory of a version of a branch as .tar.gz archive
+func ArchiveDirectoryAtVersion(project string, branch string, version string, directory string) ([]byte, error) {
+	return storage.ArchiveDirectoryAtVersion(project, branch, version, directory)
+}
+
+// GetFileHistory returns the versions of a branch that changed a file, starting with the latest one
+func GetFileHistory(project string, branch string, path string) ([]*models.ResourceVersion, error) {
+	return storage.GetFileHistory(project, branch, path)
+}
+
+// GetFileDiff returns the unified diff of a file between two versions of a branch
+func GetFileDiff(project string, branch string, fromVersion string, toVersion string, path string) (*models.ResourceDiff, error) {
+	return storage.GetFileDiff(project, branch, fromVersion, toVersion, path)
+}
+
+// VerifyCredentials checks that the upstream of the credentials can be accessed with them
+func VerifyCredentials(credentials *GitCredentials) error {
+	return storage.VerifyCredentials(credentials)
+}
+
+// PushBranches pushes all branches of the project to the given upstream
+func PushBranches(project string, credentials *GitCredentials) error {
+	return storage.PushBranches(project, credentials)
+}
+
+// UpdateFromUpstream updates the branches of a project with the changes of its upstream
+func UpdateFromUpstream(project string) error {
+	return storage.UpdateFromUpstream(project)
+}
+
+// GetSyncStatus returns the synchronization status of a project with its upstream repository
+func GetSyncStatus(project string) (*models.SyncStatus, error) {
+	return storage.GetSyncStatus(project)
+}
+
+// StoreGitCredentials stores the specified git credentials, replacing existing credentials of the project
+func StoreGitCredentials(project string, credentials *GitCredentials) error {
+	return storage.StoreCredentials(project, credentials)
+}
+
+// GetCredentials returns the credentials for a given project, if available
+func GetCredentials(project string) (*GitCredentials, error) {
+	return storage.GetCredentials(project)
+}
+
+// DeleteCredentials deletes the credentials of a given project
+func DeleteCredentials(project string) error {
+	return storage.DeleteCredentials(project)
+}
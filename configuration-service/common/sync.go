@@ -0,0 +1,214 @@
+package common
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/keptn/keptn/configuration-service/models"
+)
+
+// pushRetries is the number of times a push that has been rejected because the upstream contains newer commits is
+// retried after merging these commits
+const pushRetries = 3
+
+// syncState is the result of the latest synchronization of a project with its upstream
+type syncState struct {
+	lastSync  time.Time
+	lastError string
+	// conflicting paths by branch
+	conflicts map[string][]string
+}
+
+var syncStates = map[string]*syncState{}
+var syncStatesMutex = &sync.Mutex{}
+
+// recordSync records the result of synchronizing a branch of a project with its upstream
+func recordSync(project string, branch string, err error) {
+	syncStatesMutex.Lock()
+	defer syncStatesMutex.Unlock()
+	state, ok := syncStates[project]
+	if !ok {
+		state = &syncState{conflicts: map[string][]string{}}
+		syncStates[project] = state
+	}
+	state.lastSync = time.Now()
+	state.lastError = ""
+	delete(state.conflicts, branch)
+	if conflict, ok := err.(*MergeConflictError); ok {
+		state.conflicts[branch] = conflict.Paths
+	} else if err != nil {
+		state.lastError = err.Error()
+	}
+}
+
+// DeleteSyncStatus removes the synchronization status of a project, e.g., after the project has been deleted
+func DeleteSyncStatus(project string) {
+	syncStatesMutex.Lock()
+	defer syncStatesMutex.Unlock()
+	delete(syncStates, project)
+}
+
+// fetchUpstream fetches all branches of the upstream repository into refs/remotes/origin
+func fetchUpstream(project string, credentials *GitCredentials) error {
+	if _, err := runGitWithCredentials(project, credentials, "fetch", "--prune", credentials.remoteURI(), "+refs/heads/*:refs/remotes/origin/*"); err != nil {
+		return errors.New("Could not fetch from upstream")
+	}
+	return nil
+}
+
+// mergeUpstream merges the upstream version of a branch, which has to be checked out. If both versions changed the
+// same files, the merge is aborted, hence the branch is not changed, and a *MergeConflictError is returned
+func mergeUpstream(project string, branch string) error {
+	remote := "refs/remotes/origin/" + branch
+	if _, err := runGit(project, "rev-parse", "--verify", "--quiet", remote); err != nil {
+		// the branch has not been pushed to the upstream yet
+		return nil
+	}
+	if _, err := runGit(project, "merge-base", "--is-ancestor", remote, "HEAD"); err == nil {
+		// the upstream does not contain any new commits
+		return nil
+	}
+	if _, err := runGit(project, "merge", "--ff-only", remote); err == nil {
+		return nil
+	}
+	_, err := runGit(project, "merge", "--no-ff", "-m", "Merged changes of branch "+branch+" from upstream", remote)
+	if err == nil {
+		return nil
+	}
+	out, _ := runGit(project, "diff", "--name-only", "-z", "--diff-filter=U")
+	runGit(project, "merge", "--abort")
+	paths := splitPaths(out)
+	if len(paths) == 0 {
+		return err
+	}
+	return &MergeConflictError{Paths: paths}
+}
+
+// syncBranch updates a branch, which has to be checked out, with the changes of the upstream
+func syncBranch(project string, branch string, credentials *GitCredentials) error {
+	err := fetchUpstream(project, credentials)
+	if err == nil {
+		err = mergeUpstream(project, branch)
+	}
+	recordSync(project, branch, err)
+	return err
+}
+
+// pushBranch pushes a branch, which has to be checked out, to the upstream. If the push is rejected because the
+// upstream contains newer commits, these are merged before the push is retried
+func pushBranch(project string, branch string, credentials *GitCredentials) error {
+	var err error
+	for i := 0; i < pushRetries; i++ {
+		if _, err = runGitWithCredentials(project, credentials, "push", credentials.remoteURI(), "HEAD:refs/heads/"+branch); err == nil {
+			// a push to the remote URI does not update the remote-tracking branch, which the sync status is based on
+			_, err = runGit(project, "update-ref", "refs/remotes/origin/"+branch, "HEAD")
+			break
+		}
+		if !isPushRejected(err) {
+			err = errors.New("Could not push to upstream")
+			break
+		}
+		if err = fetchUpstream(project, credentials); err != nil {
+			break
+		}
+		if err = mergeUpstream(project, branch); err != nil {
+			break
+		}
+		err = errors.New("Could not push to upstream, the upstream has been changed concurrently")
+	}
+	recordSync(project, branch, err)
+	return err
+}
+
+func isPushRejected(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "non-fast-forward") || strings.Contains(message, "fetch first") ||
+		strings.Contains(message, "[rejected]")
+}
+
+func splitPaths(out []byte) []string {
+	paths := []string{}
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// countCommits returns the number of commits that are reachable from one revision but not from the other
+func countCommits(project string, from string, notFrom string) int64 {
+	args := []string{"rev-list", "--count", from}
+	if notFrom != "" {
+		args = append(args, "^"+notFrom)
+	}
+	out, err := runGit(project, args...)
+	if err != nil {
+		return 0
+	}
+	count, _ := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	return count
+}
+
+// getSyncStatus returns the synchronization status of a project with its upstream repository, based on the last
+// synchronization of each branch. The upstream itself is not contacted
+func getSyncStatus(project string) (*models.SyncStatus, error) {
+	credentials, err := getCredentials(project)
+	hasUpstream := err == nil && credentials != nil
+
+	syncStatesMutex.Lock()
+	state, synced := syncStates[project]
+	status := &models.SyncStatus{}
+	conflicts := map[string][]string{}
+	if synced {
+		status.LastSync = strfmt.DateTime(state.lastSync)
+		status.LastError = state.lastError
+		for branch, paths := range state.conflicts {
+			conflicts[branch] = paths
+		}
+	}
+	syncStatesMutex.Unlock()
+
+	if !hasUpstream && !synced {
+		status.Status = models.SyncStatusStatusNoUpstream
+		return status, nil
+	}
+
+	branches, err := getBranches(project)
+	if err != nil {
+		return nil, err
+	}
+	pending := false
+	for _, branch := range branches {
+		if branch == "" {
+			continue
+		}
+		branchStatus := &models.BranchSyncStatus{Branch: branch, Conflicts: conflicts[branch]}
+		local := "refs/heads/" + branch
+		remote := "refs/remotes/origin/" + branch
+		if _, err := runGit(project, "rev-parse", "--verify", "--quiet", remote); err == nil {
+			branchStatus.Ahead = countCommits(project, local, remote)
+			branchStatus.Behind = countCommits(project, remote, local)
+		} else {
+			branchStatus.Ahead = countCommits(project, local, "")
+		}
+		pending = pending || branchStatus.Ahead > 0 || branchStatus.Behind > 0
+		status.Branches = append(status.Branches, branchStatus)
+	}
+
+	switch {
+	case len(conflicts) > 0:
+		status.Status = models.SyncStatusStatusConflict
+	case status.LastError != "":
+		status.Status = models.SyncStatusStatusError
+	case pending:
+		status.Status = models.SyncStatusStatusPending
+	default:
+		status.Status = models.SyncStatusStatusSynchronized
+	}
+	return status, nil
+}
@@ -0,0 +1,220 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/models"
+)
+
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %s\n%s", args, err.Error(), string(out))
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commitTestFile commits a file to the checked out branch of a repository
+func commitTestFile(t *testing.T, dir string, path string, content string) {
+	if err := ioutil.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runTestGit(t, dir, "add", ".")
+	runTestGit(t, dir, "commit", "-m", "Changed "+path)
+}
+
+func cloneTestRepo(t *testing.T, upstream string, dir string) {
+	runTestGit(t, filepath.Dir(dir), "clone", "-q", upstream, filepath.Base(dir))
+	runTestGit(t, dir, "config", "user.name", "keptn")
+	runTestGit(t, dir, "config", "user.email", "keptn@keptn.sh")
+}
+
+// testCredentials returns credentials for a local upstream, which does not require the token
+func testCredentials(upstream string) *GitCredentials {
+	return &GitCredentials{User: "keptn", Token: "token", RemoteURI: upstream}
+}
+
+// setupUpstream creates an upstream repository containing a master branch, a clone of it as project sockshop in
+// config.ConfigDir and a second clone simulating changes made outside of keptn. It returns the upstream, the second
+// clone and a function restoring config.ConfigDir
+func setupUpstream(t *testing.T) (string, string, func()) {
+	dir, err := ioutil.TempDir("", "configuration-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir := config.ConfigDir
+	config.ConfigDir = filepath.Join(dir, "config")
+	os.MkdirAll(config.ConfigDir, os.ModePerm)
+
+	upstream := filepath.Join(dir, "upstream.git")
+	runTestGit(t, dir, "init", "-q", "--bare", upstream)
+	runTestGit(t, upstream, "symbolic-ref", "HEAD", "refs/heads/master")
+	external := filepath.Join(dir, "external")
+	cloneTestRepo(t, upstream, external)
+	runTestGit(t, external, "checkout", "-q", "-b", "master")
+	commitTestFile(t, external, "values.yaml", "replicas: 1\n")
+	runTestGit(t, external, "push", "-q", "origin", "master")
+	cloneTestRepo(t, upstream, filepath.Join(config.ConfigDir, "sockshop"))
+
+	return upstream, external, func() {
+		config.ConfigDir = configDir
+		DeleteSyncStatus("sockshop")
+		os.RemoveAll(dir)
+	}
+}
+
+// TestSyncBranch checks that changes of the upstream are merged with local changes to other files
+func TestSyncBranch(t *testing.T) {
+	upstream, external, teardown := setupUpstream(t)
+	defer teardown()
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+
+	commitTestFile(t, external, "slo.yaml", "objectives: []\n")
+	runTestGit(t, external, "push", "-q", "origin", "master")
+	commitTestFile(t, projectConfigPath, "values.yaml", "replicas: 3\n")
+
+	if err := syncBranch("sockshop", "master", testCredentials(upstream)); err != nil {
+		t.Fatalf("Expected the upstream to be merged, got %v", err)
+	}
+	if content := runTestGit(t, projectConfigPath, "show", "HEAD:slo.yaml"); content != "objectives: []" {
+		t.Errorf("Expected slo.yaml of the upstream, got %s", content)
+	}
+	if content := runTestGit(t, projectConfigPath, "show", "HEAD:values.yaml"); content != "replicas: 3" {
+		t.Errorf("Expected the local values.yaml, got %s", content)
+	}
+
+	status, err := GetSyncStatus("sockshop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != models.SyncStatusStatusPending || len(status.Branches) != 1 || status.Branches[0].Ahead != 2 {
+		t.Errorf("Expected the merged commits to be pending, got %+v", status.Branches[0])
+	}
+
+	if err := pushBranch("sockshop", "master", testCredentials(upstream)); err != nil {
+		t.Fatalf("Expected the branch to be pushed, got %v", err)
+	}
+	status, _ = GetSyncStatus("sockshop")
+	if status.Status != models.SyncStatusStatusSynchronized {
+		t.Errorf("Expected the project to be synchronized, got %s", status.Status)
+	}
+}
+
+// TestSyncBranchWithConflicts checks that conflicting changes of the upstream do not overwrite local changes
+func TestSyncBranchWithConflicts(t *testing.T) {
+	upstream, external, teardown := setupUpstream(t)
+	defer teardown()
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+
+	commitTestFile(t, external, "values.yaml", "replicas: 2\n")
+	runTestGit(t, external, "push", "-q", "origin", "master")
+	commitTestFile(t, projectConfigPath, "values.yaml", "replicas: 3\n")
+	version := runTestGit(t, projectConfigPath, "rev-parse", "HEAD")
+
+	err := syncBranch("sockshop", "master", testCredentials(upstream))
+	conflict, ok := err.(*MergeConflictError)
+	if !ok {
+		t.Fatalf("Expected a merge conflict, got %v", err)
+	}
+	if !reflect.DeepEqual(conflict.Paths, []string{"values.yaml"}) {
+		t.Errorf("Expected values.yaml to conflict, got %v", conflict.Paths)
+	}
+	if current := runTestGit(t, projectConfigPath, "rev-parse", "HEAD"); current != version {
+		t.Errorf("Expected the branch not to be changed")
+	}
+	if changes := runTestGit(t, projectConfigPath, "status", "--porcelain"); changes != "" {
+		t.Errorf("Expected the working copy to be clean, got %s", changes)
+	}
+
+	status, err := GetSyncStatus("sockshop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != models.SyncStatusStatusConflict || !reflect.DeepEqual(status.Branches[0].Conflicts, []string{"values.yaml"}) {
+		t.Errorf("Expected the conflict to be reported, got %s %v", status.Status, status.Branches[0].Conflicts)
+	}
+}
+
+// TestPushBranchRetries checks that a push that is rejected because of concurrent changes is retried after merging them
+func TestPushBranchRetries(t *testing.T) {
+	upstream, external, teardown := setupUpstream(t)
+	defer teardown()
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+
+	commitTestFile(t, external, "slo.yaml", "objectives: []\n")
+	runTestGit(t, external, "push", "-q", "origin", "master")
+	commitTestFile(t, projectConfigPath, "values.yaml", "replicas: 3\n")
+
+	if err := pushBranch("sockshop", "master", testCredentials(upstream)); err != nil {
+		t.Fatalf("Expected the branch to be pushed, got %v", err)
+	}
+	files := runTestGit(t, upstream, "ls-tree", "--name-only", "master")
+	if files != "slo.yaml\nvalues.yaml" {
+		t.Errorf("Expected both changes in the upstream, got %s", files)
+	}
+	if content := runTestGit(t, upstream, "show", "master:values.yaml"); content != "replicas: 3" {
+		t.Errorf("Expected the local values.yaml in the upstream, got %s", content)
+	}
+}
+
+// testCredentialsStore keeps the credentials of the projects in memory instead of secrets in the cluster
+type testCredentialsStore map[string]*GitCredentials
+
+func (s testCredentialsStore) StoreCredentials(project string, credentials *GitCredentials) error {
+	s[project] = credentials
+	return nil
+}
+
+func (s testCredentialsStore) GetCredentials(project string) (*GitCredentials, error) {
+	return s[project], nil
+}
+
+func (s testCredentialsStore) DeleteCredentials(project string) error {
+	delete(s, project)
+	return nil
+}
+
+// TestStageAndCommitAllWithUnreachableUpstream checks that a commit that cannot be pushed is kept and reported as
+// pending instead of failed
+func TestStageAndCommitAllWithUnreachableUpstream(t *testing.T) {
+	upstream, _, teardown := setupUpstream(t)
+	defer teardown()
+	SetCredentialsStore(testCredentialsStore{"sockshop": testCredentials(upstream)})
+	defer SetCredentialsStore(nil)
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+
+	if err := ioutil.WriteFile(filepath.Join(projectConfigPath, "values.yaml"), []byte("replicas: 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := stageAndCommitAll("sockshop", "Updated values.yaml"); err != nil {
+		t.Fatalf("Expected the change to be committed, got %v", err)
+	}
+	status, _ := GetSyncStatus("sockshop")
+	if status.Status != models.SyncStatusStatusSynchronized {
+		t.Errorf("Expected the pushed commit to be synchronized, got %s", status.Status)
+	}
+
+	os.RemoveAll(upstream)
+	if err := ioutil.WriteFile(filepath.Join(projectConfigPath, "values.yaml"), []byte("replicas: 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := stageAndCommitAll("sockshop", "Updated values.yaml"); err != nil {
+		t.Fatalf("Expected the change to be committed, got %v", err)
+	}
+	if content := runTestGit(t, projectConfigPath, "show", "HEAD:values.yaml"); content != "replicas: 3" {
+		t.Errorf("Expected the commit to be kept, got %s", content)
+	}
+	status, _ = GetSyncStatus("sockshop")
+	if status.Status != models.SyncStatusStatusError || status.Branches[0].Ahead != 1 {
+		t.Errorf("Expected the commit to be pending with an error, got %s %+v", status.Status, status.Branches[0])
+	}
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/keptn/configuration-service/config"
+)
+
+// WatchUpstreams periodically updates all projects from their upstream repositories, so that reads, which do not
+// pull from the upstream, see changes made outside of keptn
+func WatchUpstreams(interval time.Duration) {
+	logger := utils.NewLogger("", "", "configuration-service")
+	scrubRemoteURLs(logger)
+	for range time.Tick(interval) {
+		projects, err := ioutil.ReadDir(config.ConfigDir)
+		if err != nil {
+			logger.Error("Could not list projects: " + err.Error())
+			continue
+		}
+		for _, project := range projects {
+			if !project.IsDir() {
+				continue
+			}
+			LockProject(project.Name())
+			err := UpdateFromUpstream(project.Name())
+			UnlockProject(project.Name())
+			if err != nil {
+				logger.Error("Could not update project " + project.Name() + " from upstream: " + err.Error())
+			}
+		}
+	}
+}
+
+// scrubRemoteURLs removes the credentials from the URLs of the origin remotes of all projects, which clones created by
+// earlier versions contain
+func scrubRemoteURLs(logger *utils.Logger) {
+	projects, err := ioutil.ReadDir(config.ConfigDir)
+	if err != nil {
+		logger.Error("Could not list projects: " + err.Error())
+		return
+	}
+	for _, project := range projects {
+		if !project.IsDir() || !FileExists(config.ConfigDir+"/"+project.Name()+"/.git") {
+			continue
+		}
+		credentials, err := getCredentials(project.Name())
+		if err != nil || credentials == nil {
+			continue
+		}
+		LockProject(project.Name())
+		err = setRemoteURL(project.Name(), credentials)
+		UnlockProject(project.Name())
+		if err != nil {
+			logger.Error("Could not update remote URL of project " + project.Name() + ": " + err.Error())
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/keptn/keptn/configuration-service/common"
+	"github.com/keptn/keptn/configuration-service/config"
+)
+
+// setupConfigDir points config.ConfigDir to a temporary directory and returns a function restoring it
+func setupConfigDir(t testing.TB) func() {
+	dir, err := ioutil.TempDir("", "configuration-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir := config.ConfigDir
+	config.ConfigDir = dir
+	return func() {
+		config.ConfigDir = configDir
+		os.RemoveAll(dir)
+	}
+}
+
+// createTestProject creates a project repository with a master branch containing the given files and a branch per stage
+func createTestProject(t testing.TB, project string, files map[string]string, stages ...string) {
+	projectConfigPath := filepath.Join(config.ConfigDir, project)
+	if err := os.MkdirAll(projectConfigPath, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	files["metadata.yaml"] = "ProjectName: " + project + "\n"
+	runTestGit(t, projectConfigPath, "init")
+	runTestGit(t, projectConfigPath, "symbolic-ref", "HEAD", "refs/heads/master")
+	runTestGit(t, projectConfigPath, "config", "user.name", "keptn")
+	runTestGit(t, projectConfigPath, "config", "user.email", "keptn@keptn.sh")
+	writeTestFiles(t, projectConfigPath, files)
+	runTestGit(t, projectConfigPath, "add", ".")
+	runTestGit(t, projectConfigPath, "commit", "-m", "Added metadata.yaml")
+	for _, stage := range stages {
+		runTestGit(t, projectConfigPath, "branch", stage)
+	}
+}
+
+// commitTestFiles commits the given files to a branch of a project and checks out master again
+func commitTestFiles(t testing.TB, project string, branch string, files map[string]string) {
+	projectConfigPath := filepath.Join(config.ConfigDir, project)
+	runTestGit(t, projectConfigPath, "checkout", branch)
+	writeTestFiles(t, projectConfigPath, files)
+	runTestGit(t, projectConfigPath, "add", ".")
+	runTestGit(t, projectConfigPath, "commit", "-m", "Added resources")
+	runTestGit(t, projectConfigPath, "checkout", "master")
+}
+
+func writeTestFiles(t testing.TB, dir string, files map[string]string) {
+	for path, content := range files {
+		filePath := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func runTestGit(t testing.TB, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %s\n%s", args, err.Error(), string(out))
+	}
+	return string(out)
+}
+
+// testCredentialsStore keeps the credentials of the projects in memory instead of secrets in the cluster
+type testCredentialsStore map[string]*common.GitCredentials
+
+func (s testCredentialsStore) StoreCredentials(project string, credentials *common.GitCredentials) error {
+	s[project] = credentials
+	return nil
+}
+
+func (s testCredentialsStore) GetCredentials(project string) (*common.GitCredentials, error) {
+	return s[project], nil
+}
+
+func (s testCredentialsStore) DeleteCredentials(project string) error {
+	delete(s, project)
+	return nil
+}
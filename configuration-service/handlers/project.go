@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"io/ioutil"
-	"os"
+	"net/http"
 	"time"
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	"github.com/keptn/go-utils/pkg/utils"
@@ -22,8 +23,6 @@ type projectMetadata struct {
 
 // GetProjectHandlerFunc gets a list of projects
 func GetProjectHandlerFunc(params project.GetProjectParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
 	var payload = &models.Projects{
 		PageSize:    0,
 		NextPageKey: "0",
@@ -36,27 +35,32 @@ func GetProjectHandlerFunc(params project.GetProjectParams) middleware.Responder
 		return project.NewGetProjectOK().WithPayload(payload)
 	}
 
-	paginationInfo := common.Paginate(len(files), params.PageSize, params.NextPageKey)
-
-	totalCount := len(files)
-	if paginationInfo.NextPageKey < int64(totalCount) {
-		for _, f := range files[paginationInfo.NextPageKey:paginationInfo.EndIndex] {
-			if f.IsDir() && common.FileExists(config.ConfigDir+"/"+f.Name()+"/metadata.yaml") {
-				var project = &models.Project{ProjectName: f.Name()}
-				payload.Projects = append(payload.Projects, project)
-			}
+	// projects are the directories containing a metadata.yaml file, sorted by their name
+	projectNames := []string{}
+	for _, f := range files {
+		if f.IsDir() && common.FileExists(config.ConfigDir+"/"+f.Name()+"/metadata.yaml") {
+			projectNames = append(projectNames, f.Name())
 		}
 	}
 
-	payload.TotalCount = float64(totalCount)
+	paginationInfo, err := common.Paginate(projectNames, params.PageSize, params.NextPageKey)
+	if err != nil {
+		return project.NewGetProjectDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String("Invalid next page key")})
+	}
+	for _, projectName := range projectNames[paginationInfo.StartIndex:paginationInfo.EndIndex] {
+		payload.Projects = append(payload.Projects, &models.Project{ProjectName: projectName})
+	}
+
+	payload.PageSize = float64(len(payload.Projects))
+	payload.TotalCount = float64(len(projectNames))
 	payload.NextPageKey = paginationInfo.NewNextPageKey
 	return project.NewGetProjectOK().WithPayload(payload)
 }
 
 // PostProjectHandlerFunc creates a new project
 func PostProjectHandlerFunc(params project.PostProjectParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.Project.ProjectName)
+	defer common.UnlockProject(params.Project.ProjectName)
 	credentialsCreated := false
 	logger := utils.NewLogger("", "", "configuration-service")
 	projectConfigPath := config.ConfigDir + "/" + params.Project.ProjectName
@@ -69,16 +73,21 @@ func PostProjectHandlerFunc(params project.PostProjectParams) middleware.Respond
 	////////////////////////////////////////////////////
 	// clone existing repo
 	////////////////////////////////////////////////////
-	if params.Project.GitUser != "" && params.Project.GitToken != "" && params.Project.GitRemoteURI != "" {
+	credentials := getGitCredentials(params.Project)
+	if credentials.RemoteURI != "" && credentials.PrivateKey != "" && !credentials.Valid() {
+		// the host key of the upstream could not be verified
+		return project.NewPostProjectBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Known hosts of the git remote have to be specified for a private key")})
+	}
+	if credentials.Valid() {
 		// try to clone the repo
-		err := common.CloneRepo(params.Project.ProjectName, params.Project.GitUser, params.Project.GitToken, params.Project.GitRemoteURI)
+		err := common.CloneRepo(params.Project.ProjectName, credentials)
 		if err != nil {
 			logger.Error(err.Error())
 			return project.NewPostProjectBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not clone git repository")})
 		}
 
 		// store credentials (e.g., as a kubernetes secret)
-		err = common.StoreGitCredentials(params.Project.ProjectName, params.Project.GitUser, params.Project.GitToken, params.Project.GitRemoteURI)
+		err = common.StoreGitCredentials(params.Project.ProjectName, credentials)
 		if err != nil {
 			logger.Error(err.Error())
 			return project.NewPostProjectBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not store git credentials")})
@@ -87,17 +96,11 @@ func PostProjectHandlerFunc(params project.PostProjectParams) middleware.Respond
 	} else {
 		// if no remote URI has been specified, create a new repo
 		///////////////////////////////////////////////////
-		err := os.MkdirAll(projectConfigPath, os.ModePerm)
+		err := common.CreateProject(params.Project.ProjectName)
 		if err != nil {
 			logger.Error(err.Error())
 			return project.NewPostProjectBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not create project")})
 		}
-
-		_, err = utils.ExecuteCommandInDirectory("git", []string{"init"}, projectConfigPath)
-		if err != nil {
-			logger.Error(err.Error())
-			return project.NewPostProjectBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not initialize git repo")})
-		}
 	}
 	////////////////////////////////////////////////////
 	newProjectMetadata := &projectMetadata{
@@ -138,33 +141,79 @@ func PostProjectHandlerFunc(params project.PostProjectParams) middleware.Respond
 	return project.NewPostProjectNoContent()
 }
 
+// getGitCredentials returns the git credentials contained in a project
+func getGitCredentials(p *models.Project) *common.GitCredentials {
+	if p == nil {
+		return nil
+	}
+	return &common.GitCredentials{
+		User:             p.GitUser,
+		Token:            p.GitToken,
+		RemoteURI:        p.GitRemoteURI,
+		PrivateKey:       p.GitPrivateKey,
+		KnownHosts:       p.GitKnownHosts,
+		AcceptNewHostKey: p.GitAcceptNewHostKey,
+		CACertificate:    p.GitCACertificate,
+	}
+}
+
 // GetProjectProjectNameHandlerFunc gets a project by its name
 func GetProjectProjectNameHandlerFunc(params project.GetProjectProjectNameParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
 	if !common.ProjectExists(params.ProjectName) {
 		return project.NewGetProjectProjectNameNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
 	}
 	var projectResponse = &models.Project{ProjectName: params.ProjectName}
 	projectCreds, _ := common.GetCredentials(params.ProjectName)
 	if projectCreds != nil {
-		projectResponse.GitRemoteURI = projectCreds.RemoteURI
+		projectResponse.GitRemoteURI = projectCreds.PublicRemoteURI()
 	}
 	return project.NewGetProjectProjectNameOK().WithPayload(projectResponse)
 }
 
 // PutProjectProjectNameHandlerFunc updates a project
 func PutProjectProjectNameHandlerFunc(params project.PutProjectProjectNameParams) middleware.Responder {
-	return middleware.NotImplemented("operation project.PutProjectProjectName has not yet been implemented")
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	logger := utils.NewLogger("", "", "configuration-service")
+
+	if !common.ProjectExists(params.ProjectName) {
+		return project.NewPutProjectProjectNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist")})
+	}
+	credentials := getGitCredentials(params.Project)
+	if !credentials.Valid() {
+		return project.NewPutProjectProjectNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Git remote URI and either user and token or private key and known hosts have to be specified")})
+	}
+
+	existingCredentials, _ := common.GetCredentials(params.ProjectName)
+	if existingCredentials != nil && existingCredentials.PublicRemoteURI() == credentials.PublicRemoteURI() {
+		// the credentials of the upstream are rotated, hence it is sufficient to check that they grant access
+		if err := common.VerifyCredentials(credentials); err != nil {
+			logger.Error(err.Error())
+			return project.NewPutProjectProjectNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not access git repository")})
+		}
+	} else if err := common.PushBranches(params.ProjectName, credentials); err != nil {
+		// push the existing stages before storing the credentials to make sure the upstream is usable
+		logger.Error(err.Error())
+		return project.NewPutProjectProjectNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not push to git repository")})
+	}
+
+	err := common.StoreGitCredentials(params.ProjectName, credentials)
+	if err != nil {
+		logger.Error(err.Error())
+		return project.NewPutProjectProjectNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not store git credentials")})
+	}
+	logger.Debug("Updated upstream of project " + params.ProjectName)
+
+	return project.NewPutProjectProjectNameNoContent()
 }
 
 // DeleteProjectProjectNameHandlerFunc deletes a project
 func DeleteProjectProjectNameHandlerFunc(params project.DeleteProjectProjectNameParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	logger.Debug("Deleting project " + params.ProjectName)
-	err := os.RemoveAll(config.ConfigDir + "/" + params.ProjectName)
+	err := common.DeleteProject(params.ProjectName)
 	if err != nil {
 		logger.Error(err.Error())
 		return project.NewDeleteProjectProjectNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not delete project")})
@@ -181,3 +230,33 @@ func DeleteProjectProjectNameHandlerFunc(params project.DeleteProjectProjectName
 	logger.Debug("Project " + params.ProjectName + " has been deleted")
 	return project.NewDeleteProjectProjectNameNoContent()
 }
+
+// GetProjectProjectNameSyncHandlerFunc gets the synchronization status of a project with its upstream
+func GetProjectProjectNameSyncHandlerFunc(params project.GetProjectProjectNameSyncParams) middleware.Responder {
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return project.NewGetProjectProjectNameSyncNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
+	}
+	status, err := common.GetSyncStatus(params.ProjectName)
+	if err != nil {
+		logger.Error(err.Error())
+		return project.NewGetProjectProjectNameSyncDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not get synchronization status")})
+	}
+	return project.NewGetProjectProjectNameSyncOK().WithPayload(status)
+}
+
+// newMergeConflictResponder returns a 409 response for changes that conflict with the upstream of a project. The
+// operations share this response, hence it is not generated for each of them
+func newMergeConflictResponder(conflict *common.MergeConflictError) middleware.Responder {
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+		rw.WriteHeader(http.StatusConflict)
+		payload := &models.MergeConflict{
+			Code:      http.StatusConflict,
+			Message:   "Resources have been changed in the upstream repository as well",
+			Conflicts: conflict.Paths,
+		}
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	})
+}
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"encoding/base64"
-	"io/ioutil"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
@@ -15,31 +14,34 @@ import (
 
 // GetProjectProjectNameResourceHandlerFunc get list of project resources
 func GetProjectProjectNameResourceHandlerFunc(params project_resource.GetProjectProjectNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.RLockBranch(params.ProjectName, "master")
+	defer common.RUnlockBranch(params.ProjectName, "master")
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 
 		return project_resource.NewGetProjectProjectNameResourceNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project does not exist")})
 	}
 
-	err := common.CheckoutBranch(params.ProjectName, "master")
+	resources, err := common.ListResourcesInBranch(params.ProjectName, "master", "")
 	if err != nil {
 		logger.Error(err.Error())
 
-		return project_resource.NewGetProjectProjectNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
+		return project_resource.NewGetProjectProjectNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve project resources")})
+	}
+	result, errPayload := listResources(resources, params.ResourceURIPrefix, params.ResourceURIGlob, params.PageSize, params.NextPageKey)
+	if errPayload != nil {
+		return project_resource.NewGetProjectProjectNameResourceDefault(400).WithPayload(errPayload)
 	}
-
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	result := common.GetPaginatedResources(projectConfigPath, params.PageSize, params.NextPageKey)
 
 	return project_resource.NewGetProjectProjectNameResourceOK().WithPayload(result)
 }
 
 // PutProjectProjectNameResourceHandlerFunc update list of project resources
 func PutProjectProjectNameResourceHandlerFunc(params project_resource.PutProjectProjectNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 	logger := utils.NewLogger("", "", "configuration-service")
 
 	if !common.ProjectExists(params.ProjectName) {
@@ -52,6 +54,9 @@ func PutProjectProjectNameResourceHandlerFunc(params project_resource.PutProject
 	logger.Debug("Checking out master branch")
 	err := common.CheckoutBranch(params.ProjectName, "master")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 
 		return project_resource.NewPutProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
@@ -66,6 +71,9 @@ func PutProjectProjectNameResourceHandlerFunc(params project_resource.PutProject
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Updated resources")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 
 		return project_resource.NewPutProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
@@ -86,8 +94,10 @@ func PutProjectProjectNameResourceHandlerFunc(params project_resource.PutProject
 
 // PostProjectProjectNameResourceHandlerFunc creates a list of new resources
 func PostProjectProjectNameResourceHandlerFunc(params project_resource.PostProjectProjectNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 
@@ -99,6 +109,9 @@ func PostProjectProjectNameResourceHandlerFunc(params project_resource.PostProje
 	logger.Debug("Checking out master branch")
 	err := common.CheckoutBranch(params.ProjectName, "master")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 
 		return project_resource.NewPostProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
@@ -113,6 +126,9 @@ func PostProjectProjectNameResourceHandlerFunc(params project_resource.PostProje
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Added resources")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 
 		return project_resource.NewPostProjectProjectNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
@@ -133,30 +149,22 @@ func PostProjectProjectNameResourceHandlerFunc(params project_resource.PostProje
 
 // GetProjectProjectNameResourceResourceURIHandlerFunc gets the specified resource
 func GetProjectProjectNameResourceResourceURIHandlerFunc(params project_resource.GetProjectProjectNameResourceResourceURIParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.RLockBranch(params.ProjectName, "master")
+	defer common.RUnlockBranch(params.ProjectName, "master")
 	logger := utils.NewLogger("", "", "configuration-service")
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	resourcePath := projectConfigPath + "/" + params.ResourceURI
 	if !common.ProjectExists(params.ProjectName) {
 
 		return project_resource.NewGetProjectProjectNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
 	}
-	logger.Debug("Checking out master branch")
-	err := common.CheckoutBranch(params.ProjectName, "master")
-	if err != nil {
-		logger.Error(err.Error())
 
-		return project_resource.NewGetProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
-	}
+	dat, err := common.GetFileAtVersion(params.ProjectName, "master", swag.StringValue(params.Version), params.ResourceURI)
+	if err == common.ErrVersionNotFound {
 
-	if !common.FileExists(resourcePath) {
+		return project_resource.NewGetProjectProjectNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Version not found")})
+	} else if err == common.ErrFileNotFound {
 
 		return project_resource.NewGetProjectProjectNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project resource not found")})
-	}
-
-	dat, err := ioutil.ReadFile(resourcePath)
-	if err != nil {
+	} else if err != nil {
 		logger.Error(err.Error())
 
 		return project_resource.NewGetProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
@@ -171,10 +179,59 @@ func GetProjectProjectNameResourceResourceURIHandlerFunc(params project_resource
 		})
 }
 
+// GetProjectProjectNameResourceResourceURIHistoryHandlerFunc gets the versions of the specified resource
+func GetProjectProjectNameResourceResourceURIHistoryHandlerFunc(params project_resource.GetProjectProjectNameResourceResourceURIHistoryParams) middleware.Responder {
+	common.RLockBranch(params.ProjectName, "master")
+	defer common.RUnlockBranch(params.ProjectName, "master")
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return project_resource.NewGetProjectProjectNameResourceResourceURIHistoryNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
+	}
+
+	versions, err := common.GetFileHistory(params.ProjectName, "master", params.ResourceURI)
+	if err == common.ErrFileNotFound {
+		return project_resource.NewGetProjectProjectNameResourceResourceURIHistoryNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project resource not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return project_resource.NewGetProjectProjectNameResourceResourceURIHistoryDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve the versions of the resource")})
+	}
+
+	result, err := common.PaginateVersions(versions, params.PageSize, params.NextPageKey)
+	if err != nil {
+		return project_resource.NewGetProjectProjectNameResourceResourceURIHistoryDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String("Invalid next page key")})
+	}
+	return project_resource.NewGetProjectProjectNameResourceResourceURIHistoryOK().WithPayload(result)
+}
+
+// GetProjectProjectNameResourceResourceURIDiffHandlerFunc gets the changes of the specified resource between two versions
+func GetProjectProjectNameResourceResourceURIDiffHandlerFunc(params project_resource.GetProjectProjectNameResourceResourceURIDiffParams) middleware.Responder {
+	common.RLockBranch(params.ProjectName, "master")
+	defer common.RUnlockBranch(params.ProjectName, "master")
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return project_resource.NewGetProjectProjectNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
+	}
+
+	diff, err := common.GetFileDiff(params.ProjectName, "master", params.FromVersion, swag.StringValue(params.ToVersion), params.ResourceURI)
+	if err == common.ErrVersionNotFound {
+		return project_resource.NewGetProjectProjectNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Version not found")})
+	} else if err == common.ErrFileNotFound {
+		return project_resource.NewGetProjectProjectNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project resource not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return project_resource.NewGetProjectProjectNameResourceResourceURIDiffDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not compare the versions of the resource")})
+	}
+
+	diff.ResourceURI = params.ResourceURI
+	return project_resource.NewGetProjectProjectNameResourceResourceURIDiffOK().WithPayload(diff)
+}
+
 // PutProjectProjectNameResourceResourceURIHandlerFunc updates a resource
 func PutProjectProjectNameResourceResourceURIHandlerFunc(params project_resource.PutProjectProjectNameResourceResourceURIParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 
@@ -186,6 +243,9 @@ func PutProjectProjectNameResourceResourceURIHandlerFunc(params project_resource
 	logger.Debug("Checking out branch: master")
 	err := common.CheckoutBranch(params.ProjectName, "master")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 
 		return project_resource.NewPutProjectProjectNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
@@ -197,6 +257,9 @@ func PutProjectProjectNameResourceResourceURIHandlerFunc(params project_resource
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Updated resource: "+params.ResourceURI)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 
 		return project_resource.NewPutProjectProjectNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
@@ -218,8 +281,10 @@ func PutProjectProjectNameResourceResourceURIHandlerFunc(params project_resource
 
 // DeleteProjectProjectNameResourceResourceURIHandlerFunc deletes a project resource
 func DeleteProjectProjectNameResourceResourceURIHandlerFunc(params project_resource.DeleteProjectProjectNameResourceResourceURIParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 
@@ -229,6 +294,9 @@ func DeleteProjectProjectNameResourceResourceURIHandlerFunc(params project_resou
 	resourcePath := projectConfigPath + "/" + params.ResourceURI
 	err := common.CheckoutBranch(params.ProjectName, "master")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 
 		return project_resource.NewDeleteProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
@@ -243,6 +311,9 @@ func DeleteProjectProjectNameResourceResourceURIHandlerFunc(params project_resou
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Deleted resources")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 
 		return project_resource.NewDeleteProjectProjectNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not commit changes")})
@@ -1,8 +1,9 @@
 package handlers
 
 import (
-	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -22,8 +23,8 @@ type serviceMetadata struct {
 
 // GetProjectProjectNameStageStageNameServiceHandlerFunc get list of services
 func GetProjectProjectNameStageStageNameServiceHandlerFunc(params service.GetProjectProjectNameStageStageNameServiceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 		return service.NewGetProjectProjectNameStageStageNameServiceNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
@@ -38,43 +39,40 @@ func GetProjectProjectNameStageStageNameServiceHandlerFunc(params service.GetPro
 		Services:    []*models.Service{},
 	}
 
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	err := common.CheckoutBranch(params.ProjectName, params.StageName)
+	files, err := common.ListFilesInBranch(params.ProjectName, params.StageName, "")
 	if err != nil {
 		logger.Error(err.Error())
-		return service.NewGetProjectProjectNameStageStageNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
-	}
-	files, err := ioutil.ReadDir(projectConfigPath)
-	if err != nil {
-		return service.NewGetProjectProjectNameStageStageNameServiceOK().WithPayload(payload)
+		return service.NewGetProjectProjectNameStageStageNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve services")})
 	}
 
-	filteredFiles := []os.FileInfo{}
+	// services are the directories containing a metadata.yaml file
+	filteredFiles := []string{}
 	for _, file := range files {
-		if file.IsDir() && common.FileExists(projectConfigPath+"/"+file.Name()+"/metadata.yaml") {
-			filteredFiles = append(filteredFiles, file)
+		if strings.Count(file, "/") == 1 && strings.HasSuffix(file, "/metadata.yaml") {
+			filteredFiles = append(filteredFiles, strings.TrimSuffix(file, "/metadata.yaml"))
 		}
 	}
+	sort.Strings(filteredFiles)
 
-	paginationInfo := common.Paginate(len(filteredFiles), params.PageSize, params.NextPageKey)
-
-	totalCount := len(filteredFiles)
-	if paginationInfo.NextPageKey < int64(totalCount) {
-		for _, f := range filteredFiles[paginationInfo.NextPageKey:paginationInfo.EndIndex] {
-			var service = &models.Service{ServiceName: f.Name()}
-			payload.Services = append(payload.Services, service)
-		}
+	paginationInfo, err := common.Paginate(filteredFiles, params.PageSize, params.NextPageKey)
+	if err != nil {
+		return service.NewGetProjectProjectNameStageStageNameServiceDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String("Invalid next page key")})
+	}
+	for _, serviceName := range filteredFiles[paginationInfo.StartIndex:paginationInfo.EndIndex] {
+		var service = &models.Service{ServiceName: serviceName}
+		payload.Services = append(payload.Services, service)
 	}
 
-	payload.TotalCount = float64(totalCount)
+	payload.PageSize = float64(len(payload.Services))
+	payload.TotalCount = float64(len(filteredFiles))
 	payload.NextPageKey = paginationInfo.NewNextPageKey
 	return service.NewGetProjectProjectNameStageStageNameServiceOK().WithPayload(payload)
 }
 
 // GetProjectProjectNameStageStageNameServiceServiceNameHandlerFunc get the specified service
 func GetProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(params service.GetProjectProjectNameStageStageNameServiceServiceNameParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
 	if !common.ProjectExists(params.ProjectName) {
 		return service.NewGetProjectProjectNameStageStageNameServiceServiceNameNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
 	}
@@ -92,8 +90,10 @@ func GetProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(params ser
 
 // PostProjectProjectNameStageStageNameServiceHandlerFunc creates a new service
 func PostProjectProjectNameStageStageNameServiceHandlerFunc(params service.PostProjectProjectNameStageStageNameServiceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
 	servicePath := projectConfigPath + "/" + params.Service.ServiceName
@@ -109,6 +109,9 @@ func PostProjectProjectNameStageStageNameServiceHandlerFunc(params service.PostP
 	logger.Debug("Checking out branch: " + params.StageName)
 	err := common.CheckoutBranch(params.ProjectName, params.StageName)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return service.NewPostProjectProjectNameStageStageNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
 	}
@@ -125,8 +128,19 @@ func PostProjectProjectNameStageStageNameServiceHandlerFunc(params service.PostP
 
 	metadataString, err := yaml.Marshal(newServiceMetadata)
 	err = common.WriteFile(servicePath+"/metadata.yaml", metadataString)
+	if err != nil {
+		logger.Error(err.Error())
+		return service.NewPostProjectProjectNameStageStageNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not store service metadata")})
+	}
 
-	common.StageAndCommitAll(params.ProjectName, "Added service: "+params.Service.ServiceName)
+	err = common.StageAndCommitAll(params.ProjectName, "Added service: "+params.Service.ServiceName)
+	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
+		logger.Error(err.Error())
+		return service.NewPostProjectProjectNameStageStageNameServiceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not commit changes")})
+	}
 	return service.NewPostProjectProjectNameStageStageNameServiceNoContent()
 }
 
@@ -137,5 +151,90 @@ func PutProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(params ser
 
 // DeleteProjectProjectNameStageStageNameServiceServiceNameHandlerFunc deletes a service
 func DeleteProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(params service.DeleteProjectProjectNameStageStageNameServiceServiceNameParams) middleware.Responder {
-	return middleware.NotImplemented("operation service.DeleteProjectProjectNameStageStageNameServiceServiceName has not yet been implemented")
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+	servicePath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ServiceName
+
+	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName) {
+		return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Service does not exist")})
+	}
+	logger.Debug("Checking out branch: " + params.StageName)
+	err := common.CheckoutBranch(params.ProjectName, params.StageName)
+	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
+		logger.Error(err.Error())
+		return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
+	}
+	err = os.RemoveAll(servicePath)
+	if err != nil {
+		logger.Error(err.Error())
+		return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not delete service directory")})
+	}
+
+	err = common.StageAndCommitAll(params.ProjectName, "Deleted service: "+params.ServiceName)
+	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
+		logger.Error(err.Error())
+		return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not commit changes")})
+	}
+	logger.Debug("Service " + params.ServiceName + " has been deleted from stage " + params.StageName)
+	return service.NewDeleteProjectProjectNameStageStageNameServiceServiceNameNoContent()
+}
+
+// PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandlerFunc merges the changes of a service in another stage into the stage
+func PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandlerFunc(params service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteParams) middleware.Responder {
+	if params.Promotion == nil || params.Promotion.SourceStage == "" || params.Promotion.SourceStage == params.StageName {
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Source stage has to be specified")})
+	}
+	sourceStage := params.Promotion.SourceStage
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+
+	if !common.StageExists(params.ProjectName, params.StageName) {
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage " + params.StageName + " does not exist")})
+	}
+	if !common.ServiceExists(params.ProjectName, sourceStage, params.ServiceName) {
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Service does not exist in stage " + sourceStage)})
+	}
+
+	logger.Debug("Checking out branch: " + params.StageName)
+	err := common.CheckoutBranch(params.ProjectName, params.StageName)
+	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
+		logger.Error(err.Error())
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
+	}
+
+	logger.Debug("Promoting service " + params.ServiceName + " from stage " + sourceStage + " to stage " + params.StageName)
+	err = common.PromoteDirectory(params.ProjectName, sourceStage, params.StageName, params.ServiceName, "Promoted service "+params.ServiceName+" from stage "+sourceStage)
+	if conflict, ok := err.(*common.MergeConflictError); ok {
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteConflict().WithPayload(&models.MergeConflict{
+			Code:      409,
+			Message:   "Resources of the service have been changed in both stages",
+			Conflicts: conflict.Paths,
+		})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not promote service")})
+	}
+
+	newVersion, err := common.GetCurrentVersion(params.ProjectName)
+	if err != nil {
+		logger.Error(err.Error())
+		return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve latest version")})
+	}
+	logger.Debug("Successfully promoted service " + params.ServiceName)
+	return service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated().WithPayload(&models.Version{Version: newVersion})
 }
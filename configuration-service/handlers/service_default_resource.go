@@ -1,6 +1,11 @@
 package handlers
 
 import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	"github.com/keptn/go-utils/pkg/utils"
@@ -10,78 +15,300 @@ import (
 	"github.com/keptn/keptn/configuration-service/restapi/operations/service_default_resource"
 )
 
+// defaultResourcesDirectory is the directory on the master branch containing the default resources of the services
+const defaultResourcesDirectory = "defaults"
+
+const (
+	resourceOriginStage   = "stage"
+	resourceOriginDefault = "default"
+)
+
+var errServiceNotFound = errors.New("Service does not exist")
+
+// getDefaultResourcesPath returns the directory containing the default resources of a service on the master branch
+func getDefaultResourcesPath(serviceName string) string {
+	return defaultResourcesDirectory + "/" + serviceName
+}
+
 // GetProjectProjectNameServiceServiceNameResourceHandlerFunc get list of default resources for the service
 func GetProjectProjectNameServiceServiceNameResourceHandlerFunc(params service_default_resource.GetProjectProjectNameServiceServiceNameResourceParams) middleware.Responder {
-	return middleware.NotImplemented("operation service_default_resource.GetProjectProjectNameServiceServiceNameResource has not yet been implemented")
+	logger := utils.NewLogger("", "", "configuration-service")
+	_, err := getStagesOfService(params.ProjectName, params.ServiceName)
+	if err == errServiceNotFound {
+		return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not get stages for project")})
+	}
+
+	common.RLockBranch(params.ProjectName, "master")
+	defer common.RUnlockBranch(params.ProjectName, "master")
+	resources, err := listDefaultResources(params.ProjectName, params.ServiceName)
+	if err != nil {
+		logger.Error(err.Error())
+		return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve service default resources")})
+	}
+
+	result, errPayload := listResources(resources, params.ResourceURIPrefix, params.ResourceURIGlob, params.PageSize, params.NextPageKey)
+	if errPayload != nil {
+		return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceDefault(400).WithPayload(errPayload)
+	}
+	return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceOK().WithPayload(result)
 }
 
 // GetProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc gets a specified default resource
 func GetProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(params service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
-	return middleware.NotImplemented("operation service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURI has not yet been implemented")
+	common.RLockBranch(params.ProjectName, "master")
+	defer common.RUnlockBranch(params.ProjectName, "master")
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
+	}
+
+	dat, err := getDefaultResource(params.ProjectName, params.ServiceName, params.ResourceURI)
+	if err == common.ErrFileNotFound {
+		return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Service default resource not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
+	}
+
+	resourceContent := base64.StdEncoding.EncodeToString(dat)
+	return service_default_resource.NewGetProjectProjectNameServiceServiceNameResourceResourceURIOK().WithPayload(
+		&models.Resource{
+			ResourceURI:     &params.ResourceURI,
+			ResourceContent: resourceContent,
+			Origin:          resourceOriginDefault,
+		})
 }
 
 // PostProjectProjectNameServiceServiceNameResourceHandlerFunc creates a list of new default resources
 func PostProjectProjectNameServiceServiceNameResourceHandlerFunc(params service_default_resource.PostProjectProjectNameServiceServiceNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
-	logger := utils.NewLogger("", "", "configuration-service")
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 
-	if !common.ProjectExists(params.ProjectName) {
-		return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceDefault(404).WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist")})
+	version, errPayload, conflict := updateDefaultResources(params.ProjectName, params.ServiceName, "Added default resources", func(defaultResourcesPath string) error {
+		return writeResources(defaultResourcesPath, params.Resources.Resources)
+	})
+	if conflict != nil {
+		return newMergeConflictResponder(conflict)
+	}
+	if errPayload != nil {
+		return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceBadRequest().WithPayload(errPayload)
 	}
 
-	branches, err := common.GetBranches(params.ProjectName)
-	if err != nil {
-		logger.Error(err.Error())
-		return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceDefault(500).WithPayload(&models.Error{Code: 400, Message: swag.String("Could not get stages for project")})
+	return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceCreated().WithPayload(version)
+}
+
+// PutProjectProjectNameServiceServiceNameResourceHandlerFunc updates a list of default resources
+func PutProjectProjectNameServiceServiceNameResourceHandlerFunc(params service_default_resource.PutProjectProjectNameServiceServiceNameResourceParams) middleware.Responder {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
+
+	version, errPayload, conflict := updateDefaultResources(params.ProjectName, params.ServiceName, "Updated default resources", func(defaultResourcesPath string) error {
+		return writeResources(defaultResourcesPath, params.Resources.Resources)
+	})
+	if conflict != nil {
+		return newMergeConflictResponder(conflict)
 	}
+	if errPayload != nil {
+		return service_default_resource.NewPutProjectProjectNameServiceServiceNameResourceBadRequest().WithPayload(errPayload)
+	}
+
+	return service_default_resource.NewPutProjectProjectNameServiceServiceNameResourceCreated().WithPayload(version)
+}
+
+// PutProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc updates the specified resource for the service
+func PutProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(params service_default_resource.PutProjectProjectNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
 
+	if params.Resource == nil {
+		return service_default_resource.NewPutProjectProjectNameServiceServiceNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Resource has to be specified")})
+	}
+
+	version, errPayload, conflict := updateDefaultResources(params.ProjectName, params.ServiceName, "Updated default resource: "+params.ResourceURI, func(defaultResourcesPath string) error {
+		return writeResource(defaultResourcesPath, params.ResourceURI, params.Resource.ResourceContent)
+	})
+	if conflict != nil {
+		return newMergeConflictResponder(conflict)
+	}
+	if errPayload != nil {
+		return service_default_resource.NewPutProjectProjectNameServiceServiceNameResourceResourceURIBadRequest().WithPayload(errPayload)
+	}
+
+	return service_default_resource.NewPutProjectProjectNameServiceServiceNameResourceResourceURICreated().WithPayload(version)
+}
+
+// DeleteProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc deletes the specified resource from the service
+func DeleteProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(params service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
+
+	_, errPayload, conflict := updateDefaultResources(params.ProjectName, params.ServiceName, "Deleted default resource: "+params.ResourceURI, func(defaultResourcesPath string) error {
+		resourcePath, _ := getServiceResourcePath(defaultResourcesPath, params.ResourceURI)
+		if !common.FileExists(resourcePath) {
+			return common.ErrFileNotFound
+		}
+		return os.RemoveAll(resourcePath)
+	})
+	if conflict != nil {
+		return newMergeConflictResponder(conflict)
+	}
+	if errPayload != nil {
+		return service_default_resource.NewDeleteProjectProjectNameServiceServiceNameResourceResourceURIBadRequest().WithPayload(errPayload)
+	}
+
+	return service_default_resource.NewDeleteProjectProjectNameServiceServiceNameResourceResourceURINoContent()
+}
+
+// getStagesOfService returns the stages of the project that contain the service
+func getStagesOfService(project string, service string) ([]string, error) {
+	if !common.ProjectExists(project) {
+		return nil, errServiceNotFound
+	}
+	branches, err := common.GetBranches(project)
+	if err != nil {
+		return nil, err
+	}
+	stages := []string{}
 	for _, branch := range branches {
 		if branch == "master" || branch == "" {
 			continue
 		}
-		if !common.ServiceExists(params.ProjectName, branch, params.ServiceName) {
-			return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceDefault(404).WithPayload(&models.Error{Code: 400, Message: swag.String("Service does not exist")})
-		}
-		serviceConfigPath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ServiceName
-
-		logger.Debug("Creating new resource(s) in: " + serviceConfigPath + " in stage " + branch)
-		logger.Debug("Checking out branch: " + branch)
-		err := common.CheckoutBranch(params.ProjectName, branch)
-		if err != nil {
-			logger.Error(err.Error())
-			return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
+		common.RLockBranch(project, branch)
+		exists := common.ServiceExists(project, branch, service)
+		common.RUnlockBranch(project, branch)
+		if exists {
+			stages = append(stages, branch)
 		}
+	}
+	if len(stages) == 0 {
+		return nil, errServiceNotFound
+	}
+	return stages, nil
+}
 
-		for _, res := range params.Resources.Resources {
-			filePath := serviceConfigPath + "/" + *res.ResourceURI
-			logger.Debug("Adding resource: " + filePath)
-			common.WriteBase64EncodedFile(filePath, res.ResourceContent)
-		}
+// listDefaultResources lists the default resources of a service with their metadata. The caller has to hold the lock
+// of the master branch.
+func listDefaultResources(project string, service string) ([]*models.Resource, error) {
+	defaultResourcesPath := getDefaultResourcesPath(service)
+	if !common.DirectoryExistsInBranch(project, "master", defaultResourcesPath) {
+		return []*models.Resource{}, nil
+	}
+	resources, err := common.ListResourcesInBranch(project, "master", defaultResourcesPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		resource.Origin = resourceOriginDefault
+	}
+	return resources, nil
+}
 
-		logger.Debug("Staging Changes")
-		err = common.StageAndCommitAll(params.ProjectName, "Added resources")
-		if err != nil {
-			logger.Error(err.Error())
-			return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
-		}
-		logger.Debug("Successfully added resources")
+// listResources returns the page of the resources matching the filters of a listing request, or the payload of the
+// error if the filters or the next page key are invalid
+func listResources(resources []*models.Resource, resourceURIPrefix *string, resourceURIGlob *string, pageSize *int64, nextPageKey *string) (*models.Resources, *models.Error) {
+	resources, err := common.FilterResources(resources, resourceURIPrefix, resourceURIGlob)
+	if err != nil {
+		return nil, &models.Error{Code: 400, Message: swag.String("Invalid resourceURIGlob")}
 	}
+	result, err := common.PaginateResourceList(resources, pageSize, nextPageKey)
+	if err != nil {
+		return nil, &models.Error{Code: 400, Message: swag.String("Invalid next page key")}
+	}
+	return result, nil
+}
 
-	return service_default_resource.NewPostProjectProjectNameServiceServiceNameResourceCreated()
+// getDefaultResource reads a default resource of a service; Helm charts are archived from their directory.
+// The caller has to hold the lock of the master branch.
+func getDefaultResource(project string, service string, resourceURI string) ([]byte, error) {
+	resourcePath, isHelmChart := getServiceResourcePath(getDefaultResourcesPath(service), resourceURI)
+	if isHelmChart {
+		return common.ArchiveDirectoryFromBranch(project, "master", resourcePath)
+	}
+	return common.GetFileFromBranch(project, "master", resourcePath)
 }
 
-// PutProjectProjectNameServiceServiceNameResourceHandlerFunc updates a list of default resources
-func PutProjectProjectNameServiceServiceNameResourceHandlerFunc(params service_default_resource.PutProjectProjectNameServiceServiceNameResourceParams) middleware.Responder {
-	return middleware.NotImplemented("operation service_default_resource.PutProjectProjectNameServiceServiceNameResource has not yet been implemented")
+// writeResources writes the base64 encoded resources into the given directory
+func writeResources(directory string, resources []*models.Resource) error {
+	for _, res := range resources {
+		if err := writeResource(directory, *res.ResourceURI, res.ResourceContent); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// PutProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc updates the specified resource for the service
-func PutProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(params service_default_resource.PutProjectProjectNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
-	return middleware.NotImplemented("operation service_default_resource.PutProjectProjectNameServiceServiceNameResourceResourceURI has not yet been implemented")
+// writeResource writes a base64 encoded resource into the given directory. Helm charts are unpacked like the ones of
+// stages, hence they are read and deleted the same way
+func writeResource(directory string, resourceURI string, resourceContent string) error {
+	filePath := directory + "/" + resourceURI
+	if err := common.WriteBase64EncodedFile(filePath, resourceContent); err != nil {
+		return err
+	}
+	if strings.Contains(filePath, "helm") && strings.HasSuffix(resourceURI, ".tgz") {
+		return unarchiveHelmChart(resourceURI, filePath, utils.NewLogger("", "", "configuration-service"))
+	}
+	return nil
 }
 
-// DeleteProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc deletes the specified resource from the service
-func DeleteProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(params service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
-	return middleware.NotImplemented("operation service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURI has not yet been implemented")
+// updateDefaultResources applies the update to the default resources of a service on the master branch and commits
+// the changes. The caller has to hold the locks of the project and of the master branch. If the master branch
+// conflicts with its upstream, nothing is changed and the conflict is returned instead of an error payload.
+func updateDefaultResources(project string, service string, message string, update func(defaultResourcesPath string) error) (*models.Version, *models.Error, *common.MergeConflictError) {
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(project) {
+		return nil, &models.Error{Code: 400, Message: swag.String("Project does not exist")}, nil
+	}
+	_, err := getStagesOfService(project, service)
+	if err == errServiceNotFound {
+		return nil, &models.Error{Code: 400, Message: swag.String("Service does not exist")}, nil
+	} else if err != nil {
+		logger.Error(err.Error())
+		return nil, &models.Error{Code: 400, Message: swag.String("Could not get stages for project")}, nil
+	}
+	defaultResourcesPath := config.ConfigDir + "/" + project + "/" + getDefaultResourcesPath(service)
+
+	logger.Debug("Updating default resource(s) in: " + defaultResourcesPath)
+	err = common.CheckoutBranch(project, "master")
+	if conflict, ok := err.(*common.MergeConflictError); ok {
+		return nil, nil, conflict
+	} else if err != nil {
+		logger.Error(err.Error())
+		return nil, &models.Error{Code: 400, Message: swag.String("Could not check out branch")}, nil
+	}
+
+	err = update(defaultResourcesPath)
+	if err == common.ErrFileNotFound {
+		return nil, &models.Error{Code: 400, Message: swag.String("Service default resource does not exist")}, nil
+	} else if err != nil {
+		logger.Error(err.Error())
+		return nil, &models.Error{Code: 400, Message: swag.String("Could not update default resources")}, nil
+	}
+
+	logger.Debug("Staging Changes")
+	err = common.StageAndCommitAll(project, message)
+	if conflict, ok := err.(*common.MergeConflictError); ok {
+		return nil, nil, conflict
+	} else if err != nil {
+		logger.Error(err.Error())
+		return nil, &models.Error{Code: 400, Message: swag.String("Could not commit changes")}, nil
+	}
+	logger.Debug("Successfully updated default resources of service " + service)
+
+	newVersion, err := common.GetCurrentVersion(project)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, &models.Error{Code: 400, Message: swag.String("Could not retrieve latest version")}, nil
+	}
+	return &models.Version{Version: newVersion}, nil, nil
 }
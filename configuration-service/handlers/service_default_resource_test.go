@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/swag"
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/models"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/service_default_resource"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/service_resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func getServiceResource(project string, stage string, service string, resourceURI string) (*models.Resource, bool) {
+	responder := GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: project,
+		StageName:   stage,
+		ServiceName: service,
+		ResourceURI: resourceURI,
+	})
+	response, ok := responder.(*service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIOK)
+	if !ok {
+		return nil, false
+	}
+	content, _ := base64.StdEncoding.DecodeString(response.Payload.ResourceContent)
+	response.Payload.ResourceContent = string(content)
+	return response.Payload, true
+}
+
+func TestServiceDefaultResources(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev", "staging")
+	for _, stage := range []string{"dev", "staging"} {
+		commitTestFiles(t, "sockshop", stage, map[string]string{
+			"carts/metadata.yaml": "servicename: carts\n",
+		})
+	}
+
+	responder := PutProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(service_default_resource.PutProjectProjectNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		ResourceURI: "slo.yaml",
+		Resource: &models.Resource{
+			ResourceURI:     swag.String("slo.yaml"),
+			ResourceContent: base64.StdEncoding.EncodeToString([]byte("objectives: []\n")),
+		},
+	})
+	response, ok := responder.(*service_default_resource.PutProjectProjectNameServiceServiceNameResourceResourceURICreated)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	// the default resource is stored once on the master branch
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	assert.Equal(t, response.Payload.Version, runTestGit(t, projectConfigPath, "rev-parse", "master")[:len(response.Payload.Version)])
+	assert.Equal(t, "objectives: []\n", runTestGit(t, projectConfigPath, "show", "master:defaults/carts/slo.yaml"))
+	assert.Equal(t, "", runTestGit(t, projectConfigPath, "ls-tree", "-r", "--name-only", "dev", "carts/slo.yaml"))
+
+	pageSize := int64(10)
+	listResponder := GetProjectProjectNameServiceServiceNameResourceHandlerFunc(service_default_resource.GetProjectProjectNameServiceServiceNameResourceParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		PageSize:    &pageSize,
+	})
+	list, ok := listResponder.(*service_default_resource.GetProjectProjectNameServiceServiceNameResourceOK)
+	if assert.True(t, ok) && assert.Len(t, list.Payload.Resources, 1) {
+		assert.Equal(t, "slo.yaml", *list.Payload.Resources[0].ResourceURI)
+	}
+
+	getResponder := GetProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		ResourceURI: "slo.yaml",
+	})
+	get, ok := getResponder.(*service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURIOK)
+	if assert.True(t, ok) {
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("objectives: []\n")), get.Payload.ResourceContent)
+	}
+
+	deleteResponder := DeleteProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		ResourceURI: "slo.yaml",
+	})
+	_, ok = deleteResponder.(*service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURINoContent)
+	assert.True(t, ok)
+	assert.Equal(t, "Deleted default resource: slo.yaml\n", runTestGit(t, projectConfigPath, "log", "-1", "--format=%s", "master"))
+
+	getResponder = GetProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		ResourceURI: "slo.yaml",
+	})
+	_, ok = getResponder.(*service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURINotFound)
+	assert.True(t, ok)
+
+	deleteResponder = DeleteProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		ResourceURI: "slo.yaml",
+	})
+	_, ok = deleteResponder.(*service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURIBadRequest)
+	assert.True(t, ok)
+}
+
+// archiveTestChart returns a Helm chart archive containing the given files
+func archiveTestChart(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for path, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: path, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// unarchiveTestChart returns the files of a Helm chart archive
+func unarchiveTestChart(t *testing.T, archive []byte) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(content)
+	}
+}
+
+// TestServiceDefaultHelmChart checks that a default Helm chart is unpacked like the charts of stages, hence it can be
+// read and deleted
+func TestServiceDefaultHelmChart(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml": "servicename: carts\n",
+	})
+	chart := map[string]string{
+		"carts/Chart.yaml":  "name: carts\n",
+		"carts/values.yaml": "replicas: 1\n",
+	}
+
+	responder := PostProjectProjectNameServiceServiceNameResourceHandlerFunc(service_default_resource.PostProjectProjectNameServiceServiceNameResourceParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		Resources: service_default_resource.PostProjectProjectNameServiceServiceNameResourceBody{
+			Resources: []*models.Resource{{
+				ResourceURI:     swag.String("helm/carts.tgz"),
+				ResourceContent: base64.StdEncoding.EncodeToString(archiveTestChart(t, chart)),
+			}},
+		},
+	})
+	_, ok := responder.(*service_default_resource.PostProjectProjectNameServiceServiceNameResourceCreated)
+	if !assert.True(t, ok) {
+		return
+	}
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	assert.Equal(t, "defaults/carts/helm/carts/Chart.yaml\ndefaults/carts/helm/carts/values.yaml\n",
+		runTestGit(t, projectConfigPath, "ls-tree", "-r", "--name-only", "master", "defaults"))
+
+	getResponder := GetProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		ResourceURI: "helm/carts.tgz",
+	})
+	get, ok := getResponder.(*service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURIOK)
+	if assert.True(t, ok) {
+		archive, _ := base64.StdEncoding.DecodeString(get.Payload.ResourceContent)
+		assert.Equal(t, chart, unarchiveTestChart(t, archive))
+	}
+
+	// the stage falls back to the default chart
+	resource, ok := getServiceResource("sockshop", "dev", "carts", "helm/carts.tgz")
+	if assert.True(t, ok) {
+		assert.Equal(t, resourceOriginDefault, resource.Origin)
+		assert.Equal(t, chart, unarchiveTestChart(t, []byte(resource.ResourceContent)))
+	}
+
+	deleteResponder := DeleteProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+		ResourceURI: "helm/carts.tgz",
+	})
+	_, ok = deleteResponder.(*service_default_resource.DeleteProjectProjectNameServiceServiceNameResourceResourceURINoContent)
+	assert.True(t, ok)
+	assert.Equal(t, "", runTestGit(t, projectConfigPath, "ls-tree", "-r", "--name-only", "master", "defaults"))
+}
+
+func TestServiceResourcesInheritDefaults(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{
+		"defaults/carts/slo.yaml":    "objectives: []\n",
+		"defaults/carts/values.yaml": "replicas: 1\n",
+	}, "dev", "staging")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml": "servicename: carts\n",
+		"carts/values.yaml":   "replicas: 3\n",
+	})
+	commitTestFiles(t, "sockshop", "staging", map[string]string{
+		"carts/metadata.yaml": "servicename: carts\n",
+	})
+
+	tests := []struct {
+		stage   string
+		content string
+		origin  string
+	}{
+		{"dev", "replicas: 3\n", resourceOriginStage},
+		{"staging", "replicas: 1\n", resourceOriginDefault},
+	}
+	for _, tt := range tests {
+		resource, ok := getServiceResource("sockshop", tt.stage, "carts", "values.yaml")
+		if assert.True(t, ok, tt.stage) {
+			assert.Equal(t, tt.content, resource.ResourceContent, tt.stage)
+			assert.Equal(t, tt.origin, resource.Origin, tt.stage)
+		}
+	}
+	_, ok := getServiceResource("sockshop", "dev", "carts", "dashboard.json")
+	assert.False(t, ok)
+
+	pageSize := int64(10)
+	responder := GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "carts",
+		PageSize:    &pageSize,
+	})
+	response, ok := responder.(*service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceOK)
+	if assert.True(t, ok) {
+		resources := map[string]string{}
+		for _, resource := range response.Payload.Resources {
+			resources[*resource.ResourceURI] = resource.Origin
+		}
+		assert.Equal(t, map[string]string{
+			"metadata.yaml": resourceOriginStage,
+			"slo.yaml":      resourceOriginDefault,
+			"values.yaml":   resourceOriginStage,
+		}, resources)
+	}
+
+	// removing the override of a stage reveals the default resource again
+	deleteResponder := DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "carts",
+		ResourceURI: "values.yaml",
+	})
+	_, ok = deleteResponder.(*service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINoContent)
+	assert.True(t, ok)
+	resource, ok := getServiceResource("sockshop", "dev", "carts", "values.yaml")
+	if assert.True(t, ok) {
+		assert.Equal(t, "replicas: 1\n", resource.ResourceContent)
+		assert.Equal(t, resourceOriginDefault, resource.Origin)
+	}
+}
+
+func TestServiceDefaultResourcesOfUnknownService(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev")
+
+	responder := PutProjectProjectNameServiceServiceNameResourceHandlerFunc(service_default_resource.PutProjectProjectNameServiceServiceNameResourceParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+	})
+	_, ok := responder.(*service_default_resource.PutProjectProjectNameServiceServiceNameResourceBadRequest)
+	assert.True(t, ok)
+
+	listResponder := GetProjectProjectNameServiceServiceNameResourceHandlerFunc(service_default_resource.GetProjectProjectNameServiceServiceNameResourceParams{
+		ProjectName: "sockshop",
+		ServiceName: "carts",
+	})
+	_, ok = listResponder.(*service_default_resource.GetProjectProjectNameServiceServiceNameResourceNotFound)
+	assert.True(t, ok)
+}
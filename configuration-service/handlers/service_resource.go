@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"encoding/base64"
+	"errors"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -22,84 +25,229 @@ import (
 // GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc get list of resources for the service
 func GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) middleware.Responder {
-	return middleware.NotImplemented("operation service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResource has not yet been implemented")
-}
-
-// GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc gets the specified resource
-func GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(
-	params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
 	logger := utils.NewLogger("", "", "configuration-service")
-	serviceConfigPath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ServiceName
-	resourcePath := serviceConfigPath + "/" + params.ResourceURI
-	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName) {
-		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
-			WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	stageResources, err := listServiceResourcesOfStage(params.ProjectName, params.StageName, params.ServiceName)
+	if err == errServiceNotFound {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve service resources")})
 	}
-	logger.Debug("Checking out " + params.StageName + " branch")
-	err := common.CheckoutBranch(params.ProjectName, params.StageName)
+
+	common.RLockBranch(params.ProjectName, "master")
+	defaultResources, err := listDefaultResources(params.ProjectName, params.ServiceName)
+	common.RUnlockBranch(params.ProjectName, "master")
 	if err != nil {
 		logger.Error(err.Error())
-		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(500).
-			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve service default resources")})
 	}
 
-	// archive the Helm chart
-	if strings.Contains(resourcePath, "helm") && strings.Contains(params.ResourceURI, ".tgz") {
-		logger.Debug("Archive the Helm chart: " + params.ResourceURI)
-
-		chartDir := strings.Replace(resourcePath, ".tgz", "", -1)
-		if archiver.Archive([]string{chartDir}, resourcePath) != nil {
-			logger.Error(err.Error())
-			return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-				WithPayload(&models.Error{Code: 400, Message: swag.String("Could archive the Helm chart directory")})
+	// default resources are inherited unless the stage overrides them
+	resources := []*models.Resource{}
+	overridden := map[string]bool{}
+	for _, resource := range stageResources {
+		resource.Origin = resourceOriginStage
+		resources = append(resources, resource)
+		overridden[*resource.ResourceURI] = true
+	}
+	for _, resource := range defaultResources {
+		if !overridden[*resource.ResourceURI] {
+			resources = append(resources, resource)
 		}
 	}
+	sort.Slice(resources, func(i, j int) bool {
+		return *resources[i].ResourceURI < *resources[j].ResourceURI
+	})
 
-	if !common.FileExists(resourcePath) {
-		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
-			WithPayload(&models.Error{Code: 404, Message: swag.String("Service resource not found")})
+	result, errPayload := listResources(resources, params.ResourceURIPrefix, params.ResourceURIGlob, params.PageSize, params.NextPageKey)
+	if errPayload != nil {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceDefault(400).WithPayload(errPayload)
 	}
+	return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceOK().WithPayload(result)
+}
 
-	dat, err := ioutil.ReadFile(resourcePath)
-	if err != nil {
+// listServiceResourcesOfStage lists the resources of a service in a stage with their metadata while holding the read
+// lock of the branch
+func listServiceResourcesOfStage(project string, stage string, service string) ([]*models.Resource, error) {
+	common.RLockBranch(project, stage)
+	defer common.RUnlockBranch(project, stage)
+	if !common.ServiceExists(project, stage, service) {
+		return nil, errServiceNotFound
+	}
+	return common.ListResourcesInBranch(project, stage, service)
+}
+
+// GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc gets the specified resource.
+// Resources which are not defined in the stage are inherited from the default resources of the service.
+func GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(
+	params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
+	logger := utils.NewLogger("", "", "configuration-service")
+	origin := resourceOriginStage
+	dat, err := getServiceResourceOfStage(params.ProjectName, params.StageName, params.ServiceName, params.ResourceURI, swag.StringValue(params.Version))
+	if err == common.ErrFileNotFound && params.Version == nil {
+		common.RLockBranch(params.ProjectName, "master")
+		dat, err = getDefaultResource(params.ProjectName, params.ServiceName, params.ResourceURI)
+		common.RUnlockBranch(params.ProjectName, "master")
+		origin = resourceOriginDefault
+	}
+	if err == errServiceNotFound {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	} else if err == common.ErrVersionNotFound {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Version not found")})
+	} else if err == common.ErrFileNotFound {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound().
+			WithPayload(&models.Error{Code: 404, Message: swag.String("Service resource not found")})
+	} else if err != nil {
 		logger.Error(err.Error())
 		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(500).
 			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
 	}
 
-	// remove Helch chart .tgz file
-	if strings.Contains(resourcePath, "helm") && strings.HasSuffix(params.ResourceURI, ".tgz") {
-		logger.Debug("Remove the Helm chart: " + params.ResourceURI)
-
-		if os.Remove(resourcePath) != nil {
-			logger.Error(err.Error())
-			return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-				WithPayload(&models.Error{Code: 400, Message: swag.String("Could not delete Helm chart package")})
-		}
-	}
-
 	resourceContent := base64.StdEncoding.EncodeToString(dat)
 	return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIOK().WithPayload(
 		&models.Resource{
 			ResourceURI:     &params.ResourceURI,
 			ResourceContent: resourceContent,
+			Origin:          origin,
 		})
 }
 
+// getServiceResourceOfStage reads a resource of a service in a stage while holding the read lock of the branch
+func getServiceResourceOfStage(project string, stage string, service string, resourceURI string, version string) ([]byte, error) {
+	common.RLockBranch(project, stage)
+	defer common.RUnlockBranch(project, stage)
+	if !common.ServiceExists(project, stage, service) {
+		return nil, errServiceNotFound
+	}
+
+	resourcePath, isHelmChart := getServiceResourcePath(service, resourceURI)
+	if isHelmChart {
+		// the Helm chart is archived from the chart directory of the branch
+		return common.ArchiveDirectoryAtVersion(project, stage, version, resourcePath)
+	}
+	return common.GetFileAtVersion(project, stage, version, resourcePath)
+}
+
+// getServiceResourcePath returns the path of a resource of a service in the repository. Helm charts (helm/<chart>.tgz)
+// are stored as directory
+func getServiceResourcePath(serviceName string, resourceURI string) (string, bool) {
+	resourcePath := serviceName + "/" + resourceURI
+	if strings.Contains(resourcePath, "helm") && strings.Contains(resourceURI, ".tgz") {
+		return strings.Replace(resourcePath, ".tgz", "", -1), true
+	}
+	return resourcePath, false
+}
+
+// GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandlerFunc gets the versions of the specified resource
+func GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandlerFunc(params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryParams) middleware.Responder {
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName) {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	}
+
+	resourcePath, _ := getServiceResourcePath(params.ServiceName, params.ResourceURI)
+	versions, err := common.GetFileHistory(params.ProjectName, params.StageName, resourcePath)
+	if err == common.ErrFileNotFound {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Service resource not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve the versions of the resource")})
+	}
+
+	result, err := common.PaginateVersions(versions, params.PageSize, params.NextPageKey)
+	if err != nil {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String("Invalid next page key")})
+	}
+	return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryOK().WithPayload(result)
+}
+
+// GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandlerFunc gets the changes of the specified resource between two versions
+func GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandlerFunc(params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffParams) middleware.Responder {
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName) {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Service not found")})
+	}
+
+	resourcePath, _ := getServiceResourcePath(params.ServiceName, params.ResourceURI)
+	diff, err := common.GetFileDiff(params.ProjectName, params.StageName, params.FromVersion, swag.StringValue(params.ToVersion), resourcePath)
+	if err == common.ErrVersionNotFound {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Version not found")})
+	} else if err == common.ErrFileNotFound {
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Service resource not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not compare the versions of the resource")})
+	}
+
+	diff.ResourceURI = params.ResourceURI
+	return service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffOK().WithPayload(diff)
+}
+
 // DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc deletes the specified resource
 func DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(
 	params service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
-	return middleware.NotImplemented(
-		"operation service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURI has not yet been implemented")
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName) {
+		return service_resource.NewDeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest().
+			WithPayload(&models.Error{Code: 400, Message: swag.String("Service does not exist")})
+	}
+	resourcePath, _ := getServiceResourcePath(params.ServiceName, params.ResourceURI)
+	resourcePath = config.ConfigDir + "/" + params.ProjectName + "/" + resourcePath
+
+	logger.Debug("Checking out branch: " + params.StageName)
+	err := common.CheckoutBranch(params.ProjectName, params.StageName)
+	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
+		logger.Error(err.Error())
+		return service_resource.NewDeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(500).
+			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
+	}
+	if !common.FileExists(resourcePath) {
+		return service_resource.NewDeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest().
+			WithPayload(&models.Error{Code: 400, Message: swag.String("Service resource does not exist")})
+	}
+	// helm charts are stored as directories
+	err = os.RemoveAll(resourcePath)
+	if err != nil {
+		logger.Error(err.Error())
+		return service_resource.NewDeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(500).
+			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not delete file")})
+	}
+
+	logger.Debug("Staging Changes")
+	err = common.StageAndCommitAll(params.ProjectName, "Deleted resource: "+params.ResourceURI)
+	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
+		logger.Error(err.Error())
+		return service_resource.NewDeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDefault(500).
+			WithPayload(&models.Error{Code: 500, Message: swag.String("Could not commit changes")})
+	}
+	logger.Debug("Successfully deleted resource: " + params.ResourceURI)
+
+	return service_resource.NewDeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINoContent()
 }
 
 // PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc creates a new resource
 func PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	params service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
@@ -119,6 +267,9 @@ func PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	logger.Debug("Checking out branch: " + params.StageName)
 	err := common.CheckoutBranch(params.ProjectName, params.StageName)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
@@ -140,6 +291,9 @@ func PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Added resources")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
@@ -159,8 +313,18 @@ func PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 }
 
 func untarHelm(res *models.Resource, logger *utils.Logger, filePath string) middleware.Responder {
+	if err := unarchiveHelmChart(*res.ResourceURI, filePath, logger); err != nil {
+		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
+			WithPayload(&models.Error{Code: 400, Message: swag.String(err.Error())})
+	}
+	return nil
+}
+
+// unarchiveHelmChart replaces the Helm chart archive at filePath by the directory of the chart. The returned error
+// describes the failure for the client, its cause is logged
+func unarchiveHelmChart(resourceURI string, filePath string, logger *utils.Logger) error {
 	// unarchive the Helm chart
-	logger.Debug("Unarchive the Helm chart: " + *res.ResourceURI)
+	logger.Debug("Unarchive the Helm chart: " + resourceURI)
 	tmpDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		log.Fatal(err)
@@ -169,52 +333,44 @@ func untarHelm(res *models.Resource, logger *utils.Logger, filePath string) midd
 
 	tarGz := archiver.NewTarGz()
 	tarGz.OverwriteExisting = true
-	if tarGz.Unarchive(filePath, tmpDir) != nil {
+	if err := tarGz.Unarchive(filePath, tmpDir); err != nil {
 		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not unarchive Helm chart")})
+		return errors.New("Could not unarchive Helm chart")
 	}
 	files, err := ioutil.ReadDir(tmpDir)
 	if err != nil {
 		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not read unpacked files")})
+		return errors.New("Could not read unpacked files")
 	}
 
 	if len(files) != 1 {
-		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Unexpected amount of unpacked files")})
+		logger.Error("Helm chart " + resourceURI + " contains " + strconv.Itoa(len(files)) + " top-level entries")
+		return errors.New("Unexpected amount of unpacked files")
 	}
-	uri := *res.ResourceURI
-	folderName := filepath.Join(tmpDir, uri[strings.LastIndex(uri, "/")+1:len(uri)-4])
+	folderName := filepath.Join(tmpDir, resourceURI[strings.LastIndex(resourceURI, "/")+1:len(resourceURI)-4])
 	oldPath := filepath.Join(tmpDir, files[0].Name())
 	if oldPath != folderName {
 		if err := os.Rename(oldPath, folderName); err != nil {
 			logger.Error(err.Error())
-			return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-				WithPayload(&models.Error{Code: 400, Message: swag.String("Could not rename unpacked folder")})
+			return errors.New("Could not rename unpacked folder")
 		}
 	}
 
 	dir, err := filepath.Abs(filepath.Dir(filePath))
 	if err != nil {
 		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Path of Helm chart is invalid")})
+		return errors.New("Path of Helm chart is invalid")
 	}
 	if err := copy.Copy(tmpDir, dir); err != nil {
 		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not copy folder")})
+		return errors.New("Could not copy folder")
 	}
 
 	// remove Helm chart .tgz file
-	logger.Debug("Remove the Helm chart: " + *res.ResourceURI)
-	if os.Remove(filePath) != nil {
+	logger.Debug("Remove the Helm chart: " + resourceURI)
+	if err := os.Remove(filePath); err != nil {
 		logger.Error(err.Error())
-		return service_resource.NewPostProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
-			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not delete Helm chart package")})
+		return errors.New("Could not delete Helm chart package")
 	}
 	return nil
 }
@@ -222,8 +378,10 @@ func untarHelm(res *models.Resource, logger *utils.Logger, filePath string) midd
 // PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc updates a list of resources
 func PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	params service_resource.PutProjectProjectNameStageStageNameServiceServiceNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
@@ -243,6 +401,9 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	logger.Debug("Checking out branch: " + params.StageName)
 	err := common.CheckoutBranch(params.ProjectName, params.StageName)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
@@ -262,6 +423,9 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Updated resources")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
@@ -282,8 +446,10 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(
 // PutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc updates a specified resource
 func PutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(
 	params service_resource.PutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ServiceExists(params.ProjectName, params.StageName, params.ServiceName) {
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest().
@@ -295,6 +461,9 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHan
 	logger.Debug("Checking out branch: " + params.StageName)
 	err := common.CheckoutBranch(params.ProjectName, params.StageName)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
@@ -306,6 +475,9 @@ func PutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHan
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Updated resource: "+params.ResourceURI)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return service_resource.NewPutProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest().
 			WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
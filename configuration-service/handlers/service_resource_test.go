@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/service_resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHelmChartFromBranch(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml":          "servicename: carts\n",
+		"carts/helm/carts/Chart.yaml":  "name: carts\n",
+		"carts/helm/carts/values.yaml": "replicas: 1\n",
+	})
+
+	responder := GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "carts",
+		ResourceURI: "helm/carts.tgz",
+	})
+	response, ok := responder.(*service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIOK)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	archive, err := base64.StdEncoding.DecodeString(response.Payload.ResourceContent)
+	assert.Nil(t, err)
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	assert.Nil(t, err)
+	files := map[string]string{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		if header.Typeflag == tar.TypeReg {
+			content := &bytes.Buffer{}
+			io.Copy(content, tarReader)
+			files[header.Name] = content.String()
+		}
+	}
+	assert.Equal(t, map[string]string{"carts/Chart.yaml": "name: carts\n", "carts/values.yaml": "replicas: 1\n"}, files)
+
+	responder = GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "carts",
+		ResourceURI: "helm/orders.tgz",
+	})
+	_, ok = responder.(*service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINotFound)
+	assert.True(t, ok)
+}
+
+func TestGetServiceResources(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml":    "servicename: carts\n",
+		"carts/slo.yaml":         "objectives: []\n",
+		"carts-db/metadata.yaml": "servicename: carts-db\n",
+	})
+
+	pageSize := int64(10)
+	responder := GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "carts",
+		PageSize:    &pageSize,
+	})
+	response, ok := responder.(*service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceOK)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, float64(2), response.Payload.TotalCount)
+	assert.Equal(t, "metadata.yaml", *response.Payload.Resources[0].ResourceURI)
+	assert.Equal(t, "slo.yaml", *response.Payload.Resources[1].ResourceURI)
+
+	responder = GetProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "orders",
+	})
+	_, ok = responder.(*service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceNotFound)
+	assert.True(t, ok)
+}
+
+func TestDeleteServiceResource(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml":          "servicename: carts\n",
+		"carts/slo.yaml":               "objectives: []\n",
+		"carts/helm/carts/Chart.yaml":  "name: carts\n",
+		"carts/helm/carts/values.yaml": "replicas: 1\n",
+	})
+
+	for _, resourceURI := range []string{"slo.yaml", "helm/carts.tgz"} {
+		responder := DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams{
+			ProjectName: "sockshop",
+			StageName:   "dev",
+			ServiceName: "carts",
+			ResourceURI: resourceURI,
+		})
+		_, ok := responder.(*service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURINoContent)
+		assert.True(t, ok, resourceURI)
+	}
+
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	assert.Equal(t, "carts/metadata.yaml\n", runTestGit(t, projectConfigPath, "ls-tree", "-r", "--name-only", "dev", "carts"))
+
+	responder := DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "carts",
+		ResourceURI: "slo.yaml",
+	})
+	_, ok := responder.(*service_resource.DeleteProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIBadRequest)
+	assert.True(t, ok)
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/models"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServicesOfStage(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml":       "servicename: carts\n",
+		"carts-db/metadata.yaml":    "servicename: carts-db\n",
+		"helm/carts/values.yaml":    "replicas: 1\n",
+		"orders/helm/metadata.yaml": "name: orders\n",
+	})
+
+	pageSize := int64(10)
+	responder := GetProjectProjectNameStageStageNameServiceHandlerFunc(service.GetProjectProjectNameStageStageNameServiceParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		PageSize:    &pageSize,
+	})
+	response, ok := responder.(*service.GetProjectProjectNameStageStageNameServiceOK)
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), response.Payload.TotalCount)
+	assert.Equal(t, "carts", response.Payload.Services[0].ServiceName)
+	assert.Equal(t, "carts-db", response.Payload.Services[1].ServiceName)
+}
+
+func TestDeleteService(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev", "staging")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml":         "servicename: carts\n",
+		"carts/helm/carts/Chart.yaml": "name: carts\n",
+		"carts-db/metadata.yaml":      "servicename: carts-db\n",
+	})
+	commitTestFiles(t, "sockshop", "staging", map[string]string{
+		"carts/metadata.yaml": "servicename: carts\n",
+	})
+
+	responder := DeleteProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(service.DeleteProjectProjectNameStageStageNameServiceServiceNameParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "carts",
+	})
+	_, ok := responder.(*service.DeleteProjectProjectNameStageStageNameServiceServiceNameNoContent)
+	assert.True(t, ok)
+
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	assert.Equal(t, "carts-db/metadata.yaml\n", runTestGit(t, projectConfigPath, "ls-tree", "-r", "--name-only", "dev", "carts", "carts-db"))
+	assert.Equal(t, "Deleted service: carts\n", runTestGit(t, projectConfigPath, "log", "-1", "--format=%s", "dev"))
+	assert.Equal(t, "carts/metadata.yaml\n", runTestGit(t, projectConfigPath, "ls-tree", "-r", "--name-only", "staging", "carts"))
+
+	responder = DeleteProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(service.DeleteProjectProjectNameStageStageNameServiceServiceNameParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ServiceName: "carts",
+	})
+	_, ok = responder.(*service.DeleteProjectProjectNameStageStageNameServiceServiceNameBadRequest)
+	assert.True(t, ok)
+}
+
+func promoteService(project string, stage string, serviceName string, sourceStage string) middleware.Responder {
+	return PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandlerFunc(service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteParams{
+		ProjectName: project,
+		StageName:   stage,
+		ServiceName: serviceName,
+		Promotion:   &models.Promotion{SourceStage: sourceStage},
+	})
+}
+
+func TestPromoteService(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{
+		"carts/metadata.yaml":  "servicename: carts\n",
+		"carts/values.yaml":    "replicas: 1\nport: 8080\nimage: carts:0.1\n",
+		"carts/dashboard.json": "{}\n",
+	}, "dev", "staging")
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/values.yaml":    "replicas: 1\nport: 8080\nimage: carts:0.2\n",
+		"carts/slo.yaml":       "objectives: []\n",
+		"orders/values.yaml":   "replicas: 1\n",
+		"orders/metadata.yaml": "servicename: orders\n",
+	})
+	runTestGit(t, projectConfigPath, "checkout", "dev")
+	runTestGit(t, projectConfigPath, "rm", "-q", "carts/dashboard.json")
+	runTestGit(t, projectConfigPath, "commit", "-m", "Deleted resource")
+	runTestGit(t, projectConfigPath, "checkout", "master")
+	commitTestFiles(t, "sockshop", "staging", map[string]string{
+		"carts/values.yaml": "replicas: 3\nport: 8080\nimage: carts:0.1\n",
+	})
+
+	response, ok := promoteService("sockshop", "staging", "carts", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.NotEmpty(t, response.Payload.Version)
+	assert.Equal(t, "replicas: 3\nport: 8080\nimage: carts:0.2\n", runTestGit(t, projectConfigPath, "show", "staging:carts/values.yaml"))
+	assert.Equal(t, "carts/metadata.yaml\ncarts/slo.yaml\ncarts/values.yaml\n", runTestGit(t, projectConfigPath, "ls-tree", "-r", "--name-only", "staging", "carts", "orders"))
+	devVersion := strings.TrimSpace(runTestGit(t, projectConfigPath, "rev-parse", "dev"))
+	assert.Equal(t, "Promoted service carts from stage dev\n\nPromoted-Directory: carts\nSource-Stage: dev\nSource-Version: "+devVersion+"\n",
+		strings.TrimSpace(runTestGit(t, projectConfigPath, "log", "-1", "--format=%B", "staging"))+"\n")
+	// the promotion is no merge, as the changes of orders have not been promoted
+	assert.Equal(t, "", runTestGit(t, projectConfigPath, "log", "--merges", "--format=%s", "staging"))
+
+	// only the changes since the last promotion are merged
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/values.yaml": "replicas: 1\nport: 8080\nimage: carts:0.3\n",
+	})
+	_, ok = promoteService("sockshop", "staging", "carts", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated)
+	assert.True(t, ok)
+	assert.Equal(t, "replicas: 3\nport: 8080\nimage: carts:0.3\n", runTestGit(t, projectConfigPath, "show", "staging:carts/values.yaml"))
+}
+
+// TestPromoteServicesOneAfterAnother checks whether promoting a service does not mark the changes of other services
+// in the source stage as promoted
+func TestPromoteServicesOneAfterAnother(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{
+		"carts/metadata.yaml":  "servicename: carts\n",
+		"carts/values.yaml":    "image: carts:0.1\n",
+		"orders/metadata.yaml": "servicename: orders\n",
+		"orders/values.yaml":   "image: orders:0.1\n",
+	}, "dev", "staging")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/values.yaml":  "image: carts:0.2\n",
+		"orders/values.yaml": "image: orders:0.2\n",
+	})
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+
+	_, ok := promoteService("sockshop", "staging", "carts", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated)
+	assert.True(t, ok)
+	assert.Equal(t, "image: orders:0.1\n", runTestGit(t, projectConfigPath, "show", "staging:orders/values.yaml"))
+
+	_, ok = promoteService("sockshop", "staging", "orders", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated)
+	assert.True(t, ok)
+	assert.Equal(t, "image: carts:0.2\n", runTestGit(t, projectConfigPath, "show", "staging:carts/values.yaml"))
+	assert.Equal(t, "image: orders:0.2\n", runTestGit(t, projectConfigPath, "show", "staging:orders/values.yaml"))
+	assert.Equal(t, "Promoted service orders from stage dev\nPromoted service carts from stage dev\n",
+		runTestGit(t, projectConfigPath, "log", "-2", "--format=%s", "staging"))
+}
+
+// TestPromoteServiceWithoutChanges checks whether promoting a service that has not been changed since its last
+// promotion succeeds without a commit
+func TestPromoteServiceWithoutChanges(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{
+		"carts/metadata.yaml": "servicename: carts\n",
+		"carts/values.yaml":   "image: carts:0.1\n",
+	}, "dev", "staging")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/values.yaml": "image: carts:0.2\n",
+	})
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+
+	_, ok := promoteService("sockshop", "staging", "carts", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated)
+	assert.True(t, ok)
+	stagingVersion := strings.TrimSpace(runTestGit(t, projectConfigPath, "rev-parse", "staging"))
+
+	response, ok := promoteService("sockshop", "staging", "carts", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, stagingVersion, response.Payload.Version)
+	assert.Equal(t, stagingVersion+"\n", runTestGit(t, projectConfigPath, "rev-parse", "staging"))
+	assert.Equal(t, "", runTestGit(t, projectConfigPath, "status", "--porcelain"))
+}
+
+func TestPromoteServiceWithConflicts(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{
+		"carts/metadata.yaml": "servicename: carts\n",
+		"carts/values.yaml":   "replicas: 1\nimage: carts:0.1\n",
+		"carts/slo.yaml":      "objectives: []\n",
+	}, "dev", "staging")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/values.yaml": "replicas: 1\nimage: carts:0.2\n",
+		"carts/slo.yaml":    "objectives: [response_time]\n",
+	})
+	commitTestFiles(t, "sockshop", "staging", map[string]string{
+		"carts/values.yaml": "replicas: 1\nimage: carts:0.1-hotfix\n",
+	})
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	stagingVersion := runTestGit(t, projectConfigPath, "rev-parse", "staging")
+
+	response, ok := promoteService("sockshop", "staging", "carts", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteConflict)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, []string{"carts/values.yaml"}, response.Payload.Conflicts)
+	// nothing has been promoted, not even the changes without conflicts
+	assert.Equal(t, stagingVersion, runTestGit(t, projectConfigPath, "rev-parse", "staging"))
+	assert.Equal(t, "", runTestGit(t, projectConfigPath, "status", "--porcelain"))
+
+	_, ok = promoteService("sockshop", "staging", "orders", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteBadRequest)
+	assert.True(t, ok)
+	_, ok = promoteService("sockshop", "staging", "carts", "staging").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteBadRequest)
+	assert.True(t, ok)
+}
@@ -7,16 +7,18 @@ import (
 	keptnmodels "github.com/keptn/go-utils/pkg/models"
 	"github.com/keptn/go-utils/pkg/utils"
 	"github.com/keptn/keptn/configuration-service/common"
-	"github.com/keptn/keptn/configuration-service/config"
 	"github.com/keptn/keptn/configuration-service/models"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/stage"
-	"io/ioutil"
 )
 
 // PostProjectProjectNameStageHandlerFunc creates a new stage
 func PostProjectProjectNameStageHandlerFunc(params stage.PostProjectProjectNameStageParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
+	common.LockBranch(params.ProjectName, params.Stage.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.Stage.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 		return stage.NewPostProjectProjectNameStageBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist.")})
@@ -36,34 +38,45 @@ func PutProjectProjectNameStageStageNameHandlerFunc(params stage.PutProjectProje
 
 // DeleteProjectProjectNameStageStageNameHandlerFunc deletes a stage
 func DeleteProjectProjectNameStageStageNameHandlerFunc(params stage.DeleteProjectProjectNameStageStageNameParams) middleware.Responder {
-	return middleware.NotImplemented("operation stage.DeleteProjectProjectNameStageStageName has not yet been implemented")
+	if params.StageName == "master" {
+		return stage.NewDeleteProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("The master stage cannot be deleted.")})
+	}
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, "master")
+	defer common.UnlockBranch(params.ProjectName, "master")
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.ProjectExists(params.ProjectName) {
+		return stage.NewDeleteProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Project does not exist.")})
+	}
+	if !common.StageExists(params.ProjectName, params.StageName) {
+		return stage.NewDeleteProjectProjectNameStageStageNameBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist.")})
+	}
+	err := common.DeleteBranch(params.ProjectName, params.StageName)
+	if err != nil {
+		logger.Error(err.Error())
+		return stage.NewDeleteProjectProjectNameStageStageNameDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not delete stage.")})
+	}
+	logger.Debug("Stage " + params.StageName + " has been deleted")
+	return stage.NewDeleteProjectProjectNameStageStageNameNoContent()
 }
 
 // GetProjectProjectNameStageHandlerFunc gets list of stages for a project
 func GetProjectProjectNameStageHandlerFunc(params stage.GetProjectProjectNameStageParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.RLockBranch(params.ProjectName, "master")
+	defer common.RUnlockBranch(params.ProjectName, "master")
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.ProjectExists(params.ProjectName) {
 		return stage.NewGetProjectProjectNameStageNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project does not exist.")})
 	}
 
-	err := common.CheckoutBranch(params.ProjectName, "master")
-	if err != nil {
-		logger.Error(err.Error())
+	dat, err := common.GetFileFromBranch(params.ProjectName, "master", "shipyard.yaml")
+	if err == common.ErrFileNotFound {
 
 		return stage.NewGetProjectProjectNameStageDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve stages.")})
-	}
-
-	shipyardPath := config.ConfigDir + "/" + params.ProjectName + "/shipyard.yaml"
-
-	if !common.FileExists(shipyardPath) {
-
-		return stage.NewGetProjectProjectNameStageDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve stages.")})
-	}
-
-	dat, err := ioutil.ReadFile(shipyardPath)
-	if err != nil {
+	} else if err != nil {
 		logger.Error(err.Error())
 
 		return stage.NewGetProjectProjectNameStageDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read shipyard file.")})
@@ -96,8 +109,8 @@ func GetProjectProjectNameStageHandlerFunc(params stage.GetProjectProjectNameSta
 
 // GetProjectProjectNameStageStageNameHandlerFunc gets the specified stage
 func GetProjectProjectNameStageStageNameHandlerFunc(params stage.GetProjectProjectNameStageStageNameParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
 	if !common.ProjectExists(params.ProjectName) {
 		return stage.NewGetProjectProjectNameStageStageNameNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
 	}
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"encoding/base64"
-	"io/ioutil"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
@@ -15,47 +14,39 @@ import (
 
 // GetProjectProjectNameStageStageNameResourceHandlerFunc get list of stage resources
 func GetProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resource.GetProjectProjectNameStageStageNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.StageExists(params.ProjectName, params.StageName) {
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage does not exist")})
 	}
-	logger.Debug("Checking out " + params.StageName + " branch")
-	err := common.CheckoutBranch(params.ProjectName, params.StageName)
+	resources, err := common.ListResourcesInBranch(params.ProjectName, params.StageName, "")
 	if err != nil {
 		logger.Error(err.Error())
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve stage resources")})
 	}
-
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	result := common.GetPaginatedResources(projectConfigPath, params.PageSize, params.NextPageKey)
+	result, errPayload := listResources(resources, params.ResourceURIPrefix, params.ResourceURIGlob, params.PageSize, params.NextPageKey)
+	if errPayload != nil {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceDefault(400).WithPayload(errPayload)
+	}
 	return stage_resource.NewGetProjectProjectNameStageStageNameResourceOK().WithPayload(result)
 }
 
 // GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc get the specified resource
 func GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
-	projectConfigPath := config.ConfigDir + "/" + params.ProjectName
-	resourcePath := projectConfigPath + "/" + params.ResourceURI
 	if !common.StageExists(params.ProjectName, params.StageName) {
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Project not found")})
 	}
-	logger.Debug("Checking out " + params.StageName + " branch")
-	err := common.CheckoutBranch(params.ProjectName, params.StageName)
-	if err != nil {
-		logger.Error(err.Error())
-		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch containing stage config")})
-	}
 
-	if !common.FileExists(resourcePath) {
+	dat, err := common.GetFileAtVersion(params.ProjectName, params.StageName, swag.StringValue(params.Version), params.ResourceURI)
+	if err == common.ErrVersionNotFound {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Version not found")})
+	} else if err == common.ErrFileNotFound {
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURINotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage resource not found")})
-	}
-
-	dat, err := ioutil.ReadFile(resourcePath)
-	if err != nil {
+	} else if err != nil {
 		logger.Error(err.Error())
 		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not read file")})
 	}
@@ -68,10 +59,59 @@ func GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params st
 		})
 }
 
+// GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandlerFunc gets the versions of the specified resource
+func GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandlerFunc(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryParams) middleware.Responder {
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.StageExists(params.ProjectName, params.StageName) {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIHistoryNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage not found")})
+	}
+
+	versions, err := common.GetFileHistory(params.ProjectName, params.StageName, params.ResourceURI)
+	if err == common.ErrFileNotFound {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIHistoryNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage resource not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIHistoryDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not retrieve the versions of the resource")})
+	}
+
+	result, err := common.PaginateVersions(versions, params.PageSize, params.NextPageKey)
+	if err != nil {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIHistoryDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String("Invalid next page key")})
+	}
+	return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIHistoryOK().WithPayload(result)
+}
+
+// GetProjectProjectNameStageStageNameResourceResourceURIDiffHandlerFunc gets the changes of the specified resource between two versions
+func GetProjectProjectNameStageStageNameResourceResourceURIDiffHandlerFunc(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffParams) middleware.Responder {
+	common.RLockBranch(params.ProjectName, params.StageName)
+	defer common.RUnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.StageExists(params.ProjectName, params.StageName) {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage not found")})
+	}
+
+	diff, err := common.GetFileDiff(params.ProjectName, params.StageName, params.FromVersion, swag.StringValue(params.ToVersion), params.ResourceURI)
+	if err == common.ErrVersionNotFound {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Version not found")})
+	} else if err == common.ErrFileNotFound {
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDiffNotFound().WithPayload(&models.Error{Code: 404, Message: swag.String("Stage resource not found")})
+	} else if err != nil {
+		logger.Error(err.Error())
+		return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDiffDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not compare the versions of the resource")})
+	}
+
+	diff.ResourceURI = params.ResourceURI
+	return stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDiffOK().WithPayload(diff)
+}
+
 // PostProjectProjectNameStageStageNameResourceHandlerFunc creates list of new resources in a stage
 func PostProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resource.PostProjectProjectNameStageStageNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.StageExists(params.ProjectName, params.StageName) {
 		return stage_resource.NewPostProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist")})
@@ -83,6 +123,9 @@ func PostProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resour
 	logger.Debug("Checking out branch: " + params.StageName)
 	err := common.CheckoutBranch(params.ProjectName, params.StageName)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return stage_resource.NewPostProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch containing stage config")})
 	}
@@ -96,6 +139,9 @@ func PostProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resour
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Added resources")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return stage_resource.NewPostProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
 	}
@@ -113,8 +159,10 @@ func PostProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resour
 
 // PutProjectProjectNameStageStageNameResourceHandlerFunc updates list of stage resources
 func PutProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resource.PutProjectProjectNameStageStageNameResourceParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.StageExists(params.ProjectName, params.StageName) {
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist")})
@@ -125,6 +173,9 @@ func PutProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resourc
 	logger.Debug("Checking out branch: " + params.StageName)
 	err := common.CheckoutBranch(params.ProjectName, params.StageName)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
 	}
@@ -137,6 +188,9 @@ func PutProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resourc
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Updated resources")
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
 	}
@@ -154,8 +208,10 @@ func PutProjectProjectNameStageStageNameResourceHandlerFunc(params stage_resourc
 
 // PutProjectProjectNameStageStageNameResourceResourceURIHandlerFunc updates the specified stage resource
 func PutProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params stage_resource.PutProjectProjectNameStageStageNameResourceResourceURIParams) middleware.Responder {
-	common.Lock()
-	defer common.UnLock()
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
 	logger := utils.NewLogger("", "", "configuration-service")
 	if !common.StageExists(params.ProjectName, params.StageName) {
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist")})
@@ -166,6 +222,9 @@ func PutProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params st
 	logger.Debug("Checking out branch: " + params.StageName)
 	err := common.CheckoutBranch(params.ProjectName, params.StageName)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not check out branch")})
 	}
@@ -176,6 +235,9 @@ func PutProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params st
 	logger.Debug("Staging Changes")
 	err = common.StageAndCommitAll(params.ProjectName, "Updated resource: "+params.ResourceURI)
 	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
 		logger.Error(err.Error())
 		return stage_resource.NewPutProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Could not commit changes")})
 	}
@@ -193,5 +255,44 @@ func PutProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params st
 
 // DeleteProjectProjectNameStageStageNameResourceResourceURIHandlerFunc deletes the specified stage resource
 func DeleteProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(params stage_resource.DeleteProjectProjectNameStageStageNameResourceResourceURIParams) middleware.Responder {
-	return middleware.NotImplemented("operation stage_resource.DeleteProjectProjectNameStageStageNameResourceResourceURI has not yet been implemented")
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+	common.LockBranch(params.ProjectName, params.StageName)
+	defer common.UnlockBranch(params.ProjectName, params.StageName)
+	logger := utils.NewLogger("", "", "configuration-service")
+	if !common.StageExists(params.ProjectName, params.StageName) {
+		return stage_resource.NewDeleteProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage does not exist")})
+	}
+	resourcePath := config.ConfigDir + "/" + params.ProjectName + "/" + params.ResourceURI
+
+	logger.Debug("Checking out branch: " + params.StageName)
+	err := common.CheckoutBranch(params.ProjectName, params.StageName)
+	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
+		logger.Error(err.Error())
+		return stage_resource.NewDeleteProjectProjectNameStageStageNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not check out branch")})
+	}
+	if !common.FileExists(resourcePath) {
+		return stage_resource.NewDeleteProjectProjectNameStageStageNameResourceResourceURIBadRequest().WithPayload(&models.Error{Code: 400, Message: swag.String("Stage resource does not exist")})
+	}
+	err = common.DeleteFile(resourcePath)
+	if err != nil {
+		logger.Error(err.Error())
+		return stage_resource.NewDeleteProjectProjectNameStageStageNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not delete file")})
+	}
+
+	logger.Debug("Staging Changes")
+	err = common.StageAndCommitAll(params.ProjectName, "Deleted resource: "+params.ResourceURI)
+	if err != nil {
+		if conflict, ok := err.(*common.MergeConflictError); ok {
+			return newMergeConflictResponder(conflict)
+		}
+		logger.Error(err.Error())
+		return stage_resource.NewDeleteProjectProjectNameStageStageNameResourceResourceURIDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String("Could not commit changes")})
+	}
+	logger.Debug("Successfully deleted resource: " + params.ResourceURI)
+
+	return stage_resource.NewDeleteProjectProjectNameStageStageNameResourceResourceURINoContent()
 }
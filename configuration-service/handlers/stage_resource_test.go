@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/keptn/keptn/configuration-service/common"
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/stage_resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func getStageResource(project string, stage string, resourceURI string) (string, bool) {
+	responder := GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIParams{
+		ProjectName: project,
+		StageName:   stage,
+		ResourceURI: resourceURI,
+	})
+	response, ok := responder.(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIOK)
+	if !ok {
+		return "", false
+	}
+	content, _ := base64.StdEncoding.DecodeString(response.Payload.ResourceContent)
+	return string(content), true
+}
+
+func TestGetStageResourceDoesNotCheckOutBranch(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{"shipyard.yaml": "stages: []\n"}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{"carts/slo.yaml": "objectives: []\n"})
+
+	content, ok := getStageResource("sockshop", "dev", "carts/slo.yaml")
+	assert.True(t, ok)
+	assert.Equal(t, "objectives: []\n", content)
+
+	_, ok = getStageResource("sockshop", "dev", "carts/sli.yaml")
+	assert.False(t, ok)
+	_, ok = getStageResource("sockshop", "production", "carts/slo.yaml")
+	assert.False(t, ok)
+
+	// the working copy stays on the master branch
+	head := runTestGit(t, filepath.Join(config.ConfigDir, "sockshop"), "rev-parse", "--abbrev-ref", "HEAD")
+	assert.Equal(t, "master", strings.TrimSpace(head))
+}
+
+func TestGetStageResourceIsNotBlockedByOtherProjects(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev")
+	createTestProject(t, "simpleproject", map[string]string{}, "dev")
+	commitTestFiles(t, "simpleproject", "dev", map[string]string{"simplenode/slo.yaml": "objectives: []\n"})
+
+	// a long running operation, e.g. a pull of the upstream, holds the lock of another project
+	common.LockProject("sockshop")
+	defer common.UnlockProject("sockshop")
+	// other reads of the same branch do not block reads either
+	common.RLockBranch("simpleproject", "dev")
+	defer common.RUnlockBranch("simpleproject", "dev")
+
+	done := make(chan bool)
+	go func() {
+		_, ok := getStageResource("simpleproject", "dev", "simplenode/slo.yaml")
+		done <- ok
+	}()
+	select {
+	case ok := <-done:
+		assert.True(t, ok)
+	case <-time.After(10 * time.Second):
+		t.Fatal("reading a resource of project simpleproject was blocked")
+	}
+}
+
+func TestGetStageResourceIsNotBlockedByWritesToOtherStages(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev", "staging")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{"carts/slo.yaml": "objectives: []\n"})
+
+	// a write to the staging branch holds the working copy of the project
+	common.LockProject("sockshop")
+	defer common.UnlockProject("sockshop")
+	common.LockBranch("sockshop", "staging")
+	defer common.UnlockBranch("sockshop", "staging")
+
+	done := make(chan bool)
+	go func() {
+		_, ok := getStageResource("sockshop", "dev", "carts/slo.yaml")
+		done <- ok
+	}()
+	select {
+	case ok := <-done:
+		assert.True(t, ok)
+	case <-time.After(10 * time.Second):
+		t.Fatal("reading a resource of stage dev was blocked by a write to stage staging")
+	}
+}
+
+func TestGetStageResources(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{"shipyard.yaml": "stages: []\n"}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml": "servicename: carts\n",
+		"carts/slo.yaml":      "objectives: []\n",
+	})
+
+	pageSize := int64(2)
+	responder := GetProjectProjectNameStageStageNameResourceHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		PageSize:    &pageSize,
+	})
+	response, ok := responder.(*stage_resource.GetProjectProjectNameStageStageNameResourceOK)
+	assert.True(t, ok)
+	assert.Equal(t, float64(4), response.Payload.TotalCount)
+	assert.Len(t, response.Payload.Resources, 2)
+	assert.Equal(t, "carts/metadata.yaml", *response.Payload.Resources[0].ResourceURI)
+	slo := response.Payload.Resources[1]
+	assert.Equal(t, "carts/slo.yaml", *slo.ResourceURI)
+	assert.Empty(t, slo.ResourceContent)
+	assert.Equal(t, int64(len("objectives: []\n")), slo.Metadata.Size)
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	assert.Equal(t, strings.TrimSpace(runTestGit(t, projectConfigPath, "rev-parse", "dev:carts/slo.yaml")), slo.Metadata.ContentHash)
+	assert.Equal(t, strings.TrimSpace(runTestGit(t, projectConfigPath, "rev-parse", "dev")), slo.Metadata.Version)
+
+	// the next page starts after the last resource of the previous page, even if a resource has been added before it
+	commitTestFiles(t, "sockshop", "dev", map[string]string{"carts/deployment.yaml": "kind: Deployment\n"})
+	responder = GetProjectProjectNameStageStageNameResourceHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		PageSize:    &pageSize,
+		NextPageKey: &response.Payload.NextPageKey,
+	})
+	response, ok = responder.(*stage_resource.GetProjectProjectNameStageStageNameResourceOK)
+	assert.True(t, ok)
+	assert.Equal(t, float64(5), response.Payload.TotalCount)
+	assert.Equal(t, "0", response.Payload.NextPageKey)
+	assert.Len(t, response.Payload.Resources, 2)
+	assert.Equal(t, "metadata.yaml", *response.Payload.Resources[0].ResourceURI)
+	assert.Equal(t, "shipyard.yaml", *response.Payload.Resources[1].ResourceURI)
+
+	invalidPageKey := "2"
+	_, ok = GetProjectProjectNameStageStageNameResourceHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		PageSize:    &pageSize,
+		NextPageKey: &invalidPageKey,
+	}).(*stage_resource.GetProjectProjectNameStageStageNameResourceDefault)
+	assert.True(t, ok)
+}
+
+func TestGetStageResourcesFiltered(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{"shipyard.yaml": "stages: []\n"}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"carts/metadata.yaml":          "servicename: carts\n",
+		"carts/slo.yaml":               "objectives: []\n",
+		"carts/helm/carts/values.yaml": "replicas: 1\n",
+		"orders/slo.yaml":              "objectives: []\n",
+	})
+
+	getResources := func(prefix *string, glob *string) middleware.Responder {
+		return GetProjectProjectNameStageStageNameResourceHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceParams{
+			ProjectName:       "sockshop",
+			StageName:         "dev",
+			ResourceURIPrefix: prefix,
+			ResourceURIGlob:   glob,
+		})
+	}
+	response, ok := getResources(swag.String("carts/"), swag.String("*/*.yaml")).(*stage_resource.GetProjectProjectNameStageStageNameResourceOK)
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), response.Payload.TotalCount)
+	assert.Equal(t, "carts/metadata.yaml", *response.Payload.Resources[0].ResourceURI)
+	assert.Equal(t, "carts/slo.yaml", *response.Payload.Resources[1].ResourceURI)
+
+	response, ok = getResources(nil, swag.String("*/slo.yaml")).(*stage_resource.GetProjectProjectNameStageStageNameResourceOK)
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), response.Payload.TotalCount)
+
+	_, ok = getResources(nil, swag.String("[")).(*stage_resource.GetProjectProjectNameStageStageNameResourceDefault)
+	assert.True(t, ok)
+}
+
+func TestGetStageResourceVersions(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev", "staging")
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{"carts/slo.yaml": "comparison: single\n"})
+	firstVersion := strings.TrimSpace(runTestGit(t, projectConfigPath, "rev-parse", "dev"))
+	commitTestFiles(t, "sockshop", "dev", map[string]string{"carts/slo.yaml": "comparison: several\n"})
+	latestVersion := strings.TrimSpace(runTestGit(t, projectConfigPath, "rev-parse", "dev"))
+	commitTestFiles(t, "sockshop", "staging", map[string]string{"carts/slo.yaml": "comparison: other\n"})
+	stagingVersion := strings.TrimSpace(runTestGit(t, projectConfigPath, "rev-parse", "staging"))
+
+	getVersion := func(version string) middleware.Responder {
+		return GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIParams{
+			ProjectName: "sockshop",
+			StageName:   "dev",
+			ResourceURI: "carts/slo.yaml",
+			Version:     &version,
+		})
+	}
+	response, ok := getVersion(firstVersion).(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIOK)
+	assert.True(t, ok)
+	content, _ := base64.StdEncoding.DecodeString(response.Payload.ResourceContent)
+	assert.Equal(t, "comparison: single\n", string(content))
+	response, ok = getVersion(firstVersion[:8]).(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIOK)
+	assert.True(t, ok)
+
+	// versions of other stages and arguments of git are rejected
+	for _, version := range []string{stagingVersion, "0000000", "--all", "dev"} {
+		_, ok = getVersion(version).(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURINotFound)
+		assert.True(t, ok, version)
+	}
+
+	pageSize := int64(1)
+	historyResponder := GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ResourceURI: "carts/slo.yaml",
+		PageSize:    &pageSize,
+	})
+	history, ok := historyResponder.(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryOK)
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), history.Payload.TotalCount)
+	assert.Equal(t, latestVersion, history.Payload.Versions[0].Version)
+	assert.Equal(t, "keptn", history.Payload.Versions[0].Author)
+	assert.Equal(t, "Added resources", history.Payload.Versions[0].Message)
+
+	historyResponder = GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ResourceURI: "carts/slo.yaml",
+		PageSize:    &pageSize,
+		NextPageKey: &history.Payload.NextPageKey,
+	})
+	history, ok = historyResponder.(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryOK)
+	assert.True(t, ok)
+	assert.Equal(t, "0", history.Payload.NextPageKey)
+	assert.Equal(t, firstVersion, history.Payload.Versions[0].Version)
+
+	historyResponder = GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ResourceURI: "carts/sli.yaml",
+		PageSize:    &pageSize,
+	})
+	_, ok = historyResponder.(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryNotFound)
+	assert.True(t, ok)
+
+	diffResponder := GetProjectProjectNameStageStageNameResourceResourceURIDiffHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ResourceURI: "carts/slo.yaml",
+		FromVersion: firstVersion,
+	})
+	diff, ok := diffResponder.(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffOK)
+	assert.True(t, ok)
+	assert.Equal(t, firstVersion, diff.Payload.FromVersion)
+	assert.Equal(t, latestVersion, diff.Payload.ToVersion)
+	assert.Contains(t, diff.Payload.Diff, "-comparison: single\n+comparison: several\n")
+
+	diffResponder = GetProjectProjectNameStageStageNameResourceResourceURIDiffHandlerFunc(stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ResourceURI: "carts/slo.yaml",
+		FromVersion: stagingVersion,
+	})
+	_, ok = diffResponder.(*stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffNotFound)
+	assert.True(t, ok)
+}
+
+// BenchmarkParallelGetStageResource reads resources of several projects in parallel
+func BenchmarkParallelGetStageResource(b *testing.B) {
+	defer setupConfigDir(b)()
+	projects := []string{"project-a", "project-b", "project-c", "project-d"}
+	for _, project := range projects {
+		createTestProject(b, project, map[string]string{}, "dev")
+		commitTestFiles(b, project, "dev", map[string]string{"carts/slo.yaml": "objectives: []\n"})
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			project := projects[i%len(projects)]
+			if _, ok := getStageResource(project, "dev", "carts/slo.yaml"); !ok {
+				b.Error(fmt.Sprintf("could not read resource of project %s", project))
+			}
+			i++
+		}
+	})
+}
+
+func TestDeleteStageResource(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev")
+	commitTestFiles(t, "sockshop", "dev", map[string]string{
+		"shipyard.yaml": "stages: []\n",
+	})
+
+	responder := DeleteProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(stage_resource.DeleteProjectProjectNameStageStageNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ResourceURI: "shipyard.yaml",
+	})
+	_, ok := responder.(*stage_resource.DeleteProjectProjectNameStageStageNameResourceResourceURINoContent)
+	assert.True(t, ok)
+
+	_, found := getStageResource("sockshop", "dev", "shipyard.yaml")
+	assert.False(t, found)
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	assert.Equal(t, "Deleted resource: shipyard.yaml\n", runTestGit(t, projectConfigPath, "log", "-1", "--format=%s", "dev"))
+
+	responder = DeleteProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(stage_resource.DeleteProjectProjectNameStageStageNameResourceResourceURIParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		ResourceURI: "shipyard.yaml",
+	})
+	_, ok = responder.(*stage_resource.DeleteProjectProjectNameStageStageNameResourceResourceURIBadRequest)
+	assert.True(t, ok)
+}
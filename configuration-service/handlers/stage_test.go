@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keptn/keptn/configuration-service/common"
+	"github.com/keptn/keptn/configuration-service/config"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/stage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteStage(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev", "staging")
+
+	responder := DeleteProjectProjectNameStageStageNameHandlerFunc(stage.DeleteProjectProjectNameStageStageNameParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+	})
+	_, ok := responder.(*stage.DeleteProjectProjectNameStageStageNameNoContent)
+	assert.True(t, ok)
+
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+	assert.Equal(t, "master\nstaging\n", runTestGit(t, projectConfigPath, "for-each-ref", "--format=%(refname:short)", "refs/heads/*"))
+
+	for stageName, message := range map[string]string{"dev": "Stage does not exist.", "master": "The master stage cannot be deleted."} {
+		responder = DeleteProjectProjectNameStageStageNameHandlerFunc(stage.DeleteProjectProjectNameStageStageNameParams{
+			ProjectName: "sockshop",
+			StageName:   stageName,
+		})
+		response, ok := responder.(*stage.DeleteProjectProjectNameStageStageNameBadRequest)
+		if assert.True(t, ok, stageName) {
+			assert.Equal(t, message, *response.Payload.Message)
+		}
+	}
+}
+
+// TestDeleteStageWithUpstream checks that a stage is deleted from the upstream and its remote-tracking branch, and
+// that it is kept if the upstream cannot be updated
+func TestDeleteStageWithUpstream(t *testing.T) {
+	defer setupConfigDir(t)()
+	createTestProject(t, "sockshop", map[string]string{}, "dev", "staging", "production")
+	projectConfigPath := filepath.Join(config.ConfigDir, "sockshop")
+
+	dir, err := ioutil.TempDir("", "upstream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	upstream := filepath.Join(dir, "sockshop.git")
+	runTestGit(t, dir, "init", "-q", "--bare", upstream)
+	runTestGit(t, projectConfigPath, "remote", "add", "origin", upstream)
+	runTestGit(t, projectConfigPath, "push", "-q", "--all", "origin")
+	runTestGit(t, projectConfigPath, "fetch", "-q", "origin")
+
+	common.SetCredentialsStore(testCredentialsStore{})
+	defer common.SetCredentialsStore(nil)
+	err = common.StoreGitCredentials("sockshop", &common.GitCredentials{User: "keptn", Token: "token", RemoteURI: upstream})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responder := DeleteProjectProjectNameStageStageNameHandlerFunc(stage.DeleteProjectProjectNameStageStageNameParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+	})
+	_, ok := responder.(*stage.DeleteProjectProjectNameStageStageNameNoContent)
+	assert.True(t, ok)
+	assert.False(t, common.StageExists("sockshop", "dev"))
+	assert.Equal(t, "master\nproduction\nstaging\n", runTestGit(t, upstream, "for-each-ref", "--format=%(refname:short)", "refs/heads/*"))
+	assert.Equal(t, "origin/master\norigin/production\norigin/staging\n", runTestGit(t, projectConfigPath, "for-each-ref", "--format=%(refname:short)", "refs/remotes/origin/*"))
+
+	// a stage that has never been pushed is deleted as well
+	runTestGit(t, projectConfigPath, "branch", "hardening")
+	responder = DeleteProjectProjectNameStageStageNameHandlerFunc(stage.DeleteProjectProjectNameStageStageNameParams{
+		ProjectName: "sockshop",
+		StageName:   "hardening",
+	})
+	_, ok = responder.(*stage.DeleteProjectProjectNameStageStageNameNoContent)
+	assert.True(t, ok)
+	assert.False(t, common.StageExists("sockshop", "hardening"))
+
+	// the stage is kept if it cannot be deleted from the upstream
+	err = common.StoreGitCredentials("sockshop", &common.GitCredentials{User: "keptn", Token: "token", RemoteURI: filepath.Join(dir, "unknown.git")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	responder = DeleteProjectProjectNameStageStageNameHandlerFunc(stage.DeleteProjectProjectNameStageStageNameParams{
+		ProjectName: "sockshop",
+		StageName:   "staging",
+	})
+	response, ok := responder.(*stage.DeleteProjectProjectNameStageStageNameDefault)
+	if assert.True(t, ok) {
+		assert.Equal(t, "Could not delete stage.", *response.Payload.Message)
+	}
+	assert.True(t, common.StageExists("sockshop", "staging"))
+	assert.Equal(t, "master\nproduction\nstaging\n", runTestGit(t, projectConfigPath, "for-each-ref", "--format=%(refname:short)", "refs/heads/*"))
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/configuration-service/common"
+	"github.com/keptn/keptn/configuration-service/models"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/project"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/service"
+	"github.com/keptn/keptn/configuration-service/restapi/operations/stage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMemoryStorage checks that projects can be managed without git using the memory storage
+func TestMemoryStorage(t *testing.T) {
+	defer setupConfigDir(t)()
+	common.SetStorage(common.NewMemoryStorage())
+	defer common.SetStorage(&common.GitStorage{})
+
+	_, ok := PostProjectHandlerFunc(project.PostProjectParams{
+		Project: &models.Project{ProjectName: "sockshop"},
+	}).(*project.PostProjectNoContent)
+	assert.True(t, ok)
+	for _, stageName := range []string{"dev", "staging"} {
+		_, ok = PostProjectProjectNameStageHandlerFunc(stage.PostProjectProjectNameStageParams{
+			ProjectName: "sockshop",
+			Stage:       &models.Stage{StageName: stageName},
+		}).(*stage.PostProjectProjectNameStageNoContent)
+		assert.True(t, ok, stageName)
+	}
+	_, ok = PostProjectProjectNameStageStageNameServiceHandlerFunc(service.PostProjectProjectNameStageStageNameServiceParams{
+		ProjectName: "sockshop",
+		StageName:   "dev",
+		Service:     &models.Service{ServiceName: "carts"},
+	}).(*service.PostProjectProjectNameStageStageNameServiceNoContent)
+	assert.True(t, ok)
+	assert.True(t, common.ServiceExists("sockshop", "dev", "carts"))
+	assert.False(t, common.ServiceExists("sockshop", "staging", "carts"))
+
+	_, ok = promoteService("sockshop", "staging", "carts", "dev").(*service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteCreated)
+	assert.True(t, ok)
+	assert.True(t, common.ServiceExists("sockshop", "staging", "carts"))
+
+	_, ok = DeleteProjectProjectNameHandlerFunc(project.DeleteProjectProjectNameParams{
+		ProjectName: "sockshop",
+	}).(*project.DeleteProjectProjectNameNoContent)
+	assert.True(t, ok)
+	assert.False(t, common.ProjectExists("sockshop"))
+}
@@ -15,6 +15,18 @@ import (
 // swagger:model Project
 type Project struct {
 
+	// Trust the SSH host key of the git remote on first use if it is not contained in gitKnownHosts. Only to be used if the network to the git remote is trusted
+	GitAcceptNewHostKey bool `json:"gitAcceptNewHostKey,omitempty"`
+
+	// PEM encoded CA bundle used to verify the git remote
+	GitCACertificate string `json:"gitCACertificate,omitempty"`
+
+	// known_hosts entries of the git remote, which its SSH host key is verified against. Required for a private key, unless gitAcceptNewHostKey is set
+	GitKnownHosts string `json:"gitKnownHosts,omitempty"`
+
+	// SSH private key (deploy key) used instead of the git user and token
+	GitPrivateKey string `json:"gitPrivateKey,omitempty"`
+
 	// Git remote URI
 	GitRemoteURI string `json:"gitRemoteURI,omitempty"`
 
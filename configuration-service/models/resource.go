@@ -17,6 +17,12 @@ import (
 // swagger:model Resource
 type Resource struct {
 
+	// metadata
+	Metadata *ResourceMetadata `json:"metadata,omitempty"`
+
+	// Origin of a service resource, either 'stage' if it is defined in the stage or 'default' if it is inherited from the default resources of the service
+	Origin string `json:"origin,omitempty"`
+
 	// Resource content
 	ResourceContent string `json:"resourceContent,omitempty"`
 
@@ -29,6 +35,10 @@ type Resource struct {
 func (m *Resource) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateMetadata(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateResourceURI(formats); err != nil {
 		res = append(res, err)
 	}
@@ -39,6 +49,24 @@ func (m *Resource) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Resource) validateMetadata(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Metadata) { // not required
+		return nil
+	}
+
+	if m.Metadata != nil {
+		if err := m.Metadata.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("metadata")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Resource) validateResourceURI(formats strfmt.Registry) error {
 
 	if err := validate.Required("resourceURI", "body", m.ResourceURI); err != nil {
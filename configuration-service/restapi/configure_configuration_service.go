@@ -8,10 +8,12 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	errors "github.com/go-openapi/errors"
 	runtime "github.com/go-openapi/runtime"
 	"github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/keptn/configuration-service/common"
 	handlers "github.com/keptn/keptn/configuration-service/handlers"
 	"github.com/keptn/keptn/configuration-service/restapi/operations"
 	"github.com/keptn/keptn/configuration-service/restapi/operations/project"
@@ -61,10 +63,16 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 
 	api.ProjectGetProjectProjectNameHandler = project.GetProjectProjectNameHandlerFunc(handlers.GetProjectProjectNameHandlerFunc)
 
+	api.ProjectGetProjectProjectNameSyncHandler = project.GetProjectProjectNameSyncHandlerFunc(handlers.GetProjectProjectNameSyncHandlerFunc)
+
 	api.ProjectResourceGetProjectProjectNameResourceHandler = project_resource.GetProjectProjectNameResourceHandlerFunc(handlers.GetProjectProjectNameResourceHandlerFunc)
 
 	api.ProjectResourceGetProjectProjectNameResourceResourceURIHandler = project_resource.GetProjectProjectNameResourceResourceURIHandlerFunc(handlers.GetProjectProjectNameResourceResourceURIHandlerFunc)
 
+	api.ProjectResourceGetProjectProjectNameResourceResourceURIDiffHandler = project_resource.GetProjectProjectNameResourceResourceURIDiffHandlerFunc(handlers.GetProjectProjectNameResourceResourceURIDiffHandlerFunc)
+
+	api.ProjectResourceGetProjectProjectNameResourceResourceURIHistoryHandler = project_resource.GetProjectProjectNameResourceResourceURIHistoryHandlerFunc(handlers.GetProjectProjectNameResourceResourceURIHistoryHandlerFunc)
+
 	api.ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler = service_default_resource.GetProjectProjectNameServiceServiceNameResourceHandlerFunc(handlers.GetProjectProjectNameServiceServiceNameResourceHandlerFunc)
 
 	api.ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceResourceURIHandler = service_default_resource.GetProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc(handlers.GetProjectProjectNameServiceServiceNameResourceResourceURIHandlerFunc)
@@ -77,6 +85,10 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 
 	api.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler = stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(handlers.GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc)
 
+	api.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIDiffHandler = stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffHandlerFunc(handlers.GetProjectProjectNameStageStageNameResourceResourceURIDiffHandlerFunc)
+
+	api.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHistoryHandler = stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandlerFunc(handlers.GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandlerFunc)
+
 	api.ServiceGetProjectProjectNameStageStageNameServiceHandler = service.GetProjectProjectNameStageStageNameServiceHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceHandlerFunc)
 
 	api.ServiceGetProjectProjectNameStageStageNameServiceServiceNameHandler = service.GetProjectProjectNameStageStageNameServiceServiceNameHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceServiceNameHandlerFunc)
@@ -85,6 +97,10 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 
 	api.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler = service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc)
 
+	api.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandler = service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandlerFunc)
+
+	api.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandler = service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandlerFunc(handlers.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandlerFunc)
+
 	api.ProjectPostProjectHandler = project.PostProjectHandlerFunc(handlers.PostProjectHandlerFunc)
 
 	api.ProjectResourcePostProjectProjectNameResourceHandler = project_resource.PostProjectProjectNameResourceHandlerFunc(handlers.PostProjectProjectNameResourceHandlerFunc)
@@ -97,6 +113,8 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 
 	api.ServicePostProjectProjectNameStageStageNameServiceHandler = service.PostProjectProjectNameStageStageNameServiceHandlerFunc(handlers.PostProjectProjectNameStageStageNameServiceHandlerFunc)
 
+	api.ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler = service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandlerFunc(handlers.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandlerFunc)
+
 	api.ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler = service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(handlers.PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc)
 
 	api.ProjectPutProjectProjectNameHandler = project.PutProjectProjectNameHandlerFunc(handlers.PutProjectProjectNameHandlerFunc)
@@ -123,9 +141,29 @@ func configureAPI(api *operations.ConfigurationServiceAPI) http.Handler {
 
 	api.ServerShutdown = func() {}
 
+	// the storage is configured by the environment variable STORAGE, i.e., git (default) or memory
+	storage, err := common.NewStorage(os.Getenv("STORAGE"))
+	if err != nil {
+		utils.NewLogger("", "", "configuration-service").Error("Could not configure storage: " + err.Error())
+		os.Exit(1)
+	}
+	common.SetStorage(storage)
+
+	go common.WatchUpstreams(getUpstreamUpdateInterval())
+
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
+// getUpstreamUpdateInterval returns the interval for updating the projects from their upstreams, configured by the
+// environment variable UPSTREAM_UPDATE_INTERVAL (e.g., 30s or 5m)
+func getUpstreamUpdateInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("UPSTREAM_UPDATE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return time.Minute
+	}
+	return interval
+}
+
 // The TLS configuration before HTTPS server starts.
 func configureTLS(tlsConfig *tls.Config) {
 	// Make all necessary changes to the TLS configuration here.
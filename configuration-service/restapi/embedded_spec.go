@@ -188,6 +188,12 @@ func init() {
           },
           {
             "$ref": "#/parameters/nextPageKey"
+          },
+          {
+            "$ref": "#/parameters/resourceURIPrefix"
+          },
+          {
+            "$ref": "#/parameters/resourceURIGlob"
           }
         ],
         "responses": {
@@ -285,6 +291,11 @@ func init() {
           "Project Resource"
         ],
         "summary": "Get the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/version"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success",
@@ -369,6 +380,94 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/resource/{resourceURI}/diff": {
+      "get": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Get the changes of the specified resource between two versions",
+        "parameters": [
+          {
+            "$ref": "#/parameters/fromVersion"
+          },
+          {
+            "$ref": "#/parameters/toVersion"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceDiff"
+            }
+          },
+          "404": {
+            "description": "Failed. Project resource or version could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
+    },
+    "/project/{projectName}/resource/{resourceURI}/history": {
+      "get": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/pageSize"
+          },
+          {
+            "$ref": "#/parameters/nextPageKey"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceHistory"
+            }
+          },
+          "404": {
+            "description": "Failed. Project resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
+    },
     "/project/{projectName}/service/{serviceName}/resource": {
       "get": {
         "tags": [
@@ -381,6 +480,12 @@ func init() {
           },
           {
             "$ref": "#/parameters/nextPageKey"
+          },
+          {
+            "$ref": "#/parameters/resourceURIPrefix"
+          },
+          {
+            "$ref": "#/parameters/resourceURIGlob"
           }
         ],
         "responses": {
@@ -507,6 +612,11 @@ func init() {
           "Service Default Resource"
         ],
         "summary": "Update the specified default resource for the service",
+        "parameters": [
+          {
+            "$ref": "#/parameters/resource"
+          }
+        ],
         "responses": {
           "201": {
             "description": "Success. Service default resource has been updated. The version of the new configuration is returned.",
@@ -731,6 +841,12 @@ func init() {
           },
           {
             "$ref": "#/parameters/nextPageKey"
+          },
+          {
+            "$ref": "#/parameters/resourceURIPrefix"
+          },
+          {
+            "$ref": "#/parameters/resourceURIGlob"
           }
         ],
         "responses": {
@@ -831,6 +947,11 @@ func init() {
           "Stage Resource"
         ],
         "summary": "Get the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/version"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success",
@@ -918,6 +1039,100 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/stage/{stageName}/resource/{resourceURI}/diff": {
+      "get": {
+        "tags": [
+          "Stage Resource"
+        ],
+        "summary": "Get the changes of the specified resource between two versions",
+        "parameters": [
+          {
+            "$ref": "#/parameters/fromVersion"
+          },
+          {
+            "$ref": "#/parameters/toVersion"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceDiff"
+            }
+          },
+          "404": {
+            "description": "Failed. Stage resource or version could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
+    },
+    "/project/{projectName}/stage/{stageName}/resource/{resourceURI}/history": {
+      "get": {
+        "tags": [
+          "Stage Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/pageSize"
+          },
+          {
+            "$ref": "#/parameters/nextPageKey"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceHistory"
+            }
+          },
+          "404": {
+            "description": "Failed. Stage resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
+    },
     "/project/{projectName}/stage/{stageName}/service": {
       "get": {
         "tags": [
@@ -1080,6 +1295,56 @@ func init() {
         }
       ]
     },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/promote": {
+      "post": {
+        "tags": [
+          "Service"
+        ],
+        "summary": "Promote the configuration of the service from another stage by merging the changes of the service directory into this stage",
+        "parameters": [
+          {
+            "$ref": "#/parameters/promotion"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. The changes have been merged. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
+          },
+          "400": {
+            "description": "Failed. Service could not be promoted.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "Failed. The service has conflicting changes in both stages; nothing has been changed.",
+            "schema": {
+              "$ref": "#/definitions/MergeConflict"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        },
+        {
+          "$ref": "#/parameters/serviceName"
+        }
+      ]
+    },
     "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource": {
       "get": {
         "tags": [
@@ -1092,6 +1357,12 @@ func init() {
           },
           {
             "$ref": "#/parameters/nextPageKey"
+          },
+          {
+            "$ref": "#/parameters/resourceURIPrefix"
+          },
+          {
+            "$ref": "#/parameters/resourceURIGlob"
           }
         ],
         "responses": {
@@ -1195,6 +1466,11 @@ func init() {
           "Service Resource"
         ],
         "summary": "Get the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/version"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success",
@@ -1284,29 +1560,225 @@ func init() {
           "$ref": "#/parameters/resourceURI"
         }
       ]
-    }
-  },
-  "definitions": {
-    "Error": {
-      "type": "object",
-      "required": [
-        "message"
-      ],
-      "properties": {
-        "code": {
-          "description": "Error code",
-          "type": "integer",
-          "format": "int64"
-        },
-        "message": {
-          "description": "Error message",
-          "type": "string"
+    },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/diff": {
+      "get": {
+        "tags": [
+          "Service Resource"
+        ],
+        "summary": "Get the changes of the specified resource between two versions",
+        "parameters": [
+          {
+            "$ref": "#/parameters/fromVersion"
+          },
+          {
+            "$ref": "#/parameters/toVersion"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceDiff"
+            }
+          },
+          "404": {
+            "description": "Failed. Service resource or version could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        },
+        {
+          "$ref": "#/parameters/serviceName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
+    },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/history": {
+      "get": {
+        "tags": [
+          "Service Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/pageSize"
+          },
+          {
+            "$ref": "#/parameters/nextPageKey"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceHistory"
+            }
+          },
+          "404": {
+            "description": "Failed. Service resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        },
+        {
+          "$ref": "#/parameters/stageName"
+        },
+        {
+          "$ref": "#/parameters/serviceName"
+        },
+        {
+          "$ref": "#/parameters/resourceURI"
+        }
+      ]
+    },
+    "/project/{projectName}/sync": {
+      "get": {
+        "tags": [
+          "Project"
+        ],
+        "summary": "Get the synchronization status of the project with its upstream repository",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/SyncStatus"
+            }
+          },
+          "404": {
+            "description": "Failed. Project could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "$ref": "#/parameters/projectName"
+        }
+      ]
+    }
+  },
+  "definitions": {
+    "BranchSyncStatus": {
+      "type": "object",
+      "properties": {
+        "ahead": {
+          "description": "Number of commits that have not been pushed to the upstream yet",
+          "type": "integer",
+          "format": "int64"
+        },
+        "behind": {
+          "description": "Number of commits of the upstream that have not been merged yet",
+          "type": "integer",
+          "format": "int64"
+        },
+        "branch": {
+          "description": "Name of the branch",
+          "type": "string"
+        },
+        "conflicts": {
+          "description": "Paths of the resources that have been changed in the branch and in the upstream",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "Error": {
+      "type": "object",
+      "required": [
+        "message"
+      ],
+      "properties": {
+        "code": {
+          "description": "Error code",
+          "type": "integer",
+          "format": "int64"
+        },
+        "message": {
+          "description": "Error message",
+          "type": "string"
+        }
+      }
+    },
+    "MergeConflict": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "description": "Error code",
+          "type": "integer",
+          "format": "int64"
+        },
+        "conflicts": {
+          "description": "Paths of the resources that have been changed in both stages",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "message": {
+          "description": "Error message",
+          "type": "string"
         }
       }
     },
     "Project": {
       "type": "object",
       "properties": {
+        "gitAcceptNewHostKey": {
+          "description": "Trust the SSH host key of the git remote on first use if it is not contained in gitKnownHosts. Only to be used if the network to the git remote is trusted",
+          "type": "boolean"
+        },
+        "gitCACertificate": {
+          "description": "PEM encoded CA bundle used to verify the git remote",
+          "type": "string"
+        },
+        "gitKnownHosts": {
+          "description": "known_hosts entries of the git remote, which its SSH host key is verified against. Required for a private key, unless gitAcceptNewHostKey is set",
+          "type": "string"
+        },
+        "gitPrivateKey": {
+          "description": "SSH private key (deploy key) used instead of the git user and token",
+          "type": "string"
+        },
         "gitRemoteURI": {
           "description": "Git remote URI",
           "type": "string"
@@ -1348,12 +1820,28 @@ func init() {
         }
       }
     },
+    "Promotion": {
+      "type": "object",
+      "properties": {
+        "sourceStage": {
+          "description": "Name of the stage the service is promoted from",
+          "type": "string"
+        }
+      }
+    },
     "Resource": {
       "type": "object",
       "required": [
         "resourceURI"
       ],
       "properties": {
+        "metadata": {
+          "$ref": "#/definitions/ResourceMetadata"
+        },
+        "origin": {
+          "description": "Origin of a service resource, either 'stage' if it is defined in the stage or 'default' if it is inherited from the default resources of the service",
+          "type": "string"
+        },
         "resourceContent": {
           "description": "Resource content",
           "type": "string"
@@ -1364,6 +1852,90 @@ func init() {
         }
       }
     },
+    "ResourceDiff": {
+      "type": "object",
+      "properties": {
+        "diff": {
+          "description": "Unified diff between the two versions of the resource",
+          "type": "string"
+        },
+        "fromVersion": {
+          "description": "Version the diff starts from",
+          "type": "string"
+        },
+        "resourceURI": {
+          "description": "Resource URI",
+          "type": "string"
+        },
+        "toVersion": {
+          "description": "Version the diff ends at",
+          "type": "string"
+        }
+      }
+    },
+    "ResourceHistory": {
+      "type": "object",
+      "properties": {
+        "nextPageKey": {
+          "description": "Pointer to next page, base64 encoded",
+          "type": "string"
+        },
+        "pageSize": {
+          "description": "Size of returned page",
+          "type": "number"
+        },
+        "totalCount": {
+          "description": "Total number of versions",
+          "type": "number"
+        },
+        "versions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ResourceVersion"
+          }
+        }
+      }
+    },
+    "ResourceMetadata": {
+      "type": "object",
+      "properties": {
+        "contentHash": {
+          "description": "Hash of the resource content (git blob hash), which allows to detect changed resources without retrieving their content",
+          "type": "string"
+        },
+        "size": {
+          "description": "Size of the resource content in bytes",
+          "type": "integer",
+          "format": "int64"
+        },
+        "version": {
+          "description": "Version (commit hash) that changed the resource last",
+          "type": "string"
+        }
+      }
+    },
+    "ResourceVersion": {
+      "type": "object",
+      "properties": {
+        "author": {
+          "description": "Author of the version",
+          "type": "string"
+        },
+        "message": {
+          "description": "Commit message of the version",
+          "type": "string"
+        },
+        "timestamp": {
+          "description": "Creation time of the version",
+          "type": "string",
+          "format": "date-time"
+        },
+        "version": {
+          "description": "Version identifier (commit hash)",
+          "type": "string"
+        }
+      }
+    },
     "Resources": {
       "type": "object",
       "properties": {
@@ -1451,17 +2023,55 @@ func init() {
         }
       }
     },
-    "Version": {
+    "SyncStatus": {
       "type": "object",
       "properties": {
-        "version": {
-          "description": "Version identifier",
+        "branches": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/BranchSyncStatus"
+          }
+        },
+        "lastError": {
+          "description": "Error of the last synchronization, if it failed",
           "type": "string"
-        }
-      }
-    }
+        },
+        "lastSync": {
+          "description": "Time of the last synchronization with the upstream",
+          "type": "string",
+          "format": "date-time"
+        },
+        "status": {
+          "description": "Synchronization status of the project with its upstream repository",
+          "type": "string",
+          "enum": [
+            "noUpstream",
+            "synchronized",
+            "pending",
+            "conflict",
+            "error"
+          ]
+        }
+      }
+    },
+    "Version": {
+      "type": "object",
+      "properties": {
+        "version": {
+          "description": "Version identifier",
+          "type": "string"
+        }
+      }
+    }
   },
   "parameters": {
+    "fromVersion": {
+      "type": "string",
+      "description": "Version (commit hash) the diff starts from",
+      "name": "fromVersion",
+      "in": "query",
+      "required": true
+    },
     "nextPageKey": {
       "type": "string",
       "description": "Pointer to the next set of items",
@@ -1492,6 +2102,14 @@ func init() {
       "in": "path",
       "required": true
     },
+    "promotion": {
+      "description": "Promotion",
+      "name": "promotion",
+      "in": "body",
+      "schema": {
+        "$ref": "#/definitions/Promotion"
+      }
+    },
     "resource": {
       "description": "Resource",
       "name": "resource",
@@ -1507,6 +2125,18 @@ func init() {
       "in": "path",
       "required": true
     },
+    "resourceURIGlob": {
+      "type": "string",
+      "description": "Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /",
+      "name": "resourceURIGlob",
+      "in": "query"
+    },
+    "resourceURIPrefix": {
+      "type": "string",
+      "description": "Only resources whose URI starts with the prefix (e.g., helm/) are returned",
+      "name": "resourceURIPrefix",
+      "in": "query"
+    },
     "resources": {
       "description": "List of resources",
       "name": "resources",
@@ -1551,6 +2181,18 @@ func init() {
       "name": "stageName",
       "in": "path",
       "required": true
+    },
+    "toVersion": {
+      "type": "string",
+      "description": "Version (commit hash) the diff ends at. The latest version is used if it is not set",
+      "name": "toVersion",
+      "in": "query"
+    },
+    "version": {
+      "type": "string",
+      "description": "Version (commit hash) of the resource. The latest version is returned if it is not set",
+      "name": "version",
+      "in": "query"
     }
   }
 }`))
@@ -1611,25 +2253,342 @@ func init() {
       },
       "post": {
         "tags": [
-          "Project"
+          "Project"
+        ],
+        "summary": "Create a new project by project name",
+        "parameters": [
+          {
+            "description": "Project entity",
+            "name": "project",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/Project"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Success. Project has been created. Response does not have a body."
+          },
+          "400": {
+            "description": "Failed. Project could not be created.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/project/{projectName}": {
+      "get": {
+        "tags": [
+          "Project"
+        ],
+        "summary": "Get the specified project",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Project"
+            }
+          },
+          "404": {
+            "description": "Failed. Project could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "Project"
+        ],
+        "summary": "Update the specified project",
+        "parameters": [
+          {
+            "description": "Project entity",
+            "name": "project",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/Project"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Success. Project has been updated. Response does not have a body."
+          },
+          "400": {
+            "description": "Failed. Project could not be updated.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "Project"
+        ],
+        "summary": "Delete the specified project",
+        "responses": {
+          "204": {
+            "description": "Success. Project has been deleted. Response does not have a body."
+          },
+          "400": {
+            "description": "Failed. Project could not be deleted.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/project/{projectName}/resource": {
+      "get": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Get list of project resources",
+        "parameters": [
+          {
+            "maximum": 50,
+            "minimum": 1,
+            "type": "integer",
+            "default": 20,
+            "description": "The number of items to return",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Pointer to the next set of items",
+            "name": "nextPageKey",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only resources whose URI starts with the prefix (e.g., helm/) are returned",
+            "name": "resourceURIPrefix",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /",
+            "name": "resourceURIGlob",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Resources"
+            }
+          },
+          "404": {
+            "description": "Failed. Containing project could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Update list of project resources",
+        "parameters": [
+          {
+            "description": "List of resources",
+            "name": "resources",
+            "in": "body",
+            "schema": {
+              "properties": {
+                "resources": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/Resource"
+                  }
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. Project resources have been updated. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
+          },
+          "400": {
+            "description": "Failed. Project resources could not be updated.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Create list of new resources for the project",
+        "parameters": [
+          {
+            "description": "List of resources",
+            "name": "resources",
+            "in": "body",
+            "schema": {
+              "properties": {
+                "resources": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/Resource"
+                  }
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. Project resource has been created. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
+          },
+          "400": {
+            "description": "Failed. Project resource could not be created.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/project/{projectName}/resource/{resourceURI}": {
+      "get": {
+        "tags": [
+          "Project Resource"
+        ],
+        "summary": "Get the specified resource",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Version (commit hash) of the resource. The latest version is returned if it is not set",
+            "name": "version",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Resource"
+            }
+          },
+          "404": {
+            "description": "Failed. Project resource could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "Project Resource"
         ],
-        "summary": "Create a new project by project name",
+        "summary": "Update the specified resource",
         "parameters": [
           {
-            "description": "Project entity",
-            "name": "project",
+            "description": "Resource",
+            "name": "resource",
             "in": "body",
             "schema": {
-              "$ref": "#/definitions/Project"
+              "$ref": "#/definitions/Resource"
             }
           }
         ],
         "responses": {
-          "204": {
-            "description": "Success. Project has been created. Response does not have a body."
+          "201": {
+            "description": "Success. Project resource has been updated. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
           },
           "400": {
-            "description": "Failed. Project could not be created.",
+            "description": "Failed. Project resource could not be updated.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1641,23 +2600,18 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/project/{projectName}": {
-      "get": {
+      },
+      "delete": {
         "tags": [
-          "Project"
+          "Project Resource"
         ],
-        "summary": "Get the specified project",
+        "summary": "Delete the specified resource",
         "responses": {
-          "200": {
-            "description": "Success",
-            "schema": {
-              "$ref": "#/definitions/Project"
-            }
+          "204": {
+            "description": "Success. Project resource has been deleted. Response does not have a body."
           },
-          "404": {
-            "description": "Failed. Project could not be found.",
+          "400": {
+            "description": "Failed. Project resource could not be deleted.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1670,27 +2624,53 @@ func init() {
           }
         }
       },
-      "put": {
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/project/{projectName}/resource/{resourceURI}/diff": {
+      "get": {
         "tags": [
-          "Project"
+          "Project Resource"
         ],
-        "summary": "Update the specified project",
+        "summary": "Get the changes of the specified resource between two versions",
         "parameters": [
           {
-            "description": "Project entity",
-            "name": "project",
-            "in": "body",
-            "schema": {
-              "$ref": "#/definitions/Project"
-            }
+            "type": "string",
+            "description": "Version (commit hash) the diff starts from",
+            "name": "fromVersion",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Version (commit hash) the diff ends at. The latest version is used if it is not set",
+            "name": "toVersion",
+            "in": "query"
           }
         ],
         "responses": {
-          "204": {
-            "description": "Success. Project has been updated. Response does not have a body."
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceDiff"
+            }
           },
-          "400": {
-            "description": "Failed. Project could not be updated.",
+          "404": {
+            "description": "Failed. Project resource or version could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1703,17 +2683,55 @@ func init() {
           }
         }
       },
-      "delete": {
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/project/{projectName}/resource/{resourceURI}/history": {
+      "get": {
         "tags": [
-          "Project"
+          "Project Resource"
+        ],
+        "summary": "Get the versions of the specified resource",
+        "parameters": [
+          {
+            "maximum": 50,
+            "minimum": 1,
+            "type": "integer",
+            "default": 20,
+            "description": "The number of items to return",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Pointer to the next set of items",
+            "name": "nextPageKey",
+            "in": "query"
+          }
         ],
-        "summary": "Delete the specified project",
         "responses": {
-          "204": {
-            "description": "Success. Project has been deleted. Response does not have a body."
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceHistory"
+            }
           },
-          "400": {
-            "description": "Failed. Project could not be deleted.",
+          "404": {
+            "description": "Failed. Project resource could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1733,15 +2751,22 @@ func init() {
           "name": "projectName",
           "in": "path",
           "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
         }
       ]
     },
-    "/project/{projectName}/resource": {
+    "/project/{projectName}/service/{serviceName}/resource": {
       "get": {
         "tags": [
-          "Project Resource"
+          "Service Default Resource"
         ],
-        "summary": "Get list of project resources",
+        "summary": "Get list of default resources for the service used in all stages",
         "parameters": [
           {
             "maximum": 50,
@@ -1757,6 +2782,18 @@ func init() {
             "description": "Pointer to the next set of items",
             "name": "nextPageKey",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only resources whose URI starts with the prefix (e.g., helm/) are returned",
+            "name": "resourceURIPrefix",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /",
+            "name": "resourceURIGlob",
+            "in": "query"
           }
         ],
         "responses": {
@@ -1767,7 +2804,7 @@ func init() {
             }
           },
           "404": {
-            "description": "Failed. Containing project could not be found.",
+            "description": "Failed. Containing service could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1782,9 +2819,9 @@ func init() {
       },
       "put": {
         "tags": [
-          "Project Resource"
+          "Service Default Resource"
         ],
-        "summary": "Update list of project resources",
+        "summary": "Update list of service default resources",
         "parameters": [
           {
             "description": "List of resources",
@@ -1804,13 +2841,13 @@ func init() {
         ],
         "responses": {
           "201": {
-            "description": "Success. Project resources have been updated. The version of the new configuration is returned.",
+            "description": "Success. Service default resources have been updated. The version of the new configuration is returned.",
             "schema": {
               "$ref": "#/definitions/Version"
             }
           },
           "400": {
-            "description": "Failed. Project resources could not be updated.",
+            "description": "Failed. Service default resources could not be updated.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1825,9 +2862,9 @@ func init() {
       },
       "post": {
         "tags": [
-          "Project Resource"
+          "Service Default Resource"
         ],
-        "summary": "Create list of new resources for the project",
+        "summary": "Create list of default resources for the service used in all stages",
         "parameters": [
           {
             "description": "List of resources",
@@ -1847,13 +2884,13 @@ func init() {
         ],
         "responses": {
           "201": {
-            "description": "Success. Project resource has been created. The version of the new configuration is returned.",
+            "description": "Success. Service default resource has been created. The version of the new configuration is returned.",
             "schema": {
               "$ref": "#/definitions/Version"
             }
           },
           "400": {
-            "description": "Failed. Project resource could not be created.",
+            "description": "Failed. Service default resource could not be created.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1873,15 +2910,22 @@ func init() {
           "name": "projectName",
           "in": "path",
           "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "serviceName",
+          "in": "path",
+          "required": true
         }
       ]
     },
-    "/project/{projectName}/resource/{resourceURI}": {
+    "/project/{projectName}/service/{serviceName}/resource/{resourceURI}": {
       "get": {
         "tags": [
-          "Project Resource"
+          "Service Default Resource"
         ],
-        "summary": "Get the specified resource",
+        "summary": "Get the specified default resource for the service",
         "responses": {
           "200": {
             "description": "Success",
@@ -1890,7 +2934,7 @@ func init() {
             }
           },
           "404": {
-            "description": "Failed. Project resource could not be found.",
+            "description": "Failed. Default resource could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1905,9 +2949,9 @@ func init() {
       },
       "put": {
         "tags": [
-          "Project Resource"
+          "Service Default Resource"
         ],
-        "summary": "Update the specified resource",
+        "summary": "Update the specified default resource for the service",
         "parameters": [
           {
             "description": "Resource",
@@ -1920,13 +2964,13 @@ func init() {
         ],
         "responses": {
           "201": {
-            "description": "Success. Project resource has been updated. The version of the new configuration is returned.",
+            "description": "Success. Service default resource has been updated. The version of the new configuration is returned.",
             "schema": {
               "$ref": "#/definitions/Version"
             }
           },
           "400": {
-            "description": "Failed. Project resource could not be updated.",
+            "description": "Failed. Service default resource could not be updated.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1941,15 +2985,15 @@ func init() {
       },
       "delete": {
         "tags": [
-          "Project Resource"
+          "Service Default Resource"
         ],
-        "summary": "Delete the specified resource",
+        "summary": "Delete the specified default resource for the service",
         "responses": {
           "204": {
-            "description": "Success. Project resource has been deleted. Response does not have a body."
+            "description": "Success. Service default resource has been deleted. Response does not have a body."
           },
           "400": {
-            "description": "Failed. Project resource could not be deleted.",
+            "description": "Failed. Service default resource could not be deleted.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1970,6 +3014,13 @@ func init() {
           "in": "path",
           "required": true
         },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "serviceName",
+          "in": "path",
+          "required": true
+        },
         {
           "type": "string",
           "description": "Resource URI",
@@ -1979,81 +3030,38 @@ func init() {
         }
       ]
     },
-    "/project/{projectName}/service/{serviceName}/resource": {
+    "/project/{projectName}/stage": {
       "get": {
         "tags": [
-          "Service Default Resource"
-        ],
-        "summary": "Get list of default resources for the service used in all stages",
-        "parameters": [
-          {
-            "maximum": 50,
-            "minimum": 1,
-            "type": "integer",
-            "default": 20,
-            "description": "The number of items to return",
-            "name": "pageSize",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "Pointer to the next set of items",
-            "name": "nextPageKey",
-            "in": "query"
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Success",
-            "schema": {
-              "$ref": "#/definitions/Resources"
-            }
-          },
-          "404": {
-            "description": "Failed. Containing service could not be found.",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          },
-          "default": {
-            "description": "Error",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
-        }
-      },
-      "put": {
-        "tags": [
-          "Service Default Resource"
+          "Stage"
         ],
-        "summary": "Update list of service default resources",
+        "summary": "Get list of stages",
         "parameters": [
           {
-            "description": "List of resources",
-            "name": "resources",
-            "in": "body",
-            "schema": {
-              "properties": {
-                "resources": {
-                  "type": "array",
-                  "items": {
-                    "$ref": "#/definitions/Resource"
-                  }
-                }
-              }
-            }
+            "maximum": 50,
+            "minimum": 1,
+            "type": "integer",
+            "default": 20,
+            "description": "The number of items to return",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Pointer to the next set of items",
+            "name": "nextPageKey",
+            "in": "query"
           }
         ],
         "responses": {
-          "201": {
-            "description": "Success. Service default resources have been updated. The version of the new configuration is returned.",
+          "200": {
+            "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Version"
+              "$ref": "#/definitions/Stages"
             }
           },
-          "400": {
-            "description": "Failed. Service default resources could not be updated.",
+          "404": {
+            "description": "Failed. Containing project could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2068,35 +3076,25 @@ func init() {
       },
       "post": {
         "tags": [
-          "Service Default Resource"
+          "Stage"
         ],
-        "summary": "Create list of default resources for the service used in all stages",
+        "summary": "Create a new stage by stage name",
         "parameters": [
           {
-            "description": "List of resources",
-            "name": "resources",
+            "description": "Stage entity",
+            "name": "stage",
             "in": "body",
             "schema": {
-              "properties": {
-                "resources": {
-                  "type": "array",
-                  "items": {
-                    "$ref": "#/definitions/Resource"
-                  }
-                }
-              }
+              "$ref": "#/definitions/Stage"
             }
           }
         ],
         "responses": {
-          "201": {
-            "description": "Success. Service default resource has been created. The version of the new configuration is returned.",
-            "schema": {
-              "$ref": "#/definitions/Version"
-            }
+          "204": {
+            "description": "Success. Stage has been created. Response does not have a body."
           },
           "400": {
-            "description": "Failed. Service default resource could not be created.",
+            "description": "Failed. Stage could not be created.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2116,31 +3114,24 @@ func init() {
           "name": "projectName",
           "in": "path",
           "required": true
-        },
-        {
-          "type": "string",
-          "description": "Name of the service",
-          "name": "serviceName",
-          "in": "path",
-          "required": true
         }
       ]
     },
-    "/project/{projectName}/service/{serviceName}/resource/{resourceURI}": {
+    "/project/{projectName}/stage/{stageName}": {
       "get": {
         "tags": [
-          "Service Default Resource"
+          "Stage"
         ],
-        "summary": "Get the specified default resource for the service",
+        "summary": "Get the specified stage",
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Resource"
+              "$ref": "#/definitions/Stage"
             }
           },
           "404": {
-            "description": "Failed. Default resource could not be found.",
+            "description": "Failed. Project resource could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2155,18 +3146,25 @@ func init() {
       },
       "put": {
         "tags": [
-          "Service Default Resource"
+          "Stage"
         ],
-        "summary": "Update the specified default resource for the service",
-        "responses": {
-          "201": {
-            "description": "Success. Service default resource has been updated. The version of the new configuration is returned.",
+        "summary": "Update the specified stage",
+        "parameters": [
+          {
+            "description": "Stage entity",
+            "name": "stage",
+            "in": "body",
             "schema": {
-              "$ref": "#/definitions/Version"
+              "$ref": "#/definitions/Stage"
             }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Success. Stage has been updated. Response does not have a body."
           },
           "400": {
-            "description": "Failed. Service default resource could not be updated.",
+            "description": "Failed. Stage could not be updated.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2181,15 +3179,15 @@ func init() {
       },
       "delete": {
         "tags": [
-          "Service Default Resource"
+          "Stage"
         ],
-        "summary": "Delete the specified default resource for the service",
+        "summary": "Delete the specified stage",
         "responses": {
           "204": {
-            "description": "Success. Service default resource has been deleted. Response does not have a body."
+            "description": "Success. Stage has been deleted. Response does not have a body."
           },
           "400": {
-            "description": "Failed. Service default resource could not be deleted.",
+            "description": "Failed. Stage could not be deleted.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2212,26 +3210,19 @@ func init() {
         },
         {
           "type": "string",
-          "description": "Name of the service",
-          "name": "serviceName",
-          "in": "path",
-          "required": true
-        },
-        {
-          "type": "string",
-          "description": "Resource URI",
-          "name": "resourceURI",
+          "description": "Name of the stage",
+          "name": "stageName",
           "in": "path",
           "required": true
         }
       ]
     },
-    "/project/{projectName}/stage": {
+    "/project/{projectName}/stage/{stageName}/resource": {
       "get": {
         "tags": [
-          "Stage"
+          "Stage Resource"
         ],
-        "summary": "Get list of stages",
+        "summary": "Get list of stage resources",
         "parameters": [
           {
             "maximum": 50,
@@ -2247,17 +3238,72 @@ func init() {
             "description": "Pointer to the next set of items",
             "name": "nextPageKey",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only resources whose URI starts with the prefix (e.g., helm/) are returned",
+            "name": "resourceURIPrefix",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /",
+            "name": "resourceURIGlob",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Stages"
+              "$ref": "#/definitions/Resources"
             }
           },
           "404": {
-            "description": "Failed. Containing project could not be found.",
+            "description": "Failed. Stage could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "Stage Resource"
+        ],
+        "summary": "Update list of stage resources",
+        "parameters": [
+          {
+            "description": "List of resources",
+            "name": "resources",
+            "in": "body",
+            "schema": {
+              "properties": {
+                "resources": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/Resource"
+                  }
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. Stage resources have been updated. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
+          },
+          "400": {
+            "description": "Failed. Stage resources could not be updated.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2272,25 +3318,35 @@ func init() {
       },
       "post": {
         "tags": [
-          "Stage"
+          "Stage Resource"
         ],
-        "summary": "Create a new stage by stage name",
+        "summary": "Create list of new resources for the stage",
         "parameters": [
           {
-            "description": "Stage entity",
-            "name": "stage",
+            "description": "List of resources",
+            "name": "resources",
             "in": "body",
             "schema": {
-              "$ref": "#/definitions/Stage"
+              "properties": {
+                "resources": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/Resource"
+                  }
+                }
+              }
             }
           }
         ],
         "responses": {
-          "204": {
-            "description": "Success. Stage has been created. Response does not have a body."
+          "201": {
+            "description": "Success. Stage resource has been created. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
           },
           "400": {
-            "description": "Failed. Stage could not be created.",
+            "description": "Failed. Stage resource could not be created.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2310,24 +3366,39 @@ func init() {
           "name": "projectName",
           "in": "path",
           "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
         }
       ]
     },
-    "/project/{projectName}/stage/{stageName}": {
+    "/project/{projectName}/stage/{stageName}/resource/{resourceURI}": {
       "get": {
         "tags": [
-          "Stage"
+          "Stage Resource"
+        ],
+        "summary": "Get the specified resource",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Version (commit hash) of the resource. The latest version is returned if it is not set",
+            "name": "version",
+            "in": "query"
+          }
         ],
-        "summary": "Get the specified stage",
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Stage"
+              "$ref": "#/definitions/Resource"
             }
           },
           "404": {
-            "description": "Failed. Project resource could not be found.",
+            "description": "Failed. Stage resource could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2342,25 +3413,51 @@ func init() {
       },
       "put": {
         "tags": [
-          "Stage"
+          "Stage Resource"
         ],
-        "summary": "Update the specified stage",
+        "summary": "Update the specified resource",
         "parameters": [
           {
-            "description": "Stage entity",
-            "name": "stage",
+            "description": "Resource",
+            "name": "resource",
             "in": "body",
             "schema": {
-              "$ref": "#/definitions/Stage"
+              "$ref": "#/definitions/Resource"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. Stage resource has been updated. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
+          },
+          "400": {
+            "description": "Failed. Stage resource could not be updated.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
             }
           }
+        }
+      },
+      "delete": {
+        "tags": [
+          "Stage Resource"
         ],
+        "summary": "Delete the specified resource",
         "responses": {
           "204": {
-            "description": "Success. Stage has been updated. Response does not have a body."
+            "description": "Success. Stage resource has been deleted. Response does not have a body."
           },
           "400": {
-            "description": "Failed. Stage could not be updated.",
+            "description": "Failed. Stage resource could not be deleted.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2373,17 +3470,60 @@ func init() {
           }
         }
       },
-      "delete": {
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/project/{projectName}/stage/{stageName}/resource/{resourceURI}/diff": {
+      "get": {
         "tags": [
-          "Stage"
+          "Stage Resource"
+        ],
+        "summary": "Get the changes of the specified resource between two versions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Version (commit hash) the diff starts from",
+            "name": "fromVersion",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Version (commit hash) the diff ends at. The latest version is used if it is not set",
+            "name": "toVersion",
+            "in": "query"
+          }
         ],
-        "summary": "Delete the specified stage",
         "responses": {
-          "204": {
-            "description": "Success. Stage has been deleted. Response does not have a body."
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ResourceDiff"
+            }
           },
-          "400": {
-            "description": "Failed. Stage could not be deleted.",
+          "404": {
+            "description": "Failed. Stage resource or version could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2410,15 +3550,22 @@ func init() {
           "name": "stageName",
           "in": "path",
           "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
         }
       ]
     },
-    "/project/{projectName}/stage/{stageName}/resource": {
+    "/project/{projectName}/stage/{stageName}/resource/{resourceURI}/history": {
       "get": {
         "tags": [
           "Stage Resource"
         ],
-        "summary": "Get list of stage resources",
+        "summary": "Get the versions of the specified resource",
         "parameters": [
           {
             "maximum": 50,
@@ -2440,11 +3587,11 @@ func init() {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Resources"
+              "$ref": "#/definitions/ResourceHistory"
             }
           },
           "404": {
-            "description": "Failed. Stage could not be found.",
+            "description": "Failed. Stage resource could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2457,37 +3604,62 @@ func init() {
           }
         }
       },
-      "put": {
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/project/{projectName}/stage/{stageName}/service": {
+      "get": {
         "tags": [
-          "Stage Resource"
+          "Service"
         ],
-        "summary": "Update list of stage resources",
+        "summary": "Get list of services",
         "parameters": [
           {
-            "description": "List of resources",
-            "name": "resources",
-            "in": "body",
-            "schema": {
-              "properties": {
-                "resources": {
-                  "type": "array",
-                  "items": {
-                    "$ref": "#/definitions/Resource"
-                  }
-                }
-              }
-            }
+            "maximum": 50,
+            "minimum": 1,
+            "type": "integer",
+            "default": 20,
+            "description": "The number of items to return",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Pointer to the next set of items",
+            "name": "nextPageKey",
+            "in": "query"
           }
         ],
         "responses": {
-          "201": {
-            "description": "Success. Stage resources have been updated. The version of the new configuration is returned.",
+          "200": {
+            "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Version"
+              "$ref": "#/definitions/Services"
             }
           },
-          "400": {
-            "description": "Failed. Stage resources could not be updated.",
+          "404": {
+            "description": "Failed. Containing project could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2502,35 +3674,25 @@ func init() {
       },
       "post": {
         "tags": [
-          "Stage Resource"
+          "Service"
         ],
-        "summary": "Create list of new resources for the stage",
+        "summary": "Create a new service by service name",
         "parameters": [
           {
-            "description": "List of resources",
-            "name": "resources",
+            "description": "Service entity",
+            "name": "service",
             "in": "body",
             "schema": {
-              "properties": {
-                "resources": {
-                  "type": "array",
-                  "items": {
-                    "$ref": "#/definitions/Resource"
-                  }
-                }
-              }
+              "$ref": "#/definitions/Service"
             }
           }
         ],
         "responses": {
-          "201": {
-            "description": "Success. Stage resource has been created. The version of the new configuration is returned.",
-            "schema": {
-              "$ref": "#/definitions/Version"
-            }
+          "204": {
+            "description": "Success. Stage has been created. Response does not have a body."
           },
           "400": {
-            "description": "Failed. Stage resource could not be created.",
+            "description": "Failed. Service could not be created.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2560,21 +3722,21 @@ func init() {
         }
       ]
     },
-    "/project/{projectName}/stage/{stageName}/resource/{resourceURI}": {
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}": {
       "get": {
         "tags": [
-          "Stage Resource"
+          "Service"
         ],
-        "summary": "Get the specified resource",
+        "summary": "Get the specified service",
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Resource"
+              "$ref": "#/definitions/Service"
             }
           },
           "404": {
-            "description": "Failed. Stage resource could not be found.",
+            "description": "Failed. Service could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2589,28 +3751,25 @@ func init() {
       },
       "put": {
         "tags": [
-          "Stage Resource"
+          "Service"
         ],
-        "summary": "Update the specified resource",
+        "summary": "Update the specified service",
         "parameters": [
           {
-            "description": "Resource",
-            "name": "resource",
+            "description": "Service entity",
+            "name": "service",
             "in": "body",
             "schema": {
-              "$ref": "#/definitions/Resource"
+              "$ref": "#/definitions/Service"
             }
           }
         ],
         "responses": {
-          "201": {
-            "description": "Success. Stage resource has been updated. The version of the new configuration is returned.",
-            "schema": {
-              "$ref": "#/definitions/Version"
-            }
+          "204": {
+            "description": "Success. Service has been updated. Response does not have a body."
           },
           "400": {
-            "description": "Failed. Stage resource could not be updated.",
+            "description": "Failed. Service could not be updated.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2625,19 +3784,86 @@ func init() {
       },
       "delete": {
         "tags": [
-          "Stage Resource"
+          "Service"
         ],
-        "summary": "Delete the specified resource",
+        "summary": "Delete the specified service",
         "responses": {
           "204": {
-            "description": "Success. Stage resource has been deleted. Response does not have a body."
+            "description": "Success. Service has been deleted. Response does not have a body."
+          },
+          "400": {
+            "description": "Failed. Service could not be deleted.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "serviceName",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/promote": {
+      "post": {
+        "tags": [
+          "Service"
+        ],
+        "summary": "Promote the configuration of the service from another stage by merging the changes of the service directory into this stage",
+        "parameters": [
+          {
+            "description": "Promotion",
+            "name": "promotion",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/Promotion"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. The changes have been merged. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
           },
           "400": {
-            "description": "Failed. Stage resource could not be deleted.",
+            "description": "Failed. Service could not be promoted.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
           },
+          "409": {
+            "description": "Failed. The service has conflicting changes in both stages; nothing has been changed.",
+            "schema": {
+              "$ref": "#/definitions/MergeConflict"
+            }
+          },
           "default": {
             "description": "Error",
             "schema": {
@@ -2663,19 +3889,19 @@ func init() {
         },
         {
           "type": "string",
-          "description": "Resource URI",
-          "name": "resourceURI",
+          "description": "Name of the service",
+          "name": "serviceName",
           "in": "path",
           "required": true
         }
       ]
     },
-    "/project/{projectName}/stage/{stageName}/service": {
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource": {
       "get": {
         "tags": [
-          "Service"
+          "Service Resource"
         ],
-        "summary": "Get list of services",
+        "summary": "Get list of service resources",
         "parameters": [
           {
             "maximum": 50,
@@ -2691,17 +3917,72 @@ func init() {
             "description": "Pointer to the next set of items",
             "name": "nextPageKey",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only resources whose URI starts with the prefix (e.g., helm/) are returned",
+            "name": "resourceURIPrefix",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /",
+            "name": "resourceURIGlob",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Services"
+              "$ref": "#/definitions/Resources"
             }
           },
           "404": {
-            "description": "Failed. Containing project could not be found.",
+            "description": "Failed. Containing Service could not be found.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "Error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "Service Resource"
+        ],
+        "summary": "Update list of service resources",
+        "parameters": [
+          {
+            "description": "List of resources",
+            "name": "resources",
+            "in": "body",
+            "schema": {
+              "properties": {
+                "resources": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/Resource"
+                  }
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success. Service resources have been updated. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
+          },
+          "400": {
+            "description": "Failed. Service resources could not be updated.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2716,25 +3997,35 @@ func init() {
       },
       "post": {
         "tags": [
-          "Service"
+          "Service Resource"
         ],
-        "summary": "Create a new service by service name",
+        "summary": "Create list of new resources for the service",
         "parameters": [
           {
-            "description": "Service entity",
-            "name": "service",
+            "description": "List of resources",
+            "name": "resources",
             "in": "body",
             "schema": {
-              "$ref": "#/definitions/Service"
+              "properties": {
+                "resources": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/Resource"
+                  }
+                }
+              }
             }
           }
         ],
         "responses": {
-          "204": {
-            "description": "Success. Stage has been created. Response does not have a body."
+          "201": {
+            "description": "Success. Service resource has been created. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
           },
           "400": {
-            "description": "Failed. Service could not be created.",
+            "description": "Failed. Service resource could not be created.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2761,24 +4052,39 @@ func init() {
           "name": "stageName",
           "in": "path",
           "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "serviceName",
+          "in": "path",
+          "required": true
         }
       ]
     },
-    "/project/{projectName}/stage/{stageName}/service/{serviceName}": {
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}": {
       "get": {
         "tags": [
-          "Service"
+          "Service Resource"
+        ],
+        "summary": "Get the specified resource",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Version (commit hash) of the resource. The latest version is returned if it is not set",
+            "name": "version",
+            "in": "query"
+          }
         ],
-        "summary": "Get the specified service",
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Service"
+              "$ref": "#/definitions/Resource"
             }
           },
           "404": {
-            "description": "Failed. Service could not be found.",
+            "description": "Failed. Service resource could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2793,25 +4099,28 @@ func init() {
       },
       "put": {
         "tags": [
-          "Service"
+          "Service Resource"
         ],
-        "summary": "Update the specified service",
+        "summary": "Update the specified resource",
         "parameters": [
           {
-            "description": "Service entity",
-            "name": "service",
+            "description": "Resource",
+            "name": "resource",
             "in": "body",
             "schema": {
-              "$ref": "#/definitions/Service"
+              "$ref": "#/definitions/Resource"
             }
           }
         ],
         "responses": {
-          "204": {
-            "description": "Success. Service has been updated. Response does not have a body."
+          "201": {
+            "description": "Success. Service resource has been updated. The version of the new configuration is returned.",
+            "schema": {
+              "$ref": "#/definitions/Version"
+            }
           },
           "400": {
-            "description": "Failed. Service could not be updated.",
+            "description": "Failed. Service resource could not be updated.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2826,15 +4135,15 @@ func init() {
       },
       "delete": {
         "tags": [
-          "Service"
+          "Service Resource"
         ],
-        "summary": "Delete the specified service",
+        "summary": "Delete the specified resource",
         "responses": {
           "204": {
-            "description": "Success. Service has been deleted. Response does not have a body."
+            "description": "Success. Service resource has been deleted. Response does not have a body."
           },
           "400": {
-            "description": "Failed. Service could not be deleted.",
+            "description": "Failed. Service resource could not be deleted.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2868,29 +4177,34 @@ func init() {
           "name": "serviceName",
           "in": "path",
           "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
         }
       ]
     },
-    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource": {
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/diff": {
       "get": {
         "tags": [
           "Service Resource"
         ],
-        "summary": "Get list of service resources",
+        "summary": "Get the changes of the specified resource between two versions",
         "parameters": [
           {
-            "maximum": 50,
-            "minimum": 1,
-            "type": "integer",
-            "default": 20,
-            "description": "The number of items to return",
-            "name": "pageSize",
-            "in": "query"
+            "type": "string",
+            "description": "Version (commit hash) the diff starts from",
+            "name": "fromVersion",
+            "in": "query",
+            "required": true
           },
           {
             "type": "string",
-            "description": "Pointer to the next set of items",
-            "name": "nextPageKey",
+            "description": "Version (commit hash) the diff ends at. The latest version is used if it is not set",
+            "name": "toVersion",
             "in": "query"
           }
         ],
@@ -2898,11 +4212,11 @@ func init() {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Resources"
+              "$ref": "#/definitions/ResourceDiff"
             }
           },
           "404": {
-            "description": "Failed. Containing Service could not be found.",
+            "description": "Failed. Service resource or version could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2915,80 +4229,69 @@ func init() {
           }
         }
       },
-      "put": {
-        "tags": [
-          "Service Resource"
-        ],
-        "summary": "Update list of service resources",
-        "parameters": [
-          {
-            "description": "List of resources",
-            "name": "resources",
-            "in": "body",
-            "schema": {
-              "properties": {
-                "resources": {
-                  "type": "array",
-                  "items": {
-                    "$ref": "#/definitions/Resource"
-                  }
-                }
-              }
-            }
-          }
-        ],
-        "responses": {
-          "201": {
-            "description": "Success. Service resources have been updated. The version of the new configuration is returned.",
-            "schema": {
-              "$ref": "#/definitions/Version"
-            }
-          },
-          "400": {
-            "description": "Failed. Service resources could not be updated.",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          },
-          "default": {
-            "description": "Error",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "projectName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stageName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "serviceName",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
         }
-      },
-      "post": {
+      ]
+    },
+    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/history": {
+      "get": {
         "tags": [
           "Service Resource"
         ],
-        "summary": "Create list of new resources for the service",
+        "summary": "Get the versions of the specified resource",
         "parameters": [
           {
-            "description": "List of resources",
-            "name": "resources",
-            "in": "body",
-            "schema": {
-              "properties": {
-                "resources": {
-                  "type": "array",
-                  "items": {
-                    "$ref": "#/definitions/Resource"
-                  }
-                }
-              }
-            }
+            "maximum": 50,
+            "minimum": 1,
+            "type": "integer",
+            "default": 20,
+            "description": "The number of items to return",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Pointer to the next set of items",
+            "name": "nextPageKey",
+            "in": "query"
           }
         ],
         "responses": {
-          "201": {
-            "description": "Success. Service resource has been created. The version of the new configuration is returned.",
+          "200": {
+            "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Version"
+              "$ref": "#/definitions/ResourceHistory"
             }
           },
-          "400": {
-            "description": "Failed. Service resource could not be created.",
+          "404": {
+            "description": "Failed. Service resource could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -3022,83 +4325,31 @@ func init() {
           "name": "serviceName",
           "in": "path",
           "required": true
+        },
+        {
+          "type": "string",
+          "description": "Resource URI",
+          "name": "resourceURI",
+          "in": "path",
+          "required": true
         }
       ]
     },
-    "/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}": {
+    "/project/{projectName}/sync": {
       "get": {
         "tags": [
-          "Service Resource"
+          "Project"
         ],
-        "summary": "Get the specified resource",
+        "summary": "Get the synchronization status of the project with its upstream repository",
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Resource"
+              "$ref": "#/definitions/SyncStatus"
             }
           },
           "404": {
-            "description": "Failed. Service resource could not be found.",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          },
-          "default": {
-            "description": "Error",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
-        }
-      },
-      "put": {
-        "tags": [
-          "Service Resource"
-        ],
-        "summary": "Update the specified resource",
-        "parameters": [
-          {
-            "description": "Resource",
-            "name": "resource",
-            "in": "body",
-            "schema": {
-              "$ref": "#/definitions/Resource"
-            }
-          }
-        ],
-        "responses": {
-          "201": {
-            "description": "Success. Service resource has been updated. The version of the new configuration is returned.",
-            "schema": {
-              "$ref": "#/definitions/Version"
-            }
-          },
-          "400": {
-            "description": "Failed. Service resource could not be updated.",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          },
-          "default": {
-            "description": "Error",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
-        }
-      },
-      "delete": {
-        "tags": [
-          "Service Resource"
-        ],
-        "summary": "Delete the specified resource",
-        "responses": {
-          "204": {
-            "description": "Success. Service resource has been deleted. Response does not have a body."
-          },
-          "400": {
-            "description": "Failed. Service resource could not be deleted.",
+            "description": "Failed. Project could not be found.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -3118,32 +4369,37 @@ func init() {
           "name": "projectName",
           "in": "path",
           "required": true
-        },
-        {
-          "type": "string",
-          "description": "Name of the stage",
-          "name": "stageName",
-          "in": "path",
-          "required": true
-        },
-        {
-          "type": "string",
-          "description": "Name of the service",
-          "name": "serviceName",
-          "in": "path",
-          "required": true
-        },
-        {
-          "type": "string",
-          "description": "Resource URI",
-          "name": "resourceURI",
-          "in": "path",
-          "required": true
         }
       ]
     }
   },
   "definitions": {
+    "BranchSyncStatus": {
+      "type": "object",
+      "properties": {
+        "ahead": {
+          "description": "Number of commits that have not been pushed to the upstream yet",
+          "type": "integer",
+          "format": "int64"
+        },
+        "behind": {
+          "description": "Number of commits of the upstream that have not been merged yet",
+          "type": "integer",
+          "format": "int64"
+        },
+        "branch": {
+          "description": "Name of the branch",
+          "type": "string"
+        },
+        "conflicts": {
+          "description": "Paths of the resources that have been changed in the branch and in the upstream",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "Error": {
       "type": "object",
       "required": [
@@ -3161,9 +4417,46 @@ func init() {
         }
       }
     },
+    "MergeConflict": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "description": "Error code",
+          "type": "integer",
+          "format": "int64"
+        },
+        "conflicts": {
+          "description": "Paths of the resources that have been changed in both stages",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "message": {
+          "description": "Error message",
+          "type": "string"
+        }
+      }
+    },
     "Project": {
       "type": "object",
       "properties": {
+        "gitAcceptNewHostKey": {
+          "description": "Trust the SSH host key of the git remote on first use if it is not contained in gitKnownHosts. Only to be used if the network to the git remote is trusted",
+          "type": "boolean"
+        },
+        "gitCACertificate": {
+          "description": "PEM encoded CA bundle used to verify the git remote",
+          "type": "string"
+        },
+        "gitKnownHosts": {
+          "description": "known_hosts entries of the git remote, which its SSH host key is verified against. Required for a private key, unless gitAcceptNewHostKey is set",
+          "type": "string"
+        },
+        "gitPrivateKey": {
+          "description": "SSH private key (deploy key) used instead of the git user and token",
+          "type": "string"
+        },
         "gitRemoteURI": {
           "description": "Git remote URI",
           "type": "string"
@@ -3205,12 +4498,28 @@ func init() {
         }
       }
     },
+    "Promotion": {
+      "type": "object",
+      "properties": {
+        "sourceStage": {
+          "description": "Name of the stage the service is promoted from",
+          "type": "string"
+        }
+      }
+    },
     "Resource": {
       "type": "object",
       "required": [
         "resourceURI"
       ],
       "properties": {
+        "metadata": {
+          "$ref": "#/definitions/ResourceMetadata"
+        },
+        "origin": {
+          "description": "Origin of a service resource, either 'stage' if it is defined in the stage or 'default' if it is inherited from the default resources of the service",
+          "type": "string"
+        },
         "resourceContent": {
           "description": "Resource content",
           "type": "string"
@@ -3221,6 +4530,90 @@ func init() {
         }
       }
     },
+    "ResourceDiff": {
+      "type": "object",
+      "properties": {
+        "diff": {
+          "description": "Unified diff between the two versions of the resource",
+          "type": "string"
+        },
+        "fromVersion": {
+          "description": "Version the diff starts from",
+          "type": "string"
+        },
+        "resourceURI": {
+          "description": "Resource URI",
+          "type": "string"
+        },
+        "toVersion": {
+          "description": "Version the diff ends at",
+          "type": "string"
+        }
+      }
+    },
+    "ResourceHistory": {
+      "type": "object",
+      "properties": {
+        "nextPageKey": {
+          "description": "Pointer to next page, base64 encoded",
+          "type": "string"
+        },
+        "pageSize": {
+          "description": "Size of returned page",
+          "type": "number"
+        },
+        "totalCount": {
+          "description": "Total number of versions",
+          "type": "number"
+        },
+        "versions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ResourceVersion"
+          }
+        }
+      }
+    },
+    "ResourceMetadata": {
+      "type": "object",
+      "properties": {
+        "contentHash": {
+          "description": "Hash of the resource content (git blob hash), which allows to detect changed resources without retrieving their content",
+          "type": "string"
+        },
+        "size": {
+          "description": "Size of the resource content in bytes",
+          "type": "integer",
+          "format": "int64"
+        },
+        "version": {
+          "description": "Version (commit hash) that changed the resource last",
+          "type": "string"
+        }
+      }
+    },
+    "ResourceVersion": {
+      "type": "object",
+      "properties": {
+        "author": {
+          "description": "Author of the version",
+          "type": "string"
+        },
+        "message": {
+          "description": "Commit message of the version",
+          "type": "string"
+        },
+        "timestamp": {
+          "description": "Creation time of the version",
+          "type": "string",
+          "format": "date-time"
+        },
+        "version": {
+          "description": "Version identifier (commit hash)",
+          "type": "string"
+        }
+      }
+    },
     "Resources": {
       "type": "object",
       "properties": {
@@ -3308,6 +4701,37 @@ func init() {
         }
       }
     },
+    "SyncStatus": {
+      "type": "object",
+      "properties": {
+        "branches": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/BranchSyncStatus"
+          }
+        },
+        "lastError": {
+          "description": "Error of the last synchronization, if it failed",
+          "type": "string"
+        },
+        "lastSync": {
+          "description": "Time of the last synchronization with the upstream",
+          "type": "string",
+          "format": "date-time"
+        },
+        "status": {
+          "description": "Synchronization status of the project with its upstream repository",
+          "type": "string",
+          "enum": [
+            "noUpstream",
+            "synchronized",
+            "pending",
+            "conflict",
+            "error"
+          ]
+        }
+      }
+    },
     "Version": {
       "type": "object",
       "properties": {
@@ -3319,6 +4743,13 @@ func init() {
     }
   },
   "parameters": {
+    "fromVersion": {
+      "type": "string",
+      "description": "Version (commit hash) the diff starts from",
+      "name": "fromVersion",
+      "in": "query",
+      "required": true
+    },
     "nextPageKey": {
       "type": "string",
       "description": "Pointer to the next set of items",
@@ -3349,6 +4780,14 @@ func init() {
       "in": "path",
       "required": true
     },
+    "promotion": {
+      "description": "Promotion",
+      "name": "promotion",
+      "in": "body",
+      "schema": {
+        "$ref": "#/definitions/Promotion"
+      }
+    },
     "resource": {
       "description": "Resource",
       "name": "resource",
@@ -3364,6 +4803,18 @@ func init() {
       "in": "path",
       "required": true
     },
+    "resourceURIGlob": {
+      "type": "string",
+      "description": "Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /",
+      "name": "resourceURIGlob",
+      "in": "query"
+    },
+    "resourceURIPrefix": {
+      "type": "string",
+      "description": "Only resources whose URI starts with the prefix (e.g., helm/) are returned",
+      "name": "resourceURIPrefix",
+      "in": "query"
+    },
     "resources": {
       "description": "List of resources",
       "name": "resources",
@@ -3408,6 +4859,18 @@ func init() {
       "name": "stageName",
       "in": "path",
       "required": true
+    },
+    "toVersion": {
+      "type": "string",
+      "description": "Version (commit hash) the diff ends at. The latest version is used if it is not set",
+      "name": "toVersion",
+      "in": "query"
+    },
+    "version": {
+      "type": "string",
+      "description": "Version (commit hash) of the resource. The latest version is returned if it is not set",
+      "name": "version",
+      "in": "query"
     }
   }
 }`))
@@ -78,6 +78,12 @@ func NewConfigurationServiceAPI(spec *loads.Document) *ConfigurationServiceAPI {
 		ProjectResourceGetProjectProjectNameResourceResourceURIHandler: project_resource.GetProjectProjectNameResourceResourceURIHandlerFunc(func(params project_resource.GetProjectProjectNameResourceResourceURIParams) middleware.Responder {
 			return middleware.NotImplemented("operation ProjectResourceGetProjectProjectNameResourceResourceURI has not yet been implemented")
 		}),
+		ProjectResourceGetProjectProjectNameResourceResourceURIDiffHandler: project_resource.GetProjectProjectNameResourceResourceURIDiffHandlerFunc(func(params project_resource.GetProjectProjectNameResourceResourceURIDiffParams) middleware.Responder {
+			return middleware.NotImplemented("operation ProjectResourceGetProjectProjectNameResourceResourceURIDiff has not yet been implemented")
+		}),
+		ProjectResourceGetProjectProjectNameResourceResourceURIHistoryHandler: project_resource.GetProjectProjectNameResourceResourceURIHistoryHandlerFunc(func(params project_resource.GetProjectProjectNameResourceResourceURIHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation ProjectResourceGetProjectProjectNameResourceResourceURIHistory has not yet been implemented")
+		}),
 		ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler: service_default_resource.GetProjectProjectNameServiceServiceNameResourceHandlerFunc(func(params service_default_resource.GetProjectProjectNameServiceServiceNameResourceParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResource has not yet been implemented")
 		}),
@@ -96,6 +102,12 @@ func NewConfigurationServiceAPI(spec *loads.Document) *ConfigurationServiceAPI {
 		StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler: stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHandlerFunc(func(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIParams) middleware.Responder {
 			return middleware.NotImplemented("operation StageResourceGetProjectProjectNameStageStageNameResourceResourceURI has not yet been implemented")
 		}),
+		StageResourceGetProjectProjectNameStageStageNameResourceResourceURIDiffHandler: stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffHandlerFunc(func(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffParams) middleware.Responder {
+			return middleware.NotImplemented("operation StageResourceGetProjectProjectNameStageStageNameResourceResourceURIDiff has not yet been implemented")
+		}),
+		StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHistoryHandler: stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandlerFunc(func(params stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHistory has not yet been implemented")
+		}),
 		ServiceGetProjectProjectNameStageStageNameServiceHandler: service.GetProjectProjectNameStageStageNameServiceHandlerFunc(func(params service.GetProjectProjectNameStageStageNameServiceParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceGetProjectProjectNameStageStageNameService has not yet been implemented")
 		}),
@@ -108,6 +120,15 @@ func NewConfigurationServiceAPI(spec *loads.Document) *ConfigurationServiceAPI {
 		ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler: service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandlerFunc(func(params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURI has not yet been implemented")
 		}),
+		ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandler: service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandlerFunc(func(params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffParams) middleware.Responder {
+			return middleware.NotImplemented("operation ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiff has not yet been implemented")
+		}),
+		ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandler: service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandlerFunc(func(params service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistory has not yet been implemented")
+		}),
+		ProjectGetProjectProjectNameSyncHandler: project.GetProjectProjectNameSyncHandlerFunc(func(params project.GetProjectProjectNameSyncParams) middleware.Responder {
+			return middleware.NotImplemented("operation ProjectGetProjectProjectNameSync has not yet been implemented")
+		}),
 		ProjectPostProjectHandler: project.PostProjectHandlerFunc(func(params project.PostProjectParams) middleware.Responder {
 			return middleware.NotImplemented("operation ProjectPostProject has not yet been implemented")
 		}),
@@ -126,6 +147,9 @@ func NewConfigurationServiceAPI(spec *loads.Document) *ConfigurationServiceAPI {
 		ServicePostProjectProjectNameStageStageNameServiceHandler: service.PostProjectProjectNameStageStageNameServiceHandlerFunc(func(params service.PostProjectProjectNameStageStageNameServiceParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServicePostProjectProjectNameStageStageNameService has not yet been implemented")
 		}),
+		ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler: service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandlerFunc(func(params service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteParams) middleware.Responder {
+			return middleware.NotImplemented("operation ServicePostProjectProjectNameStageStageNameServiceServiceNamePromote has not yet been implemented")
+		}),
 		ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler: service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceHandlerFunc(func(params service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResource has not yet been implemented")
 		}),
@@ -215,6 +239,10 @@ type ConfigurationServiceAPI struct {
 	ProjectResourceGetProjectProjectNameResourceHandler project_resource.GetProjectProjectNameResourceHandler
 	// ProjectResourceGetProjectProjectNameResourceResourceURIHandler sets the operation handler for the get project project name resource resource URI operation
 	ProjectResourceGetProjectProjectNameResourceResourceURIHandler project_resource.GetProjectProjectNameResourceResourceURIHandler
+	// ProjectResourceGetProjectProjectNameResourceResourceURIDiffHandler sets the operation handler for the get project project name resource resource URI diff operation
+	ProjectResourceGetProjectProjectNameResourceResourceURIDiffHandler project_resource.GetProjectProjectNameResourceResourceURIDiffHandler
+	// ProjectResourceGetProjectProjectNameResourceResourceURIHistoryHandler sets the operation handler for the get project project name resource resource URI history operation
+	ProjectResourceGetProjectProjectNameResourceResourceURIHistoryHandler project_resource.GetProjectProjectNameResourceResourceURIHistoryHandler
 	// ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler sets the operation handler for the get project project name service service name resource operation
 	ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler service_default_resource.GetProjectProjectNameServiceServiceNameResourceHandler
 	// ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceResourceURIHandler sets the operation handler for the get project project name service service name resource resource URI operation
@@ -227,6 +255,10 @@ type ConfigurationServiceAPI struct {
 	StageResourceGetProjectProjectNameStageStageNameResourceHandler stage_resource.GetProjectProjectNameStageStageNameResourceHandler
 	// StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler sets the operation handler for the get project project name stage stage name resource resource URI operation
 	StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHandler
+	// StageResourceGetProjectProjectNameStageStageNameResourceResourceURIDiffHandler sets the operation handler for the get project project name stage stage name resource resource URI diff operation
+	StageResourceGetProjectProjectNameStageStageNameResourceResourceURIDiffHandler stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffHandler
+	// StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHistoryHandler sets the operation handler for the get project project name stage stage name resource resource URI history operation
+	StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHistoryHandler stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandler
 	// ServiceGetProjectProjectNameStageStageNameServiceHandler sets the operation handler for the get project project name stage stage name service operation
 	ServiceGetProjectProjectNameStageStageNameServiceHandler service.GetProjectProjectNameStageStageNameServiceHandler
 	// ServiceGetProjectProjectNameStageStageNameServiceServiceNameHandler sets the operation handler for the get project project name stage stage name service service name operation
@@ -235,6 +267,12 @@ type ConfigurationServiceAPI struct {
 	ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceHandler service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceHandler
 	// ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler sets the operation handler for the get project project name stage stage name service service name resource resource URI operation
 	ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler
+	// ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandler sets the operation handler for the get project project name stage stage name service service name resource resource URI diff operation
+	ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandler service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandler
+	// ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandler sets the operation handler for the get project project name stage stage name service service name resource resource URI history operation
+	ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandler service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandler
+	// ProjectGetProjectProjectNameSyncHandler sets the operation handler for the get project project name sync operation
+	ProjectGetProjectProjectNameSyncHandler project.GetProjectProjectNameSyncHandler
 	// ProjectPostProjectHandler sets the operation handler for the post project operation
 	ProjectPostProjectHandler project.PostProjectHandler
 	// ProjectResourcePostProjectProjectNameResourceHandler sets the operation handler for the post project project name resource operation
@@ -247,6 +285,8 @@ type ConfigurationServiceAPI struct {
 	StageResourcePostProjectProjectNameStageStageNameResourceHandler stage_resource.PostProjectProjectNameStageStageNameResourceHandler
 	// ServicePostProjectProjectNameStageStageNameServiceHandler sets the operation handler for the post project project name stage stage name service operation
 	ServicePostProjectProjectNameStageStageNameServiceHandler service.PostProjectProjectNameStageStageNameServiceHandler
+	// ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler sets the operation handler for the post project project name stage stage name service service name promote operation
+	ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler
 	// ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler sets the operation handler for the post project project name stage stage name service service name resource operation
 	ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceHandler
 	// ProjectPutProjectProjectNameHandler sets the operation handler for the put project project name operation
@@ -378,6 +418,14 @@ func (o *ConfigurationServiceAPI) Validate() error {
 		unregistered = append(unregistered, "project_resource.GetProjectProjectNameResourceResourceURIHandler")
 	}
 
+	if o.ProjectResourceGetProjectProjectNameResourceResourceURIDiffHandler == nil {
+		unregistered = append(unregistered, "project_resource.GetProjectProjectNameResourceResourceURIDiffHandler")
+	}
+
+	if o.ProjectResourceGetProjectProjectNameResourceResourceURIHistoryHandler == nil {
+		unregistered = append(unregistered, "project_resource.GetProjectProjectNameResourceResourceURIHistoryHandler")
+	}
+
 	if o.ServiceDefaultResourceGetProjectProjectNameServiceServiceNameResourceHandler == nil {
 		unregistered = append(unregistered, "service_default_resource.GetProjectProjectNameServiceServiceNameResourceHandler")
 	}
@@ -402,6 +450,14 @@ func (o *ConfigurationServiceAPI) Validate() error {
 		unregistered = append(unregistered, "stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHandler")
 	}
 
+	if o.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIDiffHandler == nil {
+		unregistered = append(unregistered, "stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIDiffHandler")
+	}
+
+	if o.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHistoryHandler == nil {
+		unregistered = append(unregistered, "stage_resource.GetProjectProjectNameStageStageNameResourceResourceURIHistoryHandler")
+	}
+
 	if o.ServiceGetProjectProjectNameStageStageNameServiceHandler == nil {
 		unregistered = append(unregistered, "service.GetProjectProjectNameStageStageNameServiceHandler")
 	}
@@ -418,6 +474,18 @@ func (o *ConfigurationServiceAPI) Validate() error {
 		unregistered = append(unregistered, "service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler")
 	}
 
+	if o.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandler == nil {
+		unregistered = append(unregistered, "service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandler")
+	}
+
+	if o.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandler == nil {
+		unregistered = append(unregistered, "service_resource.GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandler")
+	}
+
+	if o.ProjectGetProjectProjectNameSyncHandler == nil {
+		unregistered = append(unregistered, "project.GetProjectProjectNameSyncHandler")
+	}
+
 	if o.ProjectPostProjectHandler == nil {
 		unregistered = append(unregistered, "project.PostProjectHandler")
 	}
@@ -442,6 +510,10 @@ func (o *ConfigurationServiceAPI) Validate() error {
 		unregistered = append(unregistered, "service.PostProjectProjectNameStageStageNameServiceHandler")
 	}
 
+	if o.ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler == nil {
+		unregistered = append(unregistered, "service.PostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler")
+	}
+
 	if o.ServiceResourcePostProjectProjectNameStageStageNameServiceServiceNameResourceHandler == nil {
 		unregistered = append(unregistered, "service_resource.PostProjectProjectNameStageStageNameServiceServiceNameResourceHandler")
 	}
@@ -643,6 +715,16 @@ func (o *ConfigurationServiceAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/project/{projectName}/resource/{resourceURI}"] = project_resource.NewGetProjectProjectNameResourceResourceURI(o.context, o.ProjectResourceGetProjectProjectNameResourceResourceURIHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/resource/{resourceURI}/diff"] = project_resource.NewGetProjectProjectNameResourceResourceURIDiff(o.context, o.ProjectResourceGetProjectProjectNameResourceResourceURIDiffHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/resource/{resourceURI}/history"] = project_resource.NewGetProjectProjectNameResourceResourceURIHistory(o.context, o.ProjectResourceGetProjectProjectNameResourceResourceURIHistoryHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -673,6 +755,16 @@ func (o *ConfigurationServiceAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/resource/{resourceURI}"] = stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURI(o.context, o.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/resource/{resourceURI}/diff"] = stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIDiff(o.context, o.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIDiffHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/resource/{resourceURI}/history"] = stage_resource.NewGetProjectProjectNameStageStageNameResourceResourceURIHistory(o.context, o.StageResourceGetProjectProjectNameStageStageNameResourceResourceURIHistoryHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -693,6 +785,21 @@ func (o *ConfigurationServiceAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}"] = service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURI(o.context, o.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/diff"] = service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiff(o.context, o.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIDiffHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/history"] = service_resource.NewGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistory(o.context, o.ServiceResourceGetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIHistoryHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/project/{projectName}/sync"] = project.NewGetProjectProjectNameSync(o.context, o.ProjectGetProjectProjectNameSyncHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -723,6 +830,11 @@ func (o *ConfigurationServiceAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/project/{projectName}/stage/{stageName}/service"] = service.NewPostProjectProjectNameStageStageNameService(o.context, o.ServicePostProjectProjectNameStageStageNameServiceHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/project/{projectName}/stage/{stageName}/service/{serviceName}/promote"] = service.NewPostProjectProjectNameStageStageNameServiceServiceNamePromote(o.context, o.ServicePostProjectProjectNameStageStageNameServiceServiceNamePromoteHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -57,6 +57,14 @@ type GetProjectProjectNameResourceParams struct {
 	  In: path
 	*/
 	ProjectName string
+	/*Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /
+	  In: query
+	*/
+	ResourceURIGlob *string
+	/*Only resources whose URI starts with the prefix (e.g., helm/) are returned
+	  In: query
+	*/
+	ResourceURIPrefix *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -85,6 +93,16 @@ func (o *GetProjectProjectNameResourceParams) BindRequest(r *http.Request, route
 		res = append(res, err)
 	}
 
+	qResourceURIGlob, qhkResourceURIGlob, _ := qs.GetOK("resourceURIGlob")
+	if err := o.bindResourceURIGlob(qResourceURIGlob, qhkResourceURIGlob, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qResourceURIPrefix, qhkResourceURIPrefix, _ := qs.GetOK("resourceURIPrefix")
+	if err := o.bindResourceURIPrefix(qResourceURIPrefix, qhkResourceURIPrefix, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -164,3 +182,39 @@ func (o *GetProjectProjectNameResourceParams) bindProjectName(rawData []string,
 
 	return nil
 }
+
+// bindResourceURIGlob binds and validates parameter ResourceURIGlob from query.
+func (o *GetProjectProjectNameResourceParams) bindResourceURIGlob(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ResourceURIGlob = &raw
+
+	return nil
+}
+
+// bindResourceURIPrefix binds and validates parameter ResourceURIPrefix from query.
+func (o *GetProjectProjectNameResourceParams) bindResourceURIPrefix(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ResourceURIPrefix = &raw
+
+	return nil
+}
@@ -17,6 +17,8 @@ type GetProjectProjectNameResourceResourceURIURL struct {
 	ProjectName string
 	ResourceURI string
 
+	Version *string
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -63,6 +65,18 @@ func (o *GetProjectProjectNameResourceResourceURIURL) Build() (*url.URL, error)
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var version string
+	if o.Version != nil {
+		version = *o.Version
+	}
+	if version != "" {
+		qs.Set("version", version)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
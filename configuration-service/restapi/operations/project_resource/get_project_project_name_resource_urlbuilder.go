@@ -18,8 +18,10 @@ import (
 type GetProjectProjectNameResourceURL struct {
 	ProjectName string
 
-	NextPageKey *string
-	PageSize    *int64
+	NextPageKey       *string
+	PageSize          *int64
+	ResourceURIGlob   *string
+	ResourceURIPrefix *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -78,6 +80,22 @@ func (o *GetProjectProjectNameResourceURL) Build() (*url.URL, error) {
 		qs.Set("pageSize", pageSize)
 	}
 
+	var resourceURIGlob string
+	if o.ResourceURIGlob != nil {
+		resourceURIGlob = *o.ResourceURIGlob
+	}
+	if resourceURIGlob != "" {
+		qs.Set("resourceURIGlob", resourceURIGlob)
+	}
+
+	var resourceURIPrefix string
+	if o.ResourceURIPrefix != nil {
+		resourceURIPrefix = *o.ResourceURIPrefix
+	}
+	if resourceURIPrefix != "" {
+		qs.Set("resourceURIPrefix", resourceURIPrefix)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
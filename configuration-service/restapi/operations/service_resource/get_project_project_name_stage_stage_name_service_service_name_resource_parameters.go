@@ -57,6 +57,14 @@ type GetProjectProjectNameStageStageNameServiceServiceNameResourceParams struct
 	  In: path
 	*/
 	ProjectName string
+	/*Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /
+	  In: query
+	*/
+	ResourceURIGlob *string
+	/*Only resources whose URI starts with the prefix (e.g., helm/) are returned
+	  In: query
+	*/
+	ResourceURIPrefix *string
 	/*Name of the service
 	  Required: true
 	  In: path
@@ -95,6 +103,16 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) Bi
 		res = append(res, err)
 	}
 
+	qResourceURIGlob, qhkResourceURIGlob, _ := qs.GetOK("resourceURIGlob")
+	if err := o.bindResourceURIGlob(qResourceURIGlob, qhkResourceURIGlob, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qResourceURIPrefix, qhkResourceURIPrefix, _ := qs.GetOK("resourceURIPrefix")
+	if err := o.bindResourceURIPrefix(qResourceURIPrefix, qhkResourceURIPrefix, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rServiceName, rhkServiceName, _ := route.Params.GetOK("serviceName")
 	if err := o.bindServiceName(rServiceName, rhkServiceName, route.Formats); err != nil {
 		res = append(res, err)
@@ -185,6 +203,42 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bi
 	return nil
 }
 
+// bindResourceURIGlob binds and validates parameter ResourceURIGlob from query.
+func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bindResourceURIGlob(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ResourceURIGlob = &raw
+
+	return nil
+}
+
+// bindResourceURIPrefix binds and validates parameter ResourceURIPrefix from query.
+func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bindResourceURIPrefix(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ResourceURIPrefix = &raw
+
+	return nil
+}
+
 // bindServiceName binds and validates parameter ServiceName from path.
 func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceParams) bindServiceName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
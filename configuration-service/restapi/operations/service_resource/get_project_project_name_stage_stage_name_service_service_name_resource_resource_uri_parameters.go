@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 
 	strfmt "github.com/go-openapi/strfmt"
@@ -50,6 +51,10 @@ type GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIPar
 	  In: path
 	*/
 	StageName string
+	/*Version (commit hash) of the resource. The latest version is returned if it is not set
+	  In: query
+	*/
+	Version *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -61,6 +66,8 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceUR
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	rProjectName, rhkProjectName, _ := route.Params.GetOK("projectName")
 	if err := o.bindProjectName(rProjectName, rhkProjectName, route.Formats); err != nil {
 		res = append(res, err)
@@ -81,6 +88,11 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceUR
 		res = append(res, err)
 	}
 
+	qVersion, qhkVersion, _ := qs.GetOK("version")
+	if err := o.bindVersion(qVersion, qhkVersion, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -146,3 +158,21 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceUR
 
 	return nil
 }
+
+// bindVersion binds and validates parameter Version from query.
+func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIParams) bindVersion(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Version = &raw
+
+	return nil
+}
@@ -19,6 +19,8 @@ type GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceURIURL
 	ServiceName string
 	StageName   string
 
+	Version *string
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -79,6 +81,18 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceResourceUR
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var version string
+	if o.Version != nil {
+		version = *o.Version
+	}
+	if version != "" {
+		qs.Set("version", version)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -20,8 +20,10 @@ type GetProjectProjectNameStageStageNameServiceServiceNameResourceURL struct {
 	ServiceName string
 	StageName   string
 
-	NextPageKey *string
-	PageSize    *int64
+	NextPageKey       *string
+	PageSize          *int64
+	ResourceURIGlob   *string
+	ResourceURIPrefix *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -94,6 +96,22 @@ func (o *GetProjectProjectNameStageStageNameServiceServiceNameResourceURL) Build
 		qs.Set("pageSize", pageSize)
 	}
 
+	var resourceURIGlob string
+	if o.ResourceURIGlob != nil {
+		resourceURIGlob = *o.ResourceURIGlob
+	}
+	if resourceURIGlob != "" {
+		qs.Set("resourceURIGlob", resourceURIGlob)
+	}
+
+	var resourceURIPrefix string
+	if o.ResourceURIPrefix != nil {
+		resourceURIPrefix = *o.ResourceURIPrefix
+	}
+	if resourceURIPrefix != "" {
+		qs.Set("resourceURIPrefix", resourceURIPrefix)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
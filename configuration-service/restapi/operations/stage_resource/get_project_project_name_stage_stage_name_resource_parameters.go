@@ -57,6 +57,14 @@ type GetProjectProjectNameStageStageNameResourceParams struct {
 	  In: path
 	*/
 	ProjectName string
+	/*Only resources whose URI matches the glob pattern (e.g., helm/carts/*.yaml) are returned. A * does not match a /
+	  In: query
+	*/
+	ResourceURIGlob *string
+	/*Only resources whose URI starts with the prefix (e.g., helm/) are returned
+	  In: query
+	*/
+	ResourceURIPrefix *string
 	/*Name of the stage
 	  Required: true
 	  In: path
@@ -90,6 +98,16 @@ func (o *GetProjectProjectNameStageStageNameResourceParams) BindRequest(r *http.
 		res = append(res, err)
 	}
 
+	qResourceURIGlob, qhkResourceURIGlob, _ := qs.GetOK("resourceURIGlob")
+	if err := o.bindResourceURIGlob(qResourceURIGlob, qhkResourceURIGlob, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qResourceURIPrefix, qhkResourceURIPrefix, _ := qs.GetOK("resourceURIPrefix")
+	if err := o.bindResourceURIPrefix(qResourceURIPrefix, qhkResourceURIPrefix, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rStageName, rhkStageName, _ := route.Params.GetOK("stageName")
 	if err := o.bindStageName(rStageName, rhkStageName, route.Formats); err != nil {
 		res = append(res, err)
@@ -175,6 +193,42 @@ func (o *GetProjectProjectNameStageStageNameResourceParams) bindProjectName(rawD
 	return nil
 }
 
+// bindResourceURIGlob binds and validates parameter ResourceURIGlob from query.
+func (o *GetProjectProjectNameStageStageNameResourceParams) bindResourceURIGlob(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ResourceURIGlob = &raw
+
+	return nil
+}
+
+// bindResourceURIPrefix binds and validates parameter ResourceURIPrefix from query.
+func (o *GetProjectProjectNameStageStageNameResourceParams) bindResourceURIPrefix(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ResourceURIPrefix = &raw
+
+	return nil
+}
+
 // bindStageName binds and validates parameter StageName from path.
 func (o *GetProjectProjectNameStageStageNameResourceParams) bindStageName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -18,6 +18,8 @@ type GetProjectProjectNameStageStageNameResourceResourceURIURL struct {
 	ResourceURI string
 	StageName   string
 
+	Version *string
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -71,6 +73,18 @@ func (o *GetProjectProjectNameStageStageNameResourceResourceURIURL) Build() (*ur
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var version string
+	if o.Version != nil {
+		version = *o.Version
+	}
+	if version != "" {
+		qs.Set("version", version)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
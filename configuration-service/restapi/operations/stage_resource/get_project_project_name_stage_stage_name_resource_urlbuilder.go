@@ -19,8 +19,10 @@ type GetProjectProjectNameStageStageNameResourceURL struct {
 	ProjectName string
 	StageName   string
 
-	NextPageKey *string
-	PageSize    *int64
+	NextPageKey       *string
+	PageSize          *int64
+	ResourceURIGlob   *string
+	ResourceURIPrefix *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -86,6 +88,22 @@ func (o *GetProjectProjectNameStageStageNameResourceURL) Build() (*url.URL, erro
 		qs.Set("pageSize", pageSize)
 	}
 
+	var resourceURIGlob string
+	if o.ResourceURIGlob != nil {
+		resourceURIGlob = *o.ResourceURIGlob
+	}
+	if resourceURIGlob != "" {
+		qs.Set("resourceURIGlob", resourceURIGlob)
+	}
+
+	var resourceURIPrefix string
+	if o.ResourceURIPrefix != nil {
+		resourceURIPrefix = *o.ResourceURIPrefix
+	}
+	if resourceURIPrefix != "" {
+		qs.Set("resourceURIPrefix", resourceURIPrefix)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
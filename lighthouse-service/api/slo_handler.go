@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	"github.com/keptn/keptn/lighthouse-service/event_handler"
+	"github.com/keptn/keptn/lighthouse-service/pkg/report"
+	"github.com/keptn/keptn/lighthouse-service/pkg/slo"
+)
+
+const validateSLOPath = "/v1/slo/validate"
+const evaluateSLOPath = "/v1/slo/evaluate"
+const evaluationReportPath = "/v1/evaluation/report"
+
+// ValidationResult is returned by the SLO validation endpoint
+type ValidationResult struct {
+	Valid  bool                   `json:"valid"`
+	Errors []*slo.ValidationError `json:"errors"`
+}
+
+// DryRunRequest contains a SLO file, the SLI values and the previous evaluation results used for a dry-run evaluation
+type DryRunRequest struct {
+	keptnevents.InternalGetSLIDoneEventData
+	// SLO is the content of the slo.yaml file
+	SLO                 string                                 `json:"slo"`
+	PreviousEvaluations []*keptnevents.EvaluationDoneEventData `json:"previousEvaluations"`
+	// BaselineEvaluations contains the evaluations of the baselines pinned in the SLO file, keyed by baseline,
+	// e.g. keptn_context=<context> or labels=<key>=<value>,<key>=<value> (sorted by key)
+	BaselineEvaluations map[string][]*keptnevents.EvaluationDoneEventData `json:"baselineEvaluations,omitempty"`
+}
+
+type errorResponse struct {
+	Message string                 `json:"message"`
+	Errors  []*slo.ValidationError `json:"errors,omitempty"`
+}
+
+// Register adds the handlers of the lighthouse-service API to the given mux
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc(validateSLOPath, ValidateSLOHandler)
+	mux.HandleFunc(evaluateSLOPath, EvaluateSLOHandler)
+	mux.HandleFunc(evaluationReportPath, EvaluationReportHandler)
+}
+
+// ValidateSLOHandler validates the slo.yaml file contained in the request body
+func ValidateSLOHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, &errorResponse{Message: "method not allowed"})
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{Message: "could not read request body: " + err.Error()})
+		return
+	}
+
+	_, validationErrors := slo.ParseAndValidate(body)
+	writeJSON(w, http.StatusOK, &ValidationResult{
+		Valid:  len(validationErrors) == 0,
+		Errors: validationErrors,
+	})
+}
+
+// EvaluateSLOHandler evaluates the SLI values of a DryRunRequest without sending an evaluation-done event
+func EvaluateSLOHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, &errorResponse{Message: "method not allowed"})
+		return
+	}
+	request := &DryRunRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{Message: "could not parse request: " + err.Error()})
+		return
+	}
+
+	sloConfig, err := slo.Parse([]byte(request.SLO))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{Message: "could not parse SLO file: " + err.Error()})
+		return
+	}
+	if validationErrors := slo.Validate(sloConfig); len(validationErrors) > 0 {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{Message: "invalid SLO file", Errors: validationErrors})
+		return
+	}
+
+	evaluationResult, err := event_handler.EvaluateSLIs(&request.InternalGetSLIDoneEventData, sloConfig, request.PreviousEvaluations, request.BaselineEvaluations)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{Message: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, evaluationResult)
+}
+
+// EvaluationReportHandler renders the evaluation-done event in the request body as report. The format is selected with
+// the query parameter format (markdown, html or junit) and defaults to markdown
+func EvaluationReportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, &errorResponse{Message: "method not allowed"})
+		return
+	}
+	format := report.Markdown
+	if formatParam := r.URL.Query().Get("format"); formatParam != "" {
+		var err error
+		if format, err = report.ParseFormat(formatParam); err != nil {
+			writeJSON(w, http.StatusBadRequest, &errorResponse{Message: err.Error()})
+			return
+		}
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{Message: "could not read request body: " + err.Error()})
+		return
+	}
+	evaluation, err := report.ParseEvaluationDoneEvent(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{Message: err.Error()})
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := report.Render(buf, evaluation, format); err != nil {
+		writeJSON(w, http.StatusBadRequest, &errorResponse{Message: "could not render report: " + err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSLOFile = `---
+spec_version: '1.0'
+comparison:
+  compare_with: "single_result"
+  include_result_with_score: "all"
+  aggregate_function: avg
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+total_score:
+  pass: "90%"
+  warning: "75%"`
+
+func TestValidateSLOHandler(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Body           string
+		ExpectedResult *ValidationResult
+	}{
+		{
+			Name:           "valid SLO file",
+			Body:           testSLOFile,
+			ExpectedResult: &ValidationResult{Valid: true},
+		},
+		{
+			Name: "invalid SLO file",
+			Body: `---
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<=+10%%"`,
+			ExpectedResult: &ValidationResult{Valid: false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ValidateSLOHandler(recorder, httptest.NewRequest(http.MethodPost, validateSLOPath, bytes.NewBufferString(test.Body)))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			result := &ValidationResult{}
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), result))
+			assert.Equal(t, test.ExpectedResult.Valid, result.Valid)
+			if !test.ExpectedResult.Valid {
+				assert.NotEmpty(t, result.Errors)
+			}
+		})
+	}
+}
+
+func TestEvaluateSLOHandler(t *testing.T) {
+	previousEvaluation := &keptnevents.EvaluationDoneEventData{
+		EvaluationDetails: &keptnevents.EvaluationDetails{
+			IndicatorResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score:  1,
+					Value:  &keptnevents.SLIResult{Metric: "response_time_p95", Value: 400, Success: true},
+					Status: "pass",
+				},
+			},
+		},
+		Result: "pass",
+	}
+	tests := []struct {
+		Name           string
+		Request        *DryRunRequest
+		ExpectedStatus int
+		ExpectedResult string
+	}{
+		{
+			Name: "value within the comparison range passes",
+			Request: &DryRunRequest{
+				InternalGetSLIDoneEventData: keptnevents.InternalGetSLIDoneEventData{
+					Project:         "sockshop",
+					Stage:           "staging",
+					Service:         "carts",
+					IndicatorValues: []*keptnevents.SLIResult{{Metric: "response_time_p95", Value: 420, Success: true}},
+				},
+				SLO:                 testSLOFile,
+				PreviousEvaluations: []*keptnevents.EvaluationDoneEventData{previousEvaluation},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedResult: "pass",
+		},
+		{
+			Name: "value outside of the comparison range fails",
+			Request: &DryRunRequest{
+				InternalGetSLIDoneEventData: keptnevents.InternalGetSLIDoneEventData{
+					IndicatorValues: []*keptnevents.SLIResult{{Metric: "response_time_p95", Value: 500, Success: true}},
+				},
+				SLO:                 testSLOFile,
+				PreviousEvaluations: []*keptnevents.EvaluationDoneEventData{previousEvaluation},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedResult: "fail",
+		},
+		{
+			Name: "invalid SLO file is rejected",
+			Request: &DryRunRequest{
+				SLO: "objectives: [{sli: response_time_p95, pass: [{criteria: [\"~10\"]}]}]",
+			},
+			ExpectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			body, _ := json.Marshal(test.Request)
+			recorder := httptest.NewRecorder()
+			EvaluateSLOHandler(recorder, httptest.NewRequest(http.MethodPost, evaluateSLOPath, bytes.NewBuffer(body)))
+
+			assert.Equal(t, test.ExpectedStatus, recorder.Code)
+			if test.ExpectedStatus == http.StatusOK {
+				result := &keptnevents.EvaluationDoneEventData{}
+				assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), result))
+				assert.Equal(t, test.ExpectedResult, result.Result)
+			}
+		})
+	}
+}
+
+func TestEvaluationReportHandler(t *testing.T) {
+	event := `{"type": "sh.keptn.events.evaluation-done", "data": {"project": "sockshop", "stage": "staging", "service": "carts", "result": "fail",
+"evaluationdetails": {"score": 0, "indicatorResults": [{"score": 0, "status": "fail", "value": {"metric": "response_time_p95", "value": 640, "success": true},
+"targets": [{"criteria": "<600", "targetValue": 600, "violated": true}]}]}}}`
+	tests := []struct {
+		Name                string
+		Format              string
+		Body                string
+		ExpectedStatus      int
+		ExpectedContentType string
+		ExpectedContent     string
+	}{
+		{
+			Name:                "markdown is the default format",
+			Body:                event,
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: "text/markdown; charset=utf-8",
+			ExpectedContent:     "| response_time_p95 | 640 | fail | 0 | ~~<600~~ |",
+		},
+		{
+			Name:                "junit report",
+			Format:              "junit",
+			Body:                event,
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: "application/xml; charset=utf-8",
+			ExpectedContent:     `<failure message="response_time_p95 = 640 violates &lt;600" type="SLIViolation">`,
+		},
+		{
+			Name:           "unknown format is rejected",
+			Format:         "pdf",
+			Body:           event,
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		{
+			Name:           "other event types are rejected",
+			Format:         "html",
+			Body:           `{"type": "sh.keptn.events.tests-finished", "data": {}}`,
+			ExpectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			EvaluationReportHandler(recorder, httptest.NewRequest(http.MethodPost, evaluationReportPath+"?format="+test.Format, bytes.NewBufferString(test.Body)))
+
+			assert.Equal(t, test.ExpectedStatus, recorder.Code)
+			if test.ExpectedStatus == http.StatusOK {
+				assert.Equal(t, test.ExpectedContentType, recorder.Header().Get("Content-Type"))
+				assert.Contains(t, recorder.Body.String(), test.ExpectedContent)
+			}
+		})
+	}
+}
@@ -6,13 +6,13 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 
 	"github.com/cloudevents/sdk-go/pkg/cloudevents"
 	"github.com/cloudevents/sdk-go/pkg/cloudevents/client"
 	cloudeventshttp "github.com/cloudevents/sdk-go/pkg/cloudevents/transport/http"
-	"github.com/ghodss/yaml"
 	"github.com/keptn/go-utils/pkg/configuration-service/utils"
-	keptnmodelsv2 "github.com/keptn/go-utils/pkg/models/v2"
+	"github.com/keptn/keptn/lighthouse-service/pkg/slo"
 )
 
 const configservice = "CONFIGURATION_SERVICE"
@@ -69,56 +69,42 @@ func getServiceEndpoint(service string) (url.URL, error) {
 	return *url, nil
 }
 
-func getSLOs(project string, stage string, service string) (*keptnmodelsv2.ServiceLevelObjectives, error) {
+// errSLOFileNotFound is returned by getSLOs if the service does not provide a slo.yaml file
+var errSLOFileNotFound = errors.New("no SLO file found")
+
+// errInvalidSLOFile is returned by getSLOs if the slo.yaml file of the service cannot be parsed or is invalid
+var errInvalidSLOFile = errors.New("Could not parse SLO file")
+
+func getSLOs(project string, stage string, service string) (*slo.ServiceLevelObjectives, error) {
 	resourceHandler := utils.NewResourceHandler("configuration-service:8080")
 	sloFile, err := resourceHandler.GetServiceResource(project, stage, service, "slo.yaml")
 	if err != nil {
-		return nil, errors.New("No SLO file found for service " + service + " in stage " + stage + " in project " + project)
+		return nil, fmt.Errorf("%w for service %s in stage %s in project %s", errSLOFileNotFound, service, stage, project)
 	}
 
-	slo, err := parseSLO([]byte(sloFile.ResourceContent))
+	sloConfig, err := parseSLO([]byte(sloFile.ResourceContent))
 
 	if err != nil {
-		return nil, errors.New("Could not parse SLO file for service " + service + " in stage " + stage + " in project " + project)
+		return nil, fmt.Errorf("%w for service %s in stage %s in project %s: %s", errInvalidSLOFile, service, stage, project, err.Error())
 	}
 
-	return slo, nil
+	return sloConfig, nil
 }
 
-func parseSLO(input []byte) (*keptnmodelsv2.ServiceLevelObjectives, error) {
-	slo := &keptnmodelsv2.ServiceLevelObjectives{}
-
-	err := yaml.Unmarshal([]byte(input), &slo)
-
+// parseSLO parses and validates the content of a slo.yaml file
+func parseSLO(input []byte) (*slo.ServiceLevelObjectives, error) {
+	sloConfig, err := slo.Parse(input)
 	if err != nil {
 		return nil, err
 	}
 
-	if slo.Comparison == nil {
-		slo.Comparison = &keptnmodelsv2.SLOComparison{
-			CompareWith:               "single_result",
-			IncludeResultWithScore:    "all",
-			NumberOfComparisonResults: 1,
-			AggregateFunction:         "avg",
-		}
-	}
-
-	if slo.Comparison != nil {
-		if slo.Comparison.IncludeResultWithScore == "" {
-			slo.Comparison.IncludeResultWithScore = "all"
-		}
-		if slo.Comparison.NumberOfComparisonResults == 0 {
-			slo.Comparison.NumberOfComparisonResults = 3
-		}
-		if slo.Comparison.AggregateFunction == "" {
-			slo.Comparison.AggregateFunction = "avg"
-		}
-	}
-	for _, objective := range slo.Objectives {
-		if objective.Weight == 0 {
-			objective.Weight = 1
+	if validationErrors := slo.Validate(sloConfig); len(validationErrors) > 0 {
+		var messages []string
+		for _, validationError := range validationErrors {
+			messages = append(messages, validationError.Error())
 		}
+		return nil, errors.New(strings.Join(messages, "; "))
 	}
 
-	return slo, nil
+	return sloConfig, nil
 }
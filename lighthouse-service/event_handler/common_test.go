@@ -1,7 +1,8 @@
 package event_handler
 
 import (
-	keptnmodelsv2 "github.com/keptn/go-utils/pkg/models/v2"
+	"errors"
+	"github.com/keptn/keptn/lighthouse-service/pkg/slo"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -9,7 +10,7 @@ import (
 type getSLOTestObject struct {
 	Name           string
 	SLOFileContent string
-	ExpectedSLO    *keptnmodelsv2.ServiceLevelObjectives
+	ExpectedSLO    *slo.ServiceLevelObjectives
 	ExpectedError  error
 }
 
@@ -57,21 +58,21 @@ objectives:
 total_score:
   pass: "90%"
   warning: 75%`,
-			ExpectedSLO: &keptnmodelsv2.ServiceLevelObjectives{
+			ExpectedSLO: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter: map[string]string{
 					"id": "<prometheus_scrape_job_id>",
 				},
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "single_result",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 3,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI: "responseTime95",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=+10%"},
 							},
@@ -79,7 +80,7 @@ total_score:
 								Criteria: []string{"<200"},
 							},
 						},
-						Warning: []*keptnmodelsv2.SLOCriteria{
+						Warning: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<+15%", ">-8%", "<500"},
 							},
@@ -89,7 +90,7 @@ total_score:
 					},
 					{
 						SLI: "security_vulnerabilities",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"=0"},
 							},
@@ -99,7 +100,7 @@ total_score:
 					},
 					{
 						SLI: "sql_statements",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"=0%"},
 							},
@@ -107,7 +108,7 @@ total_score:
 								Criteria: []string{"<100"},
 							},
 						},
-						Warning: []*keptnmodelsv2.SLOCriteria{
+						Warning: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<+5%", ">-5%"},
 							},
@@ -116,13 +117,88 @@ total_score:
 						KeySLI: true,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
 			},
 			ExpectedError: nil,
 		},
+		{
+			Name: "SLO file with unknown aggregate function",
+			SLOFileContent: `---
+spec_version: '1.0'
+comparison:
+  compare_with: "single_result"
+  aggregate_function: avrg
+objectives:
+  - sli: responseTime95
+    pass:
+      - criteria:
+          - "<=+10%"
+total_score:
+  pass: "90%"`,
+			ExpectedSLO:   nil,
+			ExpectedError: errors.New("comparison.aggregate_function: unknown aggregate function avrg"),
+		},
+		{
+			Name: "SLO file with unknown comparison mode",
+			SLOFileContent: `---
+spec_version: '1.0'
+comparison:
+  compare_with: "single_result"
+  mode: mann-whitney
+objectives:
+  - sli: responseTime95
+    pass:
+      - criteria:
+          - "<=+10%"
+total_score:
+  pass: "90%"`,
+			ExpectedSLO:   nil,
+			ExpectedError: errors.New("comparison.mode: unknown comparison mode mann-whitney"),
+		},
+		{
+			Name: "SLO file with zscore comparison",
+			SLOFileContent: `---
+spec_version: '1.0'
+comparison:
+  compare_with: "several_results"
+  number_of_comparison_results: 10
+  mode: zscore
+objectives:
+  - sli: responseTime95
+    pass:
+      - criteria:
+          - "<=+2"
+total_score:
+  pass: "90%"`,
+			ExpectedSLO: &slo.ServiceLevelObjectives{
+				SpecVersion: "1.0",
+				Comparison: &slo.SLOComparison{
+					CompareWith:               "several_results",
+					IncludeResultWithScore:    "all",
+					NumberOfComparisonResults: 10,
+					AggregateFunction:         "avg",
+					Mode:                      "zscore",
+				},
+				Objectives: []*slo.SLO{
+					{
+						SLI: "responseTime95",
+						Pass: []*slo.SLOCriteria{
+							{
+								Criteria: []string{"<=+2"},
+							},
+						},
+						Weight: 1,
+					},
+				},
+				TotalScore: &slo.SLOScore{
+					Pass: "90%",
+				},
+			},
+			ExpectedError: nil,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
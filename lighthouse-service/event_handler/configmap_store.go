@@ -0,0 +1,69 @@
+package event_handler
+
+import (
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// configMapClient is the subset of the ConfigMap API of client-go used to persist state of the lighthouse-service
+type configMapClient interface {
+	Get(name string, options metav1.GetOptions) (*v1.ConfigMap, error)
+	Create(configMap *v1.ConfigMap) (*v1.ConfigMap, error)
+	Update(configMap *v1.ConfigMap) (*v1.ConfigMap, error)
+}
+
+// configMapStore persists JSON serialized entries in a ConfigMap in the keptn namespace
+type configMapStore struct {
+	configMaps configMapClient
+	name       string
+}
+
+// load reads the entries of the ConfigMap and unmarshals them with newEntry. Entries that cannot be unmarshalled are
+// skipped. If the ConfigMap does not exist yet, no entries and a nil ConfigMap are returned
+func (s *configMapStore) load(newEntry func() interface{}) (map[string]interface{}, *v1.ConfigMap, error) {
+	entries := map[string]interface{}{}
+	configMap, err := s.configMaps.Get(s.name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// the ConfigMap is created with the first entry
+		return entries, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	for key, serializedEntry := range configMap.Data {
+		entry := newEntry()
+		if err := json.Unmarshal([]byte(serializedEntry), entry); err != nil {
+			continue
+		}
+		entries[key] = entry
+	}
+	return entries, configMap, nil
+}
+
+// save replaces the entries of the ConfigMap loaded before, or creates the ConfigMap if configMap is nil
+func (s *configMapStore) save(entries map[string]interface{}, configMap *v1.ConfigMap) error {
+	data := map[string]string{}
+	for key, entry := range entries {
+		serializedEntry, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		data[key] = string(serializedEntry)
+	}
+
+	if configMap == nil {
+		_, err := s.configMaps.Create(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.name,
+				Namespace: "keptn",
+			},
+			Data: data,
+		})
+		return err
+	}
+	configMap.Data = data
+	_, err := s.configMaps.Update(configMap)
+	return err
+}
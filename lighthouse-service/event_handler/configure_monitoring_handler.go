@@ -25,23 +25,29 @@ func (eh *ConfigureMonitoringHandler) HandleEvent() error {
 		return err
 	}
 
-	configMap := eh.getSLISourceConfigMap(e)
-
 	kubeAPI, err := keptnutils.GetKubeAPI(true)
 
 	if err != nil {
 		eh.Logger.Error("Could not create Kube API")
 		return err
 	}
-	_, err = kubeAPI.ConfigMaps("keptn").Create(configMap)
 
+	// SLI providers configured per stage or service are kept, only the default SLI provider of the project is replaced
+	existingConfigMap, err := kubeAPI.ConfigMaps("keptn").Get("lighthouse-config-"+e.Project, metav1.GetOptions{})
 	if err != nil {
-		_, err = kubeAPI.ConfigMaps("keptn").Update(configMap)
-		if err != nil {
-			return err
-		}
+		_, err = kubeAPI.ConfigMaps("keptn").Create(eh.getSLISourceConfigMap(e))
+		return err
+	}
+	_, err = kubeAPI.ConfigMaps("keptn").Update(eh.mergeSLISourceConfigMap(existingConfigMap, e))
+	return err
+}
+
+func (eh *ConfigureMonitoringHandler) mergeSLISourceConfigMap(configMap *v1.ConfigMap, e *keptnevents.ConfigureMonitoringEventData) *v1.ConfigMap {
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
 	}
-	return nil
+	configMap.Data[sliProviderKey] = e.Type
+	return configMap
 }
 
 func (eh *ConfigureMonitoringHandler) getSLISourceConfigMap(e *keptnevents.ConfigureMonitoringEventData) *v1.ConfigMap {
@@ -51,7 +57,7 @@ func (eh *ConfigureMonitoringHandler) getSLISourceConfigMap(e *keptnevents.Confi
 			Namespace: "keptn",
 		},
 		Data: map[string]string{
-			"sli-provider": e.Type,
+			sliProviderKey: e.Type,
 		},
 	}
 	return configMap
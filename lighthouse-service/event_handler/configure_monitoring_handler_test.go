@@ -83,3 +83,32 @@ func TestConfigureMonitoringHandler_getSLISourceConfigMap(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureMonitoringHandler_mergeSLISourceConfigMap(t *testing.T) {
+	eh := &ConfigureMonitoringHandler{}
+	existing := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lighthouse-config-sockshop",
+			Namespace: "keptn",
+		},
+		Data: map[string]string{
+			"sli-provider":            "prometheus",
+			"sli-provider.production": "dynatrace",
+		},
+	}
+	want := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lighthouse-config-sockshop",
+			Namespace: "keptn",
+		},
+		Data: map[string]string{
+			"sli-provider":            "wavefront",
+			"sli-provider.production": "dynatrace",
+		},
+	}
+
+	got := eh.mergeSLISourceConfigMap(existing, &keptnevents.ConfigureMonitoringEventData{Type: "wavefront", Project: "sockshop"})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeSLISourceConfigMap() = %v, want %v", got, want)
+	}
+}
@@ -6,22 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"math"
 	"net/http"
 	"net/url"
-	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cloudevents/sdk-go/pkg/cloudevents"
-	"github.com/cloudevents/sdk-go/pkg/cloudevents/types"
 	"github.com/ghodss/yaml"
-	"github.com/google/uuid"
 	keptnevents "github.com/keptn/go-utils/pkg/events"
-	keptnmodelsv2 "github.com/keptn/go-utils/pkg/models/v2"
 	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/keptn/lighthouse-service/pkg/slo"
 )
 
 type datastoreResult struct {
@@ -29,22 +24,17 @@ type datastoreResult struct {
 	TotalCount  int    `json:"totalCount"`
 	PageSize    int    `json:"pageSize"`
 	Events      []struct {
-		Data interface{} `json:"data"`
+		Shkeptncontext string      `json:"shkeptncontext"`
+		Data           interface{} `json:"data"`
 	}
 }
 
-type criteriaObject struct {
-	Operator        string
-	Value           float64
-	CheckPercentage bool
-	IsComparison    bool
-	CheckIncrease   bool
-}
-
 type EvaluateSLIHandler struct {
-	Logger     *keptnutils.Logger
-	Event      cloudevents.Event
-	HTTPClient *http.Client
+	Logger             *keptnutils.Logger
+	Event              cloudevents.Event
+	HTTPClient         *http.Client
+	EvaluationCache    EvaluationCache
+	PendingEvaluations PendingEvaluationStore
 }
 
 func (eh *EvaluateSLIHandler) HandleEvent() error {
@@ -57,50 +47,89 @@ func (eh *EvaluateSLIHandler) HandleEvent() error {
 		return err
 	}
 
+	var shkeptncontext string
+	eh.Event.Context.ExtensionAs("shkeptncontext", &shkeptncontext)
+
+	// the get-sli.done event may be delivered more than once, but it must only result in one evaluation-done event.
+	// Re-delivered events of evaluations that have been sent are ignored here, concurrently processed ones are ignored
+	// when the evaluation-done event is sent
+	if eh.EvaluationCache != nil {
+		cachedEvaluation, err := eh.EvaluationCache.Get(shkeptncontext, e.Stage, e.Service)
+		if err != nil {
+			eh.Logger.Error("Could not read evaluation cache: " + err.Error())
+		} else if cachedEvaluation != nil {
+			eh.Logger.Info(fmt.Sprintf("Evaluation of service %s in stage %s has already been sent in event %s, ignoring re-delivered event",
+				e.Service, e.Stage, cachedEvaluation.EventID))
+			return nil
+		}
+	}
+
+	// if the SLIs are retrieved by several SLI providers, the evaluation waits for the values of all of them. The
+	// pending evaluation is kept until the evaluation-done event has been sent, hence the merged values are available
+	// to a re-delivered event and the evaluation times out if it fails
+	var pendingEvaluation *PendingEvaluation
+	if eh.PendingEvaluations != nil {
+		var complete bool
+		pendingEvaluation, complete, err = eh.PendingEvaluations.AddResult(shkeptncontext, e.Stage, e.Service, getSLIProvider(eh.Event), e.IndicatorValues)
+		if err != nil {
+			eh.Logger.Error("Could not update pending evaluation: " + err.Error())
+			return err
+		}
+		if pendingEvaluation != nil && !complete {
+			eh.Logger.Debug(fmt.Sprintf("Received SLI values of %d of %d SLI providers, waiting for the remaining SLI providers",
+				len(pendingEvaluation.Received), len(pendingEvaluation.Providers)))
+			return nil
+		} else if pendingEvaluation != nil {
+			e.IndicatorValues = pendingEvaluation.IndicatorValues
+		}
+	}
+
 	eh.Logger.Debug("Start to evaluate SLIs")
-	// compare the results based on the evaluation strategy
-	sloConfig, err := getSLOs(e.Project, e.Stage, e.Service)
+	evaluationResult, err := eh.evaluateSLIs(e, shkeptncontext)
 	if err != nil {
 		return err
 	}
 
-	// get results of previous evaluations from data store (mongodb-datastore.keptn-datastore.svc.cluster.local)
-	numberOfPreviousResults := 3
-	if sloConfig.Comparison.CompareWith == "single_result" {
-		numberOfPreviousResults = 1
-	} else if sloConfig.Comparison.CompareWith == "several_results" {
-		numberOfPreviousResults = sloConfig.Comparison.NumberOfComparisonResults
-	}
-	previousEvaluationEvents, err := eh.getPreviousEvaluations(e, numberOfPreviousResults)
+	// send the evaluation-done-event
+	_, err = sendEvaluationDoneOnce(eh.Logger, eh.EvaluationCache, shkeptncontext, evaluationResult, time.Now().UTC(), sendEvent)
 	if err != nil {
 		return err
 	}
 
-	var filteredPreviousEvaluationEvents []*keptnevents.EvaluationDoneEventData
+	if pendingEvaluation != nil {
+		err = eh.PendingEvaluations.Remove(shkeptncontext, e.Stage, e.Service)
+		if err != nil {
+			eh.Logger.Error("Could not remove pending evaluation: " + err.Error())
+		}
+	}
+	return nil
+}
 
-	// verify that we have enough evaluations
-	for _, val := range previousEvaluationEvents {
-		filteredPreviousEvaluationEvents = append(filteredPreviousEvaluationEvents, val)
+// evaluateSLIs evaluates the SLI values of a get-sli.done event against the SLO file of the service. An invalid SLO file
+// results in a failed evaluation, hence the evaluation-done event is sent instead of waiting for the SLI timeout
+func (eh *EvaluateSLIHandler) evaluateSLIs(e *keptnevents.InternalGetSLIDoneEventData, shkeptncontext string) (*keptnevents.EvaluationDoneEventData, error) {
+	// compare the results based on the evaluation strategy
+	sloConfig, err := getSLOs(e.Project, e.Stage, e.Service)
+	if errors.Is(err, errInvalidSLOFile) {
+		// an invalid SLO file must not let the quality gate pass
+		eh.Logger.Error("Invalid SLO file: " + err.Error())
+		return getInvalidSLOResult(e, err), nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(e, sloConfig, filteredPreviousEvaluationEvents)
-	evaluationResult.Labels = e.Labels
+	// get results of previous evaluations from data store (mongodb-datastore.keptn-datastore.svc.cluster.local)
+	previousEvaluationEvents, baselineEvaluationEvents, err := eh.getComparisonEvaluations(e, shkeptncontext, sloConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	// calculate the total score
-	err = calculateScore(maximumAchievableScore, evaluationResult, sloConfig, keySLIFailed)
+	evaluationResult, err := EvaluateSLIs(e, sloConfig, previousEvaluationEvents, baselineEvaluationEvents)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	eh.Logger.Debug("Evaluation result: " + evaluationResult.Result)
 
-	sloFileContent, _ := yaml.Marshal(sloConfig)
-	base64.StdEncoding.EncodeToString(sloFileContent)
-	evaluationResult.EvaluationDetails.SLOFileContent = base64.StdEncoding.EncodeToString(sloFileContent)
-
-	// send the evaluation-done-event
-	var shkeptncontext string
-	eh.Event.Context.ExtensionAs("shkeptncontext", &shkeptncontext)
-
 	// #1289: check if test execution that preceded the evaluation was successful or failed
 	testsFinishedEvent, _ := eh.getPreviousTestExecutionResult(e, shkeptncontext)
 	if testsFinishedEvent != nil {
@@ -110,12 +139,48 @@ func (eh *EvaluateSLIHandler) HandleEvent() error {
 			evaluationResult.EvaluationDetails.Result = "fail"
 		}
 	}
+	return evaluationResult, nil
+}
+
+// getInvalidSLOResult returns the failed evaluation of SLI values whose SLO file is invalid, whose message contains the
+// validation errors
+func getInvalidSLOResult(e *keptnevents.InternalGetSLIDoneEventData, err error) *keptnevents.EvaluationDoneEventData {
+	return &keptnevents.EvaluationDoneEventData{
+		EvaluationDetails: &keptnevents.EvaluationDetails{
+			IndicatorResults: nil,
+			TimeStart:        e.Start,
+			TimeEnd:          e.End,
+			Result:           fmt.Sprintf("evaluation failed: %s", err.Error()),
+		},
+		Result:             "fail",
+		Project:            e.Project,
+		Service:            e.Service,
+		Stage:              e.Stage,
+		TestStrategy:       e.TestStrategy,
+		DeploymentStrategy: e.DeploymentStrategy,
+		Labels:             e.Labels,
+	}
+}
+
+// EvaluateSLIs evaluates the SLI values of a get-sli.done event against the objectives of a SLO file and the results of
+// previous evaluations. Objectives comparing with a pinned baseline use the evaluations stored under the key of the
+// baseline in baselineEvaluationEvents. The result is not sent as an evaluation-done event
+func EvaluateSLIs(e *keptnevents.InternalGetSLIDoneEventData, sloConfig *slo.ServiceLevelObjectives, previousEvaluationEvents []*keptnevents.EvaluationDoneEventData, baselineEvaluationEvents map[string][]*keptnevents.EvaluationDoneEventData) (*keptnevents.EvaluationDoneEventData, error) {
+	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(e, sloConfig, previousEvaluationEvents, baselineEvaluationEvents)
+	evaluationResult.Labels = e.Labels
+
+	// calculate the total score
+	err := calculateScore(maximumAchievableScore, evaluationResult, sloConfig, keySLIFailed)
+	if err != nil {
+		return nil, err
+	}
 
-	err = eh.sendEvaluationDoneEvent(shkeptncontext, evaluationResult)
-	return err
+	sloFileContent, _ := yaml.Marshal(sloConfig)
+	evaluationResult.EvaluationDetails.SLOFileContent = base64.StdEncoding.EncodeToString(sloFileContent)
+	return evaluationResult, nil
 }
 
-func evaluateObjectives(e *keptnevents.InternalGetSLIDoneEventData, sloConfig *keptnmodelsv2.ServiceLevelObjectives, previousEvaluationEvents []*keptnevents.EvaluationDoneEventData) (*keptnevents.EvaluationDoneEventData, float64, bool) {
+func evaluateObjectives(e *keptnevents.InternalGetSLIDoneEventData, sloConfig *slo.ServiceLevelObjectives, previousEvaluationEvents []*keptnevents.EvaluationDoneEventData, baselineEvaluationEvents map[string][]*keptnevents.EvaluationDoneEventData) (*keptnevents.EvaluationDoneEventData, float64, bool) {
 	evaluationResult := &keptnevents.EvaluationDoneEventData{
 		Result:  "",
 		Project: e.Project,
@@ -132,31 +197,35 @@ func evaluateObjectives(e *keptnevents.InternalGetSLIDoneEventData, sloConfig *k
 	maximumAchievableScore := 0.0
 	keySLIFailed := false
 	for _, objective := range sloConfig.Objectives {
-		// only consider the SLI for the total score if pass criteria have been included
-		if len(objective.Pass) > 0 {
-			maximumAchievableScore += float64(objective.Weight)
-		}
 		sliEvaluationResult := &keptnevents.SLIEvaluationResult{}
 		result := getSLIResult(e.IndicatorValues, objective.SLI)
 
-		if result == nil {
-			// no result available => fail the objective
-			sliEvaluationResult.Value = &keptnevents.SLIResult{
-				Metric:  objective.SLI,
-				Success: false,
-				Message: "no value received from SLI provider",
+		if result == nil || !result.Success {
+			// no result available => apply the policy for missing SLI values of the objective
+			sliEvaluationResult, scored, failsKeySLI := evaluateMissingSLI(objective, result)
+			if scored {
+				maximumAchievableScore += float64(objective.Weight)
 			}
-			sliEvaluationResult.Status = "fail"
-			sliEvaluationResult.Score = 0
+			if failsKeySLI {
+				keySLIFailed = true
+			}
+			sliEvaluationResults = append(sliEvaluationResults, sliEvaluationResult)
 			continue
 		}
+		// only consider the SLI for the total score if pass criteria or a trend have been included
+		if len(objective.Pass) > 0 || objective.Trend != nil {
+			maximumAchievableScore += float64(objective.Weight)
+		}
 		sliEvaluationResult.Value = result
 
+		comparison := sloConfig.EffectiveComparison(objective)
+
 		// gather the previous results for the current SLI
 		var previousSLIResults []*keptnevents.SLIEvaluationResult
 
-		if previousEvaluationEvents != nil && len(previousEvaluationEvents) > 0 {
-			for _, event := range previousEvaluationEvents {
+		comparisonEvaluationEvents := selectComparisonEvaluations(comparison, previousEvaluationEvents, baselineEvaluationEvents)
+		if comparisonEvaluationEvents != nil && len(comparisonEvaluationEvents) > 0 {
+			for _, event := range comparisonEvaluationEvents {
 				for _, prevSLIResult := range event.EvaluationDetails.IndicatorResults {
 					if strings.Compare(prevSLIResult.Value.Metric, objective.SLI) == 0 {
 						previousSLIResults = append(previousSLIResults, prevSLIResult)
@@ -170,7 +239,7 @@ func evaluateObjectives(e *keptnevents.InternalGetSLIDoneEventData, sloConfig *k
 		isPassed := true
 		isWarning := true
 		if objective.Pass != nil {
-			isPassed, passTargets, _ = evaluateOrCombinedCriteria(sliEvaluationResult.Value, objective.Pass, previousSLIResults, sloConfig.Comparison)
+			isPassed, passTargets, _ = evaluateOrCombinedCriteria(sliEvaluationResult.Value, objective.Pass, previousSLIResults, comparison, e.IndicatorValues)
 			if isPassed {
 				sliEvaluationResult.Score = float64(objective.Weight)
 				sliEvaluationResult.Status = "pass"
@@ -181,7 +250,7 @@ func evaluateObjectives(e *keptnevents.InternalGetSLIDoneEventData, sloConfig *k
 
 		if !isPassed {
 			if objective.Warning != nil {
-				isWarning, warningTargets, _ = evaluateOrCombinedCriteria(sliEvaluationResult.Value, objective.Warning, previousSLIResults, sloConfig.Comparison)
+				isWarning, warningTargets, _ = evaluateOrCombinedCriteria(sliEvaluationResult.Value, objective.Warning, previousSLIResults, comparison, e.IndicatorValues)
 				if isWarning {
 					sliEvaluationResult.Score = 0.5 * float64(objective.Weight)
 					sliEvaluationResult.Status = "warning"
@@ -201,13 +270,152 @@ func evaluateObjectives(e *keptnevents.InternalGetSLIDoneEventData, sloConfig *k
 			sliEvaluationResult.Score = 0
 		}
 
+		if objective.Trend != nil {
+			trendValues := getTrendValues(objective.SLI, previousEvaluationEvents, objective.Trend.NumberOfPreviousResults(), result.Value)
+			trendStatus, trendTarget := evaluateTrend(objective.Trend, trendValues)
+			sliEvaluationResult.Targets = append(sliEvaluationResult.Targets, trendTarget)
+			applyTrendStatus(sliEvaluationResult, objective, trendStatus)
+			if sliEvaluationResult.Status == "fail" && objective.KeySLI {
+				keySLIFailed = true
+			}
+		}
+
 		sliEvaluationResults = append(sliEvaluationResults, sliEvaluationResult)
 	}
 	evaluationResult.EvaluationDetails.IndicatorResults = sliEvaluationResults
 	return evaluationResult, maximumAchievableScore, keySLIFailed
 }
 
-func calculateScore(maximumAchievableScore float64, evaluationResult *keptnevents.EvaluationDoneEventData, sloConfig *keptnmodelsv2.ServiceLevelObjectives, keySLIFailed bool) error {
+// evaluateMissingSLI scores an objective whose SLI value has not been retrieved, or whose retrieval failed, according
+// to the on_missing policy of the objective. It returns the result, whether the objective counts toward the maximum
+// achievable score, and whether it fails the evaluation like a failed key SLI
+func evaluateMissingSLI(objective *slo.SLO, result *keptnevents.SLIResult) (*keptnevents.SLIEvaluationResult, bool, bool) {
+	reason := "no value received from SLI provider"
+	if result != nil {
+		reason = "SLI provider failed to retrieve value"
+		if result.Message != "" {
+			reason = result.Message
+		}
+	}
+	sliEvaluationResult := &keptnevents.SLIEvaluationResult{
+		Value: &keptnevents.SLIResult{
+			Metric:  objective.SLI,
+			Success: false,
+			Message: reason,
+		},
+		Status: "fail",
+		Score:  0,
+	}
+	// objectives without pass criteria or trend are informative only and never scored
+	scored := len(objective.Pass) > 0 || objective.Trend != nil
+
+	switch objective.OnMissing {
+	case slo.MissingSLIIgnore:
+		sliEvaluationResult.Status = "info"
+		return sliEvaluationResult, false, false
+	case slo.MissingSLIWarn:
+		if scored {
+			sliEvaluationResult.Status = "warning"
+			sliEvaluationResult.Score = 0.5 * float64(objective.Weight)
+		} else {
+			sliEvaluationResult.Status = "info"
+		}
+		return sliEvaluationResult, scored, false
+	case slo.MissingSLIKeySLI:
+		return sliEvaluationResult, scored, true
+	}
+	// fail
+	return sliEvaluationResult, scored, objective.KeySLI
+}
+
+// getTrendValues returns the values of an SLI in the given number of previous evaluations, ordered from the oldest to
+// the most recent evaluation, followed by the current value
+func getTrendValues(sli string, previousEvaluationEvents []*keptnevents.EvaluationDoneEventData, numberOfResults int, currentValue float64) []float64 {
+	if len(previousEvaluationEvents) > numberOfResults {
+		previousEvaluationEvents = previousEvaluationEvents[:numberOfResults]
+	}
+	var values []float64
+	for i := len(previousEvaluationEvents) - 1; i >= 0; i-- {
+		if previousEvaluationEvents[i].EvaluationDetails == nil {
+			continue
+		}
+		for _, prevSLIResult := range previousEvaluationEvents[i].EvaluationDetails.IndicatorResults {
+			if prevSLIResult.Value != nil && prevSLIResult.Value.Metric == sli && prevSLIResult.Value.Success {
+				values = append(values, prevSLIResult.Value.Value)
+			}
+		}
+	}
+	return append(values, currentValue)
+}
+
+// evaluateTrend fits a linear regression over the given values and compares its slope with the slopes of the trend.
+// The returned target contains the fitted slope as target value. If less than two previous values are available,
+// the trend passes
+func evaluateTrend(trend *slo.SLOTrend, values []float64) (string, *keptnevents.SLITarget) {
+	target := &keptnevents.SLITarget{Criteria: "slope" + trend.MaxSlope}
+	maxSlope, err := slo.ParseSlope(trend.MaxSlope)
+	if err != nil {
+		target.Violated = true
+		return "fail", target
+	}
+	target.Criteria = "slope" + maxSlope.String()
+	if len(values) < 3 {
+		return "pass", target
+	}
+	slope := slo.CalculateSlope(values, maxSlope.CheckPercentage)
+	target.TargetValue = slope
+	if maxSlope.Exceeds(slope) {
+		target.Violated = true
+		return "fail", target
+	}
+	if trend.WarningSlope != "" {
+		warningSlope, err := slo.ParseSlope(trend.WarningSlope)
+		if err == nil && warningSlope.Exceeds(slope) {
+			target.Criteria = "slope" + warningSlope.String()
+			target.Violated = true
+			return "warning", target
+		}
+	}
+	return "pass", target
+}
+
+// applyTrendStatus combines the result of the criteria of an objective with the result of its trend, the worse
+// result determines the status and score of the objective
+func applyTrendStatus(sliEvaluationResult *keptnevents.SLIEvaluationResult, objective *slo.SLO, trendStatus string) {
+	if sliEvaluationResult.Status == "fail" {
+		return
+	}
+	switch trendStatus {
+	case "fail":
+		sliEvaluationResult.Status = "fail"
+		sliEvaluationResult.Score = 0
+	case "warning":
+		sliEvaluationResult.Status = "warning"
+		sliEvaluationResult.Score = 0.5 * float64(objective.Weight)
+	default:
+		if sliEvaluationResult.Status == "info" {
+			// objectives with trend but without pass criteria are scored by their trend
+			sliEvaluationResult.Status = "pass"
+			sliEvaluationResult.Score = float64(objective.Weight)
+		}
+	}
+}
+
+// selectComparisonEvaluations returns the previous evaluations an objective is compared with, i.e. the evaluations of
+// its pinned baseline or the last evaluations of the service, limited to the number of results of the comparison
+func selectComparisonEvaluations(comparison *slo.SLOComparison, previousEvaluationEvents []*keptnevents.EvaluationDoneEventData, baselineEvaluationEvents map[string][]*keptnevents.EvaluationDoneEventData) []*keptnevents.EvaluationDoneEventData {
+	evaluationEvents := previousEvaluationEvents
+	if comparison != nil && comparison.Baseline.Key() != "" {
+		evaluationEvents = baselineEvaluationEvents[comparison.Baseline.Key()]
+	}
+	numberOfPreviousResults := comparison.NumberOfPreviousResults()
+	if numberOfPreviousResults > 0 && len(evaluationEvents) > numberOfPreviousResults {
+		evaluationEvents = evaluationEvents[:numberOfPreviousResults]
+	}
+	return evaluationEvents
+}
+
+func calculateScore(maximumAchievableScore float64, evaluationResult *keptnevents.EvaluationDoneEventData, sloConfig *slo.ServiceLevelObjectives, keySLIFailed bool) error {
 	if maximumAchievableScore == 0 {
 		evaluationResult.EvaluationDetails.Result = "pass"
 		evaluationResult.Result = evaluationResult.EvaluationDetails.Result
@@ -256,12 +464,12 @@ func getSLIResult(results []*keptnevents.SLIResult, sli string) *keptnevents.SLI
 	return nil
 }
 
-func evaluateOrCombinedCriteria(result *keptnevents.SLIResult, sloCriteria []*keptnmodelsv2.SLOCriteria, previousResults []*keptnevents.SLIEvaluationResult, comparison *keptnmodelsv2.SLOComparison) (bool, []*keptnevents.SLITarget, error) {
+func evaluateOrCombinedCriteria(result *keptnevents.SLIResult, sloCriteria []*slo.SLOCriteria, previousResults []*keptnevents.SLIEvaluationResult, comparison *slo.SLOComparison, indicatorValues []*keptnevents.SLIResult) (bool, []*keptnevents.SLITarget, error) {
 	var satisfied bool
 	satisfied = false
 	var sliTargets []*keptnevents.SLITarget
 	for _, crit := range sloCriteria {
-		criteriaSatisfied, evaluatedTargets, _ := evaluateCriteriaSet(result, crit, previousResults, comparison)
+		criteriaSatisfied, evaluatedTargets, _ := evaluateCriteriaSet(result, crit, previousResults, comparison, indicatorValues)
 		if criteriaSatisfied {
 			// one matching criteria set is sufficient to satisfy the evaluation. Other criteria sets are evaluated nevertheless, to get potential violations
 			satisfied = true
@@ -274,14 +482,14 @@ func evaluateOrCombinedCriteria(result *keptnevents.SLIResult, sloCriteria []*ke
 }
 
 // evaluateCriteria evaluates a set of criteria strings. Per definition, all criteria clauses within a SLOCriteria object have to be fulfilled to satisfy the SLOCriteria
-func evaluateCriteriaSet(result *keptnevents.SLIResult, sloCriteria *keptnmodelsv2.SLOCriteria, previousResults []*keptnevents.SLIEvaluationResult, comparison *keptnmodelsv2.SLOComparison) (bool, []*keptnevents.SLITarget, error) {
+func evaluateCriteriaSet(result *keptnevents.SLIResult, sloCriteria *slo.SLOCriteria, previousResults []*keptnevents.SLIEvaluationResult, comparison *slo.SLOComparison, indicatorValues []*keptnevents.SLIResult) (bool, []*keptnevents.SLITarget, error) {
 	satisfied := true
 	var sliTargets []*keptnevents.SLITarget
 	for _, criteria := range sloCriteria.Criteria {
 		target := &keptnevents.SLITarget{
 			Criteria: criteria,
 		}
-		criteriaSatisfied, _ := evaluateSingleCriteria(result, criteria, previousResults, comparison, target)
+		criteriaSatisfied, _ := evaluateSingleCriteria(result, criteria, previousResults, comparison, target, indicatorValues)
 		if !criteriaSatisfied {
 			target.Violated = true
 			satisfied = false
@@ -293,26 +501,78 @@ func evaluateCriteriaSet(result *keptnevents.SLIResult, sloCriteria *keptnmodels
 	return satisfied, sliTargets, nil
 }
 
-func evaluateSingleCriteria(sliResult *keptnevents.SLIResult, criteria string, previousResults []*keptnevents.SLIEvaluationResult, comparison *keptnmodelsv2.SLOComparison, violation *keptnevents.SLITarget) (bool, error) {
+func evaluateSingleCriteria(sliResult *keptnevents.SLIResult, criteria string, previousResults []*keptnevents.SLIEvaluationResult, comparison *slo.SLOComparison, violation *keptnevents.SLITarget, indicatorValues []*keptnevents.SLIResult) (bool, error) {
 	if !sliResult.Success {
 		return false, errors.New("cannot evaluate invalid SLI result")
 	}
 
-	co, err := parseCriteriaString(criteria)
+	expression, err := slo.ParseCriteria(criteria)
 
 	if err != nil {
 		return false, err
 	}
 
-	if !co.IsComparison {
-		// do a fixed threshold comparison
-		return evaluateFixedThreshold(sliResult, co, violation)
+	return evaluateCriteriaExpression(sliResult, expression, previousResults, comparison, violation, indicatorValues)
+}
+
+// evaluateCriteriaExpression evaluates a parsed criteria string. For combined criteria, the target value of the
+// criteria that decided the result is reported
+func evaluateCriteriaExpression(sliResult *keptnevents.SLIResult, expression slo.CriteriaExpression, previousResults []*keptnevents.SLIEvaluationResult, comparison *slo.SLOComparison, violation *keptnevents.SLITarget, indicatorValues []*keptnevents.SLIResult) (bool, error) {
+	switch e := expression.(type) {
+	case *slo.LogicalExpression:
+		satisfied, err := evaluateCriteriaExpression(sliResult, e.Left, previousResults, comparison, violation, indicatorValues)
+		if err != nil {
+			return false, err
+		}
+		if (e.Operator == slo.LogicalAnd && !satisfied) || (e.Operator == slo.LogicalOr && satisfied) {
+			return satisfied, nil
+		}
+		return evaluateCriteriaExpression(sliResult, e.Right, previousResults, comparison, violation, indicatorValues)
+	case *slo.Criteria:
+		if e.Left != nil || e.Target != nil {
+			return evaluateExpressionCriteria(sliResult, e, violation, indicatorValues)
+		}
+		if !e.IsComparison {
+			// do a fixed threshold comparison
+			return evaluateFixedThreshold(sliResult, e, violation)
+		}
+		return evaluateComparison(sliResult, e, previousResults, comparison, violation)
+	default:
+		return false, errors.New("unknown criteria expression")
+	}
+}
+
+// evaluateExpressionCriteria evaluates criteria that refer to the values of other SLIs, e.g. "error_rate < 0.01 * throughput"
+func evaluateExpressionCriteria(sliResult *keptnevents.SLIResult, co *slo.Criteria, violation *keptnevents.SLITarget, indicatorValues []*keptnevents.SLIResult) (bool, error) {
+	resolve := func(sli string) (float64, error) {
+		result := getSLIResult(indicatorValues, sli)
+		if result == nil || !result.Success {
+			return 0, fmt.Errorf("no value available for SLI %s", sli)
+		}
+		return result.Value, nil
 	}
 
-	return evaluateComparison(sliResult, co, previousResults, comparison, violation)
+	measured := sliResult.Value
+	if co.Left != nil {
+		value, err := co.Left.Evaluate(resolve)
+		if err != nil {
+			return false, err
+		}
+		measured = value
+	}
+	targetValue := co.Value
+	if co.Target != nil {
+		value, err := co.Target.Evaluate(resolve)
+		if err != nil {
+			return false, err
+		}
+		targetValue = value
+	}
+	violation.TargetValue = targetValue
+	return evaluateValue(measured, targetValue, co.Operator)
 }
 
-func evaluateComparison(sliResult *keptnevents.SLIResult, co *criteriaObject, previousResults []*keptnevents.SLIEvaluationResult, comparison *keptnmodelsv2.SLOComparison, violation *keptnevents.SLITarget) (bool, error) {
+func evaluateComparison(sliResult *keptnevents.SLIResult, co *slo.Criteria, previousResults []*keptnevents.SLIEvaluationResult, comparison *slo.SLOComparison, violation *keptnevents.SLITarget) (bool, error) {
 	// aggregate previous results
 	var aggregatedValue float64
 	var targetValue float64
@@ -347,19 +607,16 @@ func evaluateComparison(sliResult *keptnevents.SLIResult, co *criteriaObject, pr
 		return true, nil
 	}
 
+	if comparison.Mode == slo.ComparisonModeZScore {
+		return evaluateZScore(sliResult, co, previousValues, violation)
+	}
+
 	// aggregate the previous values based on the passed aggregation function
-	switch comparison.AggregateFunction {
-	case "avg":
-		aggregatedValue = calculateAverage(previousValues)
-	case "p50":
-		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.5)
-	case "p90":
-		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.9)
-	case "p95":
-		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.95)
-	default:
-		break
+	aggregate, err := slo.GetAggregationFunction(comparison.AggregateFunction)
+	if err != nil {
+		return false, err
 	}
+	aggregatedValue = aggregate(previousValues)
 
 	// calculate the comparison value
 	if co.CheckPercentage && co.CheckIncrease {
@@ -376,45 +633,30 @@ func evaluateComparison(sliResult *keptnevents.SLIResult, co *criteriaObject, pr
 	return evaluateValue(sliResult.Value, targetValue, co.Operator)
 }
 
-func calculateAverage(values []float64) float64 {
-	sum := 0.0
-
-	for _, value := range values {
-		sum += value
+// evaluateZScore judges the SLI value against the variance of the previous values: the value of a relative criteria
+// is the number of standard deviations the SLI value may deviate from the mean of the previous values, e.g. "<=+2"
+func evaluateZScore(sliResult *keptnevents.SLIResult, co *slo.Criteria, previousValues []float64, violation *keptnevents.SLITarget) (bool, error) {
+	if co.CheckPercentage {
+		return false, errors.New("percentage criteria are not supported in zscore comparison mode")
 	}
-	if len(values) > 0 {
-		return sum / float64(len(values))
+	if len(previousValues) < 2 {
+		// the standard deviation cannot be estimated from less than two values, the evaluation passes
+		return true, nil
 	}
-	return 0.0
-}
+	mean := slo.CalculateAverage(previousValues)
+	stddev := slo.CalculateStandardDeviation(previousValues)
 
-func calculatePercentile(values sort.Float64Slice, perc float64) float64 {
-	if len(values) == 0 {
-		return 0.0
-	}
-	ps := []float64{perc}
-
-	scores := make([]float64, len(ps))
-	size := len(values)
-	if size > 0 {
-		sort.Sort(values)
-		for i, p := range ps {
-			pos := p * float64(size+1) //ALTERNATIVELY, DROP THE +1
-			if pos < 1.0 {
-				scores[i] = float64(values[0])
-			} else if pos >= float64(size) {
-				scores[i] = float64(values[size-1])
-			} else {
-				lower := float64(values[int(pos)-1])
-				upper := float64(values[int(pos)])
-				scores[i] = lower + (pos-math.Floor(pos))*(upper-lower)
-			}
-		}
+	var targetValue float64
+	if co.CheckIncrease {
+		targetValue = mean + co.Value*stddev
+	} else {
+		targetValue = mean - co.Value*stddev
 	}
-	return scores[0]
+	violation.TargetValue = targetValue
+	return evaluateValue(sliResult.Value, targetValue, co.Operator)
 }
 
-func evaluateFixedThreshold(sliResult *keptnevents.SLIResult, co *criteriaObject, violation *keptnevents.SLITarget) (bool, error) {
+func evaluateFixedThreshold(sliResult *keptnevents.SLIResult, co *slo.Criteria, violation *keptnevents.SLITarget) (bool, error) {
 	violation.TargetValue = co.Value
 	return evaluateValue(sliResult.Value, co.Value, co.Operator)
 }
@@ -431,89 +673,145 @@ func evaluateValue(measured float64, expected float64, operator string) (bool, e
 		return measured >= expected, nil
 	case ">":
 		return measured > expected, nil
+	case "!=":
+		return measured != expected, nil
 	default:
 		return false, errors.New("no operator set")
 	}
 }
 
-func parseCriteriaString(criteria string) (*criteriaObject, error) {
-	// example values: <+15%, <500, >-8%, =0
-	// possible operators: <, <=, =, >, >=
-	// regex: ^([<|<=|=|>|>=]{1,2})([+|-]{0,1}\\d*\.?\d*)([%]{0,1})
-	regex := `^([<|<=|=|>|>=]{1,2})([+|-]{0,1}\d*\.?\d*)([%]{0,1})`
-	var re *regexp.Regexp
-	re = regexp.MustCompile(regex)
-
-	// remove whitespaces
-	criteria = strings.Replace(criteria, " ", "", -1)
+// previous evaluations are searched in at most maxPreviousEvaluationPages pages; evaluations matching the labels of a
+// baseline are searched in pages of at least baselinePageSize events
+const (
+	maxPreviousEvaluationPages = 10
+	baselinePageSize           = 20
+)
 
-	if !re.MatchString(criteria) {
-		return nil, errors.New("invalid criteria string")
+// getComparisonEvaluations gets the previous evaluations for the objectives comparing with the last evaluations of the
+// service as well as the evaluations of each baseline pinned in the SLO file, stored under the key of the baseline
+func (eh *EvaluateSLIHandler) getComparisonEvaluations(e *keptnevents.InternalGetSLIDoneEventData, keptnContext string, sloConfig *slo.ServiceLevelObjectives) ([]*keptnevents.EvaluationDoneEventData, map[string][]*keptnevents.EvaluationDoneEventData, error) {
+	numberOfPreviousResults := 0
+	baselines := map[string]*slo.SLOBaseline{}
+	numberOfBaselineResults := map[string]int{}
+	for _, objective := range sloConfig.Objectives {
+		// trends are calculated on the last evaluations of the service
+		if objective.Trend != nil && objective.Trend.NumberOfPreviousResults() > numberOfPreviousResults {
+			numberOfPreviousResults = objective.Trend.NumberOfPreviousResults()
+		}
+		comparison := sloConfig.EffectiveComparison(objective)
+		numberOfResults := comparison.NumberOfPreviousResults()
+		key := comparison.Baseline.Key()
+		if key == "" {
+			if numberOfResults > numberOfPreviousResults {
+				numberOfPreviousResults = numberOfResults
+			}
+			continue
+		}
+		baselines[key] = comparison.Baseline
+		if numberOfResults > numberOfBaselineResults[key] {
+			numberOfBaselineResults[key] = numberOfResults
+		}
 	}
 
-	c := &criteriaObject{}
-
-	if strings.HasSuffix(criteria, "%") {
-		c.CheckPercentage = true
-		criteria = strings.TrimSuffix(criteria, "%")
+	var previousEvaluationEvents []*keptnevents.EvaluationDoneEventData
+	if numberOfPreviousResults > 0 {
+		var err error
+		previousEvaluationEvents, err = eh.getPreviousEvaluations(e, keptnContext, numberOfPreviousResults, nil)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	operators := []string{"<=", "<", "=", ">=", ">"}
-
-	for _, operator := range operators {
-		if strings.HasPrefix(criteria, operator) {
-			c.Operator = operator
-			criteria = strings.TrimPrefix(criteria, operator)
-			break
+	baselineEvaluationEvents := map[string][]*keptnevents.EvaluationDoneEventData{}
+	for key, baseline := range baselines {
+		evaluationEvents, err := eh.getPreviousEvaluations(e, keptnContext, numberOfBaselineResults[key], baseline)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(evaluationEvents) == 0 {
+			eh.Logger.Info("No evaluations found for baseline " + key)
 		}
+		baselineEvaluationEvents[key] = evaluationEvents
 	}
+	return previousEvaluationEvents, baselineEvaluationEvents, nil
+}
 
-	if strings.HasPrefix(criteria, "-") {
-		c.IsComparison = true
-		c.CheckIncrease = false
-		criteria = strings.TrimPrefix(criteria, "-")
-	} else if strings.HasPrefix(criteria, "+") {
-		c.IsComparison = true
-		c.CheckIncrease = true
-		criteria = strings.TrimPrefix(criteria, "+")
-	} else {
-		c.IsComparison = false
-		c.CheckIncrease = false
+// gets previous evaluation-done events from mongodb-datastore. Duplicate evaluation-done events of the same keptnContext
+// (e.g. caused by a re-delivered get-sli.done event) are only considered once, and evaluations of the current keptnContext
+// are skipped. If a baseline is given, only the evaluations of the pinned keptnContext or the evaluations carrying the
+// labels of the baseline are returned
+func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnevents.InternalGetSLIDoneEventData, keptnContext string, numberOfPreviousResults int, baseline *slo.SLOBaseline) ([]*keptnevents.EvaluationDoneEventData, error) {
+	pageSize := numberOfPreviousResults
+	if baseline != nil && len(baseline.Labels) > 0 && pageSize < baselinePageSize {
+		pageSize = baselinePageSize
 	}
-
-	floatValue, err := strconv.ParseFloat(criteria, 64)
-	if err != nil {
-		return nil, errors.New("could not parse criteria target value")
+	// previous results are fetched from mongodb datastore with source=lighthouse-service
+	queryString := fmt.Sprintf(getDatastoreURL()+"/event?type=%s&source=%s&project=%s&stage=%s&service=%s&pageSize=%d",
+		keptnevents.EvaluationDoneEventType, "lighthouse-service",
+		e.Project, e.Stage, e.Service, pageSize)
+	pinnedContext := baseline != nil && baseline.KeptnContext != ""
+	if pinnedContext {
+		queryString += "&keptnContext=" + url.QueryEscape(baseline.KeptnContext)
 	}
-	c.Value = floatValue
 
-	return c, nil
+	var evaluationDoneEvents []*keptnevents.EvaluationDoneEventData
+	evaluatedContexts := map[string]bool{}
+	nextPageKey := ""
+	for page := 0; page < maxPreviousEvaluationPages; page++ {
+		pageQueryString := queryString
+		if nextPageKey != "" {
+			pageQueryString += "&nextPageKey=" + nextPageKey
+		}
+		events, next, err := eh.getEvaluationDoneEvents(pageQueryString)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			if event.keptnContext != "" {
+				if evaluatedContexts[event.keptnContext] || (!pinnedContext && event.keptnContext == keptnContext) {
+					continue
+				}
+				evaluatedContexts[event.keptnContext] = true
+			}
+			if baseline.MatchesLabels(event.data.Labels) {
+				evaluationDoneEvents = append(evaluationDoneEvents, event.data)
+				if len(evaluationDoneEvents) == numberOfPreviousResults {
+					return evaluationDoneEvents, nil
+				}
+			}
+		}
+		if next == "" || next == "0" {
+			break
+		}
+		nextPageKey = next
+	}
+	return evaluationDoneEvents, nil
 }
 
-// gets previous evaluation-done events from mongodb-datastore
-func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnevents.InternalGetSLIDoneEventData, numberOfPreviousResults int) ([]*keptnevents.EvaluationDoneEventData, error) {
-	// previous results are fetched from mongodb datastore with source=lighthouse-service
-	queryString := fmt.Sprintf(getDatastoreURL()+"/event?type=%s&source=%s&project=%s&stage=%s&service=%s&pageSize=%d",
-		keptnevents.EvaluationDoneEventType, "lighthouse-service",
-		e.Project, e.Stage, e.Service, numberOfPreviousResults)
+// storedEvaluation is an evaluation-done event retrieved from the data store
+type storedEvaluation struct {
+	keptnContext string
+	data         *keptnevents.EvaluationDoneEventData
+}
 
+func (eh *EvaluateSLIHandler) getEvaluationDoneEvents(queryString string) ([]*storedEvaluation, string, error) {
 	req, err := http.NewRequest("GET", queryString, nil)
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := eh.HTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 	body, _ := ioutil.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, errors.New("could not retrieve previous evaluation-done events")
+		return nil, "", errors.New("could not retrieve previous evaluation-done events")
 	}
 	previousEvents := &datastoreResult{}
 	err = json.Unmarshal(body, previousEvents)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	var evaluationDoneEvents []*keptnevents.EvaluationDoneEventData
+	var evaluationDoneEvents []*storedEvaluation
 
 	// iterate over previous events
 	for _, event := range previousEvents.Events {
@@ -527,9 +825,9 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnevents.InternalGetS
 		if err != nil {
 			continue
 		}
-		evaluationDoneEvents = append(evaluationDoneEvents, &evaluationDoneEvent)
+		evaluationDoneEvents = append(evaluationDoneEvents, &storedEvaluation{keptnContext: event.Shkeptncontext, data: &evaluationDoneEvent})
 	}
-	return evaluationDoneEvents, nil
+	return evaluationDoneEvents, previousEvents.NextPageKey, nil
 }
 
 func (eh *EvaluateSLIHandler) getPreviousTestExecutionResult(e *keptnevents.InternalGetSLIDoneEventData, keptnContext string) (*keptnevents.TestsFinishedEventData, error) {
@@ -570,24 +868,3 @@ func (eh *EvaluateSLIHandler) getPreviousTestExecutionResult(e *keptnevents.Inte
 	return testsFinishedEvent, nil
 
 }
-
-func (eh *EvaluateSLIHandler) sendEvaluationDoneEvent(shkeptncontext string, data *keptnevents.EvaluationDoneEventData) error {
-
-	source, _ := url.Parse("lighthouse-service")
-	contentType := "application/json"
-
-	event := cloudevents.Event{
-		Context: cloudevents.EventContextV02{
-			ID:          uuid.New().String(),
-			Time:        &types.Timestamp{Time: time.Now()},
-			Type:        keptnevents.EvaluationDoneEventType,
-			Source:      types.URLRef{URL: *source},
-			ContentType: &contentType,
-			Extensions:  map[string]interface{}{"shkeptncontext": shkeptncontext},
-		}.AsV02(),
-		Data: data,
-	}
-
-	eh.Logger.Debug("Send event: " + keptnevents.EvaluationDoneEventType)
-	return sendEvent(event)
-}
@@ -1,118 +1,21 @@
 package event_handler
 
 import (
+	"encoding/json"
 	"errors"
-	"sort"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 
 	keptnevents "github.com/keptn/go-utils/pkg/events"
-	keptnmodelsv2 "github.com/keptn/go-utils/pkg/models/v2"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/keptn/lighthouse-service/pkg/slo"
 	"github.com/stretchr/testify/assert"
 )
 
-type operatorParserTest struct {
-	Criteria               string
-	ExpectedCriteriaObject *criteriaObject
-}
-
-func TestParseCriteriaString(t *testing.T) {
-	tests := []*operatorParserTest{
-		{
-			Criteria: "<10",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        "<",
-				Value:           10,
-				CheckPercentage: false,
-				IsComparison:    false,
-				CheckIncrease:   false,
-			},
-		}, {
-			Criteria: "<=10",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        "<=",
-				Value:           10,
-				CheckPercentage: false,
-				IsComparison:    false,
-				CheckIncrease:   false,
-			},
-		}, {
-			Criteria: "=10",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        "=",
-				Value:           10,
-				CheckPercentage: false,
-				IsComparison:    false,
-				CheckIncrease:   false,
-			},
-		}, {
-			Criteria: ">=10",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        ">=",
-				Value:           10,
-				CheckPercentage: false,
-				IsComparison:    false,
-				CheckIncrease:   false,
-			},
-		}, {
-			Criteria: ">10",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        ">",
-				Value:           10,
-				CheckPercentage: false,
-				IsComparison:    false,
-				CheckIncrease:   false,
-			},
-		}, {
-			Criteria: ">-10%",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        ">",
-				Value:           10,
-				CheckPercentage: true,
-				IsComparison:    true,
-				CheckIncrease:   false,
-			},
-		}, {
-			Criteria: "<=+10.5%",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        "<=",
-				Value:           10.5,
-				CheckPercentage: true,
-				IsComparison:    true,
-				CheckIncrease:   true,
-			},
-		}, {
-			Criteria: "<=+10",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        "<=",
-				Value:           10,
-				CheckPercentage: false,
-				IsComparison:    true,
-				CheckIncrease:   true,
-			},
-		},
-		{
-			Criteria: "  <=+10   %",
-			ExpectedCriteriaObject: &criteriaObject{
-				Operator:        "<=",
-				Value:           10,
-				CheckPercentage: true,
-				IsComparison:    true,
-				CheckIncrease:   true,
-			},
-		},
-	}
-	for _, test := range tests {
-		t.Run(test.Criteria, func(t *testing.T) {
-			co, _ := parseCriteriaString(test.Criteria)
-			assert.EqualValues(t, test.ExpectedCriteriaObject.Operator, co.Operator)
-			assert.EqualValues(t, test.ExpectedCriteriaObject.Value, co.Value)
-			assert.EqualValues(t, test.ExpectedCriteriaObject.CheckPercentage, co.CheckPercentage)
-			assert.EqualValues(t, test.ExpectedCriteriaObject.IsComparison, co.IsComparison)
-			assert.EqualValues(t, test.ExpectedCriteriaObject.CheckIncrease, co.CheckIncrease)
-		})
-	}
-}
-
 type evaluateValueTestObject struct {
 	Name           string
 	MeasuredValue  float64
@@ -164,6 +67,14 @@ func TestEvaluateValue(t *testing.T) {
 			ExpectedResult: false,
 			ExpectedError:  nil,
 		},
+		{
+			Name:           "10 != 9 should return true",
+			MeasuredValue:  10.0,
+			ExpectedValue:  9.0,
+			Operator:       "!=",
+			ExpectedResult: true,
+			ExpectedError:  nil,
+		},
 		{
 			Name:           "10 ? 10 should return an error",
 			MeasuredValue:  10.0,
@@ -185,7 +96,7 @@ func TestEvaluateValue(t *testing.T) {
 type evaluateFixedThresholdTestObject struct {
 	Name             string
 	InSLIResult      *keptnevents.SLIResult
-	InCriteriaObject *criteriaObject
+	InCriteriaObject *slo.Criteria
 	InTarget         *keptnevents.SLITarget
 	ExpectedResult   bool
 	ExpectedError    error
@@ -201,7 +112,7 @@ func TestEvaluateFixedThreshold(t *testing.T) {
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
+			InCriteriaObject: &slo.Criteria{
 				Operator:        ">",
 				Value:           9.0,
 				CheckPercentage: false,
@@ -222,7 +133,7 @@ func TestEvaluateFixedThreshold(t *testing.T) {
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
+			InCriteriaObject: &slo.Criteria{
 				Operator:        "=",
 				Value:           9.0,
 				CheckPercentage: false,
@@ -243,7 +154,7 @@ func TestEvaluateFixedThreshold(t *testing.T) {
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
+			InCriteriaObject: &slo.Criteria{
 				Operator:        "?",
 				Value:           9.0,
 				CheckPercentage: false,
@@ -268,48 +179,12 @@ func TestEvaluateFixedThreshold(t *testing.T) {
 	}
 }
 
-type calculatePercentileTestObject struct {
-	Name          string
-	InValue       sort.Float64Slice
-	InPercentile  float64
-	ExpectedValue float64
-}
-
-func TestCalculatePercentile(t *testing.T) {
-	tests := []*calculatePercentileTestObject{
-		{
-			Name:          "Should return 5.0",
-			InValue:       []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
-			InPercentile:  0.5,
-			ExpectedValue: 5.0,
-		},
-		{
-			Name:          "Should return 9.0",
-			InValue:       []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
-			InPercentile:  0.9,
-			ExpectedValue: 9.8,
-		},
-		{
-			Name:          "Should return 10.0",
-			InValue:       []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
-			InPercentile:  0.95,
-			ExpectedValue: 10.0,
-		},
-	}
-	for _, test := range tests {
-		t.Run(test.Name, func(t *testing.T) {
-			percentile := calculatePercentile(test.InValue, test.InPercentile)
-			assert.EqualValues(t, test.ExpectedValue, percentile)
-		})
-	}
-}
-
 type evaluateComparisonTestObject struct {
 	Name              string
 	InSLIResult       *keptnevents.SLIResult
-	InCriteriaObject  *criteriaObject
+	InCriteriaObject  *slo.Criteria
 	InPreviousResults []*keptnevents.SLIEvaluationResult
-	InComparison      *keptnmodelsv2.SLOComparison
+	InComparison      *slo.SLOComparison
 	InTarget          *keptnevents.SLITarget
 	ExpectedResult    bool
 	ExpectedError     error
@@ -318,17 +193,476 @@ type evaluateComparisonTestObject struct {
 func TestEvaluateComparison(t *testing.T) {
 	tests := []*evaluateComparisonTestObject{
 		{
-			Name: "Expect true for 10.0 <= avg([10.0, 10.0]) + 10%",
+			Name: "Expect true for 10.0 <= avg([10.0, 10.0]) + 10%",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   10.0,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<=",
+				Value:           10.0,
+				CheckPercentage: true,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "avg",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+10%",
+			},
+			ExpectedResult: true,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect false for 11.01 <= avg([10.0, 10.0]) + 10%",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   11.01,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<=",
+				Value:           10.0,
+				CheckPercentage: true,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "avg",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+10%",
+			},
+			ExpectedResult: false,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect false for 10.0 < avg([10.0, 10.0]) + 0%",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   10.0,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<",
+				Value:           0.0,
+				CheckPercentage: true,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "avg",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+10%",
+			},
+			ExpectedResult: false,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect false for 10.0 > avg([10.0, 10.0]) + 0%",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   10.0,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        ">",
+				Value:           0.0,
+				CheckPercentage: true,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "avg",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+10%",
+			},
+			ExpectedResult: false,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect true for 11.0 = avg([10.0, 10.0]) + 1.0",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   11.0,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "=",
+				Value:           1.0,
+				CheckPercentage: false,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "avg",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+10%",
+			},
+			ExpectedResult: true,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect true for 9.0 = avg([10.0, 10.0]) - 1.0",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   9.0,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "=",
+				Value:           1.0,
+				CheckPercentage: false,
+				IsComparison:    true,
+				CheckIncrease:   false,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "avg",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+10%",
+			},
+			ExpectedResult: true,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect true for 10.0 <= p50([10.0, 5.0]) + 10.0%",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   10.0,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        ">=",
+				Value:           10.0,
+				CheckPercentage: true,
+				IsComparison:    true,
+				CheckIncrease:   false,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "p50",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+10%",
+			},
+			ExpectedResult: true,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect true for 10.0 <= max([8.0, 10.0]) + 0",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   10.0,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<=",
+				Value:           0.0,
+				CheckPercentage: false,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   8.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "max",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+0",
+			},
+			ExpectedResult: true,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect false for 9.0 <= min([8.0, 10.0]) + 0",
+			InSLIResult: &keptnevents.SLIResult{
+				Metric:  "my-test-metric",
+				Value:   9.0,
+				Success: true,
+				Message: "",
+			},
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<=",
+				Value:           0.0,
+				CheckPercentage: false,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+			InPreviousResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   8.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   10.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+			},
+			InComparison: &slo.SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 2,
+				AggregateFunction:         "min",
+			},
+			InTarget: &keptnevents.SLITarget{
+				Criteria: "<=+0",
+			},
+			ExpectedResult: false,
+			ExpectedError:  nil,
+		},
+		{
+			Name: "Expect true for 10.5 <= p75([8.0, 9.0, 10.0, 11.0]) + 0",
 			InSLIResult: &keptnevents.SLIResult{
 				Metric:  "my-test-metric",
-				Value:   10.0,
+				Value:   10.5,
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
+			InCriteriaObject: &slo.Criteria{
 				Operator:        "<=",
-				Value:           10.0,
-				CheckPercentage: true,
+				Value:           0.0,
+				CheckPercentage: false,
 				IsComparison:    true,
 				CheckIncrease:   true,
 			},
@@ -337,7 +671,18 @@ func TestEvaluateComparison(t *testing.T) {
 					Score: 2,
 					Value: &keptnevents.SLIResult{
 						Metric:  "my-test-metric",
-						Value:   10.0,
+						Value:   8.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   9.0,
 						Success: true,
 						Message: "",
 					},
@@ -355,28 +700,39 @@ func TestEvaluateComparison(t *testing.T) {
 					Targets: nil,
 					Status:  "pass",
 				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   11.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
-				NumberOfComparisonResults: 2,
-				AggregateFunction:         "avg",
+				NumberOfComparisonResults: 4,
+				AggregateFunction:         "p75",
 			},
 			InTarget: &keptnevents.SLITarget{
-				Criteria: "<=+10%",
+				Criteria: "<=+0",
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
 		},
 		{
-			Name: "Expect false for 11.01 <= avg([10.0, 10.0]) + 10%",
+			Name: "Expect error for unknown aggregate function",
 			InSLIResult: &keptnevents.SLIResult{
 				Metric:  "my-test-metric",
-				Value:   11.01,
+				Value:   10.0,
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
+			InCriteriaObject: &slo.Criteria{
 				Operator:        "<=",
 				Value:           10.0,
 				CheckPercentage: true,
@@ -407,30 +763,30 @@ func TestEvaluateComparison(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
-				AggregateFunction:         "avg",
+				AggregateFunction:         "avrg",
 			},
 			InTarget: &keptnevents.SLITarget{
 				Criteria: "<=+10%",
 			},
 			ExpectedResult: false,
-			ExpectedError:  nil,
+			ExpectedError:  errors.New("unknown aggregate function avrg"),
 		},
 		{
-			Name: "Expect false for 10.0 < avg([10.0, 10.0]) + 0%",
+			Name: "Expect true for 12.0 <= mean([8.0, 10.0, 12.0]) + 2 * stddev",
 			InSLIResult: &keptnevents.SLIResult{
 				Metric:  "my-test-metric",
-				Value:   10.0,
+				Value:   12.0,
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
-				Operator:        "<",
-				Value:           0.0,
-				CheckPercentage: true,
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<=",
+				Value:           2.0,
+				CheckPercentage: false,
 				IsComparison:    true,
 				CheckIncrease:   true,
 			},
@@ -439,7 +795,7 @@ func TestEvaluateComparison(t *testing.T) {
 					Score: 2,
 					Value: &keptnevents.SLIResult{
 						Metric:  "my-test-metric",
-						Value:   10.0,
+						Value:   8.0,
 						Success: true,
 						Message: "",
 					},
@@ -457,31 +813,43 @@ func TestEvaluateComparison(t *testing.T) {
 					Targets: nil,
 					Status:  "pass",
 				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   12.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
-				NumberOfComparisonResults: 2,
+				NumberOfComparisonResults: 3,
 				AggregateFunction:         "avg",
+				Mode:                      "zscore",
 			},
 			InTarget: &keptnevents.SLITarget{
-				Criteria: "<=+10%",
+				Criteria: "<=+2",
 			},
-			ExpectedResult: false,
+			ExpectedResult: true,
 			ExpectedError:  nil,
 		},
 		{
-			Name: "Expect false for 10.0 > avg([10.0, 10.0]) + 0%",
+			Name: "Expect false for 15.0 <= mean([8.0, 10.0, 12.0]) + 2 * stddev",
 			InSLIResult: &keptnevents.SLIResult{
 				Metric:  "my-test-metric",
-				Value:   10.0,
+				Value:   15.0,
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
-				Operator:        ">",
-				Value:           0.0,
-				CheckPercentage: true,
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<=",
+				Value:           2.0,
+				CheckPercentage: false,
 				IsComparison:    true,
 				CheckIncrease:   true,
 			},
@@ -490,7 +858,7 @@ func TestEvaluateComparison(t *testing.T) {
 					Score: 2,
 					Value: &keptnevents.SLIResult{
 						Metric:  "my-test-metric",
-						Value:   10.0,
+						Value:   8.0,
 						Success: true,
 						Message: "",
 					},
@@ -508,40 +876,52 @@ func TestEvaluateComparison(t *testing.T) {
 					Targets: nil,
 					Status:  "pass",
 				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   12.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
-				NumberOfComparisonResults: 2,
+				NumberOfComparisonResults: 3,
 				AggregateFunction:         "avg",
+				Mode:                      "zscore",
 			},
 			InTarget: &keptnevents.SLITarget{
-				Criteria: "<=+10%",
+				Criteria: "<=+2",
 			},
 			ExpectedResult: false,
 			ExpectedError:  nil,
 		},
 		{
-			Name: "Expect true for 11.0 = avg([10.0, 10.0]) + 1.0",
+			Name: "Expect false for 5.0 >= mean([8.0, 10.0, 12.0]) - 2 * stddev",
 			InSLIResult: &keptnevents.SLIResult{
 				Metric:  "my-test-metric",
-				Value:   11.0,
+				Value:   5.0,
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
-				Operator:        "=",
-				Value:           1.0,
+			InCriteriaObject: &slo.Criteria{
+				Operator:        ">=",
+				Value:           2.0,
 				CheckPercentage: false,
 				IsComparison:    true,
-				CheckIncrease:   true,
+				CheckIncrease:   false,
 			},
 			InPreviousResults: []*keptnevents.SLIEvaluationResult{
 				{
 					Score: 2,
 					Value: &keptnevents.SLIResult{
 						Metric:  "my-test-metric",
-						Value:   10.0,
+						Value:   8.0,
 						Success: true,
 						Message: "",
 					},
@@ -559,33 +939,45 @@ func TestEvaluateComparison(t *testing.T) {
 					Targets: nil,
 					Status:  "pass",
 				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   12.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
-				NumberOfComparisonResults: 2,
+				NumberOfComparisonResults: 3,
 				AggregateFunction:         "avg",
+				Mode:                      "zscore",
 			},
 			InTarget: &keptnevents.SLITarget{
-				Criteria: "<=+10%",
+				Criteria: ">=-2",
 			},
-			ExpectedResult: true,
+			ExpectedResult: false,
 			ExpectedError:  nil,
 		},
 		{
-			Name: "Expect true for 9.0 = avg([10.0, 10.0]) - 1.0",
+			Name: "Expect true for zscore comparison with less than two previous values",
 			InSLIResult: &keptnevents.SLIResult{
 				Metric:  "my-test-metric",
-				Value:   9.0,
+				Value:   100.0,
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
-				Operator:        "=",
-				Value:           1.0,
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<=",
+				Value:           2.0,
 				CheckPercentage: false,
 				IsComparison:    true,
-				CheckIncrease:   false,
+				CheckIncrease:   true,
 			},
 			InPreviousResults: []*keptnevents.SLIEvaluationResult{
 				{
@@ -599,51 +991,41 @@ func TestEvaluateComparison(t *testing.T) {
 					Targets: nil,
 					Status:  "pass",
 				},
-				{
-					Score: 2,
-					Value: &keptnevents.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
-					},
-					Targets: nil,
-					Status:  "pass",
-				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
-				NumberOfComparisonResults: 2,
+				NumberOfComparisonResults: 1,
 				AggregateFunction:         "avg",
+				Mode:                      "zscore",
 			},
 			InTarget: &keptnevents.SLITarget{
-				Criteria: "<=+10%",
+				Criteria: "<=+2",
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
 		},
 		{
-			Name: "Expect true for 10.0 <= p50([10.0, 5.0]) + 10.0%",
+			Name: "Expect error for percentage criteria in zscore comparison",
 			InSLIResult: &keptnevents.SLIResult{
 				Metric:  "my-test-metric",
 				Value:   10.0,
 				Success: true,
 				Message: "",
 			},
-			InCriteriaObject: &criteriaObject{
-				Operator:        ">=",
+			InCriteriaObject: &slo.Criteria{
+				Operator:        "<=",
 				Value:           10.0,
 				CheckPercentage: true,
 				IsComparison:    true,
-				CheckIncrease:   false,
+				CheckIncrease:   true,
 			},
 			InPreviousResults: []*keptnevents.SLIEvaluationResult{
 				{
 					Score: 2,
 					Value: &keptnevents.SLIResult{
 						Metric:  "my-test-metric",
-						Value:   10.0,
+						Value:   8.0,
 						Success: true,
 						Message: "",
 					},
@@ -661,18 +1043,30 @@ func TestEvaluateComparison(t *testing.T) {
 					Targets: nil,
 					Status:  "pass",
 				},
+				{
+					Score: 2,
+					Value: &keptnevents.SLIResult{
+						Metric:  "my-test-metric",
+						Value:   12.0,
+						Success: true,
+						Message: "",
+					},
+					Targets: nil,
+					Status:  "pass",
+				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
-				NumberOfComparisonResults: 2,
-				AggregateFunction:         "p50",
+				NumberOfComparisonResults: 3,
+				AggregateFunction:         "avg",
+				Mode:                      "zscore",
 			},
 			InTarget: &keptnevents.SLITarget{
 				Criteria: "<=+10%",
 			},
-			ExpectedResult: true,
-			ExpectedError:  nil,
+			ExpectedResult: false,
+			ExpectedError:  errors.New("percentage criteria are not supported in zscore comparison mode"),
 		},
 	}
 	for _, test := range tests {
@@ -689,7 +1083,7 @@ type evaluateSingleCriteriaTestObject struct {
 	InSLIResult       *keptnevents.SLIResult
 	InCriteria        string
 	InPreviousResults []*keptnevents.SLIEvaluationResult
-	InComparison      *keptnmodelsv2.SLOComparison
+	InComparison      *slo.SLOComparison
 	InTarget          *keptnevents.SLITarget
 	ExpectedResult    bool
 	ExpectedError     error
@@ -730,7 +1124,7 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -775,7 +1169,7 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -820,7 +1214,7 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -865,7 +1259,7 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -910,7 +1304,7 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -955,7 +1349,7 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -1000,7 +1394,7 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -1030,9 +1424,113 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			result, err := evaluateSingleCriteria(test.InSLIResult, test.InCriteria, test.InPreviousResults, test.InComparison, test.InTarget)
+			result, err := evaluateSingleCriteria(test.InSLIResult, test.InCriteria, test.InPreviousResults, test.InComparison, test.InTarget, nil)
+			assert.EqualValues(t, test.ExpectedResult, result)
+			assert.EqualValues(t, test.ExpectedError, err)
+		})
+	}
+}
+
+type evaluateCriteriaExpressionTestObject struct {
+	Name                string
+	InSLIResult         *keptnevents.SLIResult
+	InCriteria          string
+	InPreviousResults   []*keptnevents.SLIEvaluationResult
+	InIndicatorValues   []*keptnevents.SLIResult
+	ExpectedResult      bool
+	ExpectedTargetValue float64
+	ExpectedError       error
+}
+
+func TestEvaluateCriteriaExpression(t *testing.T) {
+	errorRate := &keptnevents.SLIResult{Metric: "error_rate", Value: 5, Success: true}
+	indicatorValues := []*keptnevents.SLIResult{
+		errorRate,
+		{Metric: "throughput", Value: 1000, Success: true},
+		{Metric: "response_time_p95", Value: 0, Success: false, Message: "no data"},
+	}
+	previousResults := []*keptnevents.SLIEvaluationResult{
+		{
+			Score:  1,
+			Value:  &keptnevents.SLIResult{Metric: "error_rate", Value: 4, Success: true},
+			Status: "pass",
+		},
+	}
+	tests := []*evaluateCriteriaExpressionTestObject{
+		{
+			Name:                "Expect true for 5 in range 0..10",
+			InSLIResult:         errorRate,
+			InCriteria:          "0..10",
+			ExpectedResult:      true,
+			ExpectedTargetValue: 10,
+		},
+		{
+			Name:                "Expect false for 5 in range 6..10 and report the violated lower bound",
+			InSLIResult:         errorRate,
+			InCriteria:          "6..10",
+			ExpectedResult:      false,
+			ExpectedTargetValue: 6,
+		},
+		{
+			Name:                "Expect true for 5 <= +10% OR < 6",
+			InSLIResult:         errorRate,
+			InCriteria:          "<=+10% OR <6",
+			InPreviousResults:   previousResults,
+			ExpectedResult:      true,
+			ExpectedTargetValue: 6,
+		},
+		{
+			Name:                "Expect false for 5 <= +10% AND < 6",
+			InSLIResult:         errorRate,
+			InCriteria:          "<=+10% AND <6",
+			InPreviousResults:   previousResults,
+			ExpectedResult:      false,
+			ExpectedTargetValue: 4.4,
+		},
+		{
+			Name:                "Expect true for error_rate < 0.01 * throughput",
+			InSLIResult:         errorRate,
+			InCriteria:          "error_rate < 0.01 * throughput",
+			ExpectedResult:      true,
+			ExpectedTargetValue: 10,
+		},
+		{
+			Name:                "Expect false for <= 0.001 * throughput",
+			InSLIResult:         errorRate,
+			InCriteria:          "<= 0.001 * throughput",
+			ExpectedResult:      false,
+			ExpectedTargetValue: 1,
+		},
+		{
+			Name:           "Expect error for a reference to a failed SLI",
+			InSLIResult:    errorRate,
+			InCriteria:     "< response_time_p95",
+			ExpectedResult: false,
+			ExpectedError:  errors.New("no value available for SLI response_time_p95"),
+		},
+		{
+			Name:           "Expect error for a reference to an unknown SLI",
+			InSLIResult:    errorRate,
+			InCriteria:     "< 2 * requests",
+			ExpectedResult: false,
+			ExpectedError:  errors.New("no value available for SLI requests"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			target := &keptnevents.SLITarget{Criteria: test.InCriteria}
+			comparison := &slo.SLOComparison{
+				CompareWith:               "single_result",
+				IncludeResultWithScore:    "all",
+				NumberOfComparisonResults: 1,
+				AggregateFunction:         "avg",
+			}
+			result, err := evaluateSingleCriteria(test.InSLIResult, test.InCriteria, test.InPreviousResults, comparison, target, indicatorValues)
 			assert.EqualValues(t, test.ExpectedResult, result)
 			assert.EqualValues(t, test.ExpectedError, err)
+			if err == nil {
+				assert.InDelta(t, test.ExpectedTargetValue, target.TargetValue, 0.000001)
+			}
 		})
 	}
 }
@@ -1040,9 +1538,9 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 type evaluateCriteriaSetTestObject struct {
 	Name              string
 	InSLIResult       *keptnevents.SLIResult
-	InCriteriaSet     *keptnmodelsv2.SLOCriteria
+	InCriteriaSet     *slo.SLOCriteria
 	InPreviousResults []*keptnevents.SLIEvaluationResult
-	InComparison      *keptnmodelsv2.SLOComparison
+	InComparison      *slo.SLOComparison
 	ExpectedTargets   []*keptnevents.SLITarget
 	ExpectedResult    bool
 	ExpectedError     error
@@ -1058,7 +1556,7 @@ func TestEvaluateCriteriaSet(t *testing.T) {
 				Success: true,
 				Message: "",
 			},
-			InCriteriaSet: &keptnmodelsv2.SLOCriteria{
+			InCriteriaSet: &slo.SLOCriteria{
 				Criteria: []string{"<=+10%", "<=10.0"},
 			},
 			InPreviousResults: []*keptnevents.SLIEvaluationResult{
@@ -1085,7 +1583,7 @@ func TestEvaluateCriteriaSet(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -1114,7 +1612,7 @@ func TestEvaluateCriteriaSet(t *testing.T) {
 				Success: true,
 				Message: "",
 			},
-			InCriteriaSet: &keptnmodelsv2.SLOCriteria{
+			InCriteriaSet: &slo.SLOCriteria{
 				Criteria: []string{"<=+10%", "<=10.0"},
 			},
 			InPreviousResults: []*keptnevents.SLIEvaluationResult{
@@ -1141,7 +1639,7 @@ func TestEvaluateCriteriaSet(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -1165,7 +1663,7 @@ func TestEvaluateCriteriaSet(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			result, violations, err := evaluateCriteriaSet(test.InSLIResult, test.InCriteriaSet, test.InPreviousResults, test.InComparison)
+			result, violations, err := evaluateCriteriaSet(test.InSLIResult, test.InCriteriaSet, test.InPreviousResults, test.InComparison, nil)
 			assert.EqualValues(t, test.ExpectedResult, result)
 			assert.EqualValues(t, test.ExpectedTargets, violations)
 			assert.EqualValues(t, test.ExpectedError, err)
@@ -1176,9 +1674,9 @@ func TestEvaluateCriteriaSet(t *testing.T) {
 type evaluateOrCombinedCriteriaTestObject struct {
 	Name              string
 	InSLIResult       *keptnevents.SLIResult
-	InCriteriaSets    []*keptnmodelsv2.SLOCriteria
+	InCriteriaSets    []*slo.SLOCriteria
 	InPreviousResults []*keptnevents.SLIEvaluationResult
-	InComparison      *keptnmodelsv2.SLOComparison
+	InComparison      *slo.SLOComparison
 	ExpectedTargets   []*keptnevents.SLITarget
 	ExpectedResult    bool
 	ExpectedError     error
@@ -1194,7 +1692,7 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 				Success: true,
 				Message: "",
 			},
-			InCriteriaSets: []*keptnmodelsv2.SLOCriteria{
+			InCriteriaSets: []*slo.SLOCriteria{
 				{
 					Criteria: []string{"<=10.0"},
 				},
@@ -1226,7 +1724,7 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -1255,7 +1753,7 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 				Success: true,
 				Message: "",
 			},
-			InCriteriaSets: []*keptnmodelsv2.SLOCriteria{
+			InCriteriaSets: []*slo.SLOCriteria{
 				{
 					Criteria: []string{"<=10.0"},
 				},
@@ -1287,7 +1785,7 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -1316,7 +1814,7 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 				Success: true,
 				Message: "",
 			},
-			InCriteriaSets: []*keptnmodelsv2.SLOCriteria{
+			InCriteriaSets: []*slo.SLOCriteria{
 				{
 					Criteria: []string{"<=10.0"},
 				},
@@ -1348,7 +1846,7 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 					Status:  "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &slo.SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
@@ -1373,7 +1871,7 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
 			t.Run(test.Name, func(t *testing.T) {
-				result, violations, err := evaluateOrCombinedCriteria(test.InSLIResult, test.InCriteriaSets, test.InPreviousResults, test.InComparison)
+				result, violations, err := evaluateOrCombinedCriteria(test.InSLIResult, test.InCriteriaSets, test.InPreviousResults, test.InComparison, nil)
 				assert.EqualValues(t, test.ExpectedResult, result)
 				assert.EqualValues(t, test.ExpectedTargets, violations)
 				assert.EqualValues(t, test.ExpectedError, err)
@@ -1385,8 +1883,9 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 type evaluateObjectivesTestObject struct {
 	Name                       string
 	InGetSLIDoneEvent          *keptnevents.InternalGetSLIDoneEventData
-	InSLOConfig                *keptnmodelsv2.ServiceLevelObjectives
+	InSLOConfig                *slo.ServiceLevelObjectives
 	InPreviousEvaluationEvents []*keptnevents.EvaluationDoneEventData
+	InBaselineEvaluationEvents map[string][]*keptnevents.EvaluationDoneEventData
 	ExpectedEvaluationResult   *keptnevents.EvaluationDoneEventData
 	ExpectedMaximumScore       float64
 	ExpectedKeySLIFailed       bool
@@ -1411,19 +1910,19 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI: "my-test-metric-1",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=15.0"},
 							},
@@ -1431,7 +1930,7 @@ func TestEvaluateObjectives(t *testing.T) {
 								Criteria: []string{"<=+10%"},
 							},
 						},
-						Warning: []*keptnmodelsv2.SLOCriteria{
+						Warning: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=20.0"},
 							},
@@ -1443,7 +1942,7 @@ func TestEvaluateObjectives(t *testing.T) {
 						KeySLI: false,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
@@ -1533,19 +2032,19 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI: "my-test-metric-1",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=15.0"},
 							},
@@ -1553,7 +2052,7 @@ func TestEvaluateObjectives(t *testing.T) {
 								Criteria: []string{"<=+10%"},
 							},
 						},
-						Warning: []*keptnmodelsv2.SLOCriteria{
+						Warning: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=20.0"},
 							},
@@ -1565,7 +2064,7 @@ func TestEvaluateObjectives(t *testing.T) {
 						KeySLI: false,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
@@ -1671,19 +2170,19 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI: "my-test-metric-1",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=15.0"},
 							},
@@ -1691,7 +2190,7 @@ func TestEvaluateObjectives(t *testing.T) {
 								Criteria: []string{"<=+10%"},
 							},
 						},
-						Warning: []*keptnmodelsv2.SLOCriteria{
+						Warning: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=20.0"},
 							},
@@ -1708,7 +2207,7 @@ func TestEvaluateObjectives(t *testing.T) {
 						KeySLI: false,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
@@ -1808,19 +2307,19 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI: "response_time_p50",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=+20%", "<500"},
 							},
@@ -1829,7 +2328,7 @@ func TestEvaluateObjectives(t *testing.T) {
 						KeySLI: false,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
@@ -1892,19 +2391,19 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "single_result",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 1,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI: "response_time_p50",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=+20%"},
 							},
@@ -1913,7 +2412,7 @@ func TestEvaluateObjectives(t *testing.T) {
 						KeySLI: false,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
@@ -1984,7 +2483,7 @@ func TestEvaluateObjectives(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			evaluationDoneData, maximumScore, keySLIFailed := evaluateObjectives(test.InGetSLIDoneEvent, test.InSLOConfig, test.InPreviousEvaluationEvents)
+			evaluationDoneData, maximumScore, keySLIFailed := evaluateObjectives(test.InGetSLIDoneEvent, test.InSLOConfig, test.InPreviousEvaluationEvents, test.InBaselineEvaluationEvents)
 			assert.EqualValues(t, test.ExpectedEvaluationResult, evaluationDoneData)
 			assert.EqualValues(t, test.ExpectedMaximumScore, maximumScore)
 			assert.EqualValues(t, test.ExpectedKeySLIFailed, keySLIFailed)
@@ -1992,11 +2491,293 @@ func TestEvaluateObjectives(t *testing.T) {
 	}
 }
 
+func TestEvaluateObjectivesWithMissingSLIs(t *testing.T) {
+	passCriteria := []*slo.SLOCriteria{{Criteria: []string{"<=100"}}}
+	tests := []struct {
+		Name                 string
+		InOnMissing          string
+		InKeySLI             bool
+		ExpectedResult       *keptnevents.SLIEvaluationResult
+		ExpectedMaximumScore float64
+		ExpectedKeySLIFailed bool
+	}{
+		{
+			Name:        "missing SLI fails the objective by default",
+			InOnMissing: "",
+			ExpectedResult: &keptnevents.SLIEvaluationResult{
+				Score:  0,
+				Value:  &keptnevents.SLIResult{Metric: "error_rate", Success: false, Message: "no value received from SLI provider"},
+				Status: "fail",
+			},
+			ExpectedMaximumScore: 2,
+		},
+		{
+			Name:     "missing key SLI fails the evaluation",
+			InKeySLI: true,
+			ExpectedResult: &keptnevents.SLIEvaluationResult{
+				Score:  0,
+				Value:  &keptnevents.SLIResult{Metric: "error_rate", Success: false, Message: "no value received from SLI provider"},
+				Status: "fail",
+			},
+			ExpectedMaximumScore: 2,
+			ExpectedKeySLIFailed: true,
+		},
+		{
+			Name:        "ignored SLI is reported but not scored",
+			InOnMissing: slo.MissingSLIIgnore,
+			ExpectedResult: &keptnevents.SLIEvaluationResult{
+				Score:  0,
+				Value:  &keptnevents.SLIResult{Metric: "error_rate", Success: false, Message: "no value received from SLI provider"},
+				Status: "info",
+			},
+			ExpectedMaximumScore: 1,
+		},
+		{
+			Name:        "missing SLI is scored as warning",
+			InOnMissing: slo.MissingSLIWarn,
+			ExpectedResult: &keptnevents.SLIEvaluationResult{
+				Score:  0.5,
+				Value:  &keptnevents.SLIResult{Metric: "error_rate", Success: false, Message: "no value received from SLI provider"},
+				Status: "warning",
+			},
+			ExpectedMaximumScore: 2,
+		},
+		{
+			Name:        "missing SLI is treated as failed key SLI",
+			InOnMissing: slo.MissingSLIKeySLI,
+			ExpectedResult: &keptnevents.SLIEvaluationResult{
+				Score:  0,
+				Value:  &keptnevents.SLIResult{Metric: "error_rate", Success: false, Message: "no value received from SLI provider"},
+				Status: "fail",
+			},
+			ExpectedMaximumScore: 2,
+			ExpectedKeySLIFailed: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			sloConfig := &slo.ServiceLevelObjectives{
+				Comparison: &slo.SLOComparison{CompareWith: "single_result", IncludeResultWithScore: "all", AggregateFunction: "avg"},
+				Objectives: []*slo.SLO{
+					{SLI: "response_time_p95", Pass: passCriteria, Weight: 1},
+					{SLI: "error_rate", Pass: passCriteria, Weight: 1, KeySLI: test.InKeySLI, OnMissing: test.InOnMissing},
+				},
+			}
+			e := &keptnevents.InternalGetSLIDoneEventData{
+				IndicatorValues: []*keptnevents.SLIResult{{Metric: "response_time_p95", Value: 50, Success: true}},
+			}
+
+			evaluationResult, maximumScore, keySLIFailed := evaluateObjectives(e, sloConfig, nil, nil)
+			assert.Len(t, evaluationResult.EvaluationDetails.IndicatorResults, 2)
+			assert.EqualValues(t, test.ExpectedResult, evaluationResult.EvaluationDetails.IndicatorResults[1])
+			assert.EqualValues(t, test.ExpectedMaximumScore, maximumScore)
+			assert.EqualValues(t, test.ExpectedKeySLIFailed, keySLIFailed)
+		})
+	}
+}
+
+func TestEvaluateMissingSLIWithFailedRetrieval(t *testing.T) {
+	objective := &slo.SLO{SLI: "error_rate", Pass: []*slo.SLOCriteria{{Criteria: []string{"<=1"}}}, Weight: 1}
+
+	sliEvaluationResult, scored, keySLIFailed := evaluateMissingSLI(objective, &keptnevents.SLIResult{Metric: "error_rate", Success: false, Message: "query timed out"})
+	assert.EqualValues(t, "query timed out", sliEvaluationResult.Value.Message)
+	assert.EqualValues(t, "fail", sliEvaluationResult.Status)
+	assert.True(t, scored)
+	assert.False(t, keySLIFailed)
+
+	sliEvaluationResult, _, _ = evaluateMissingSLI(objective, &keptnevents.SLIResult{Metric: "error_rate", Success: false})
+	assert.EqualValues(t, "SLI provider failed to retrieve value", sliEvaluationResult.Value.Message)
+}
+
+func TestEvaluateTrend(t *testing.T) {
+	tests := []struct {
+		Name           string
+		InTrend        *slo.SLOTrend
+		InValues       []float64
+		ExpectedStatus string
+		ExpectedTarget *keptnevents.SLITarget
+	}{
+		{
+			Name:           "Expect pass for stable values",
+			InTrend:        &slo.SLOTrend{MaxSlope: "+2%"},
+			InValues:       []float64{100, 101, 99, 100},
+			ExpectedStatus: "pass",
+			ExpectedTarget: &keptnevents.SLITarget{Criteria: "slope+2%", TargetValue: -0.2, Violated: false},
+		},
+		{
+			Name:           "Expect fail for values increasing faster than 2% per evaluation",
+			InTrend:        &slo.SLOTrend{MaxSlope: "+2%"},
+			InValues:       []float64{100, 103, 106, 109, 112},
+			ExpectedStatus: "fail",
+			ExpectedTarget: &keptnevents.SLITarget{Criteria: "slope+2%", TargetValue: 2.830188679245283, Violated: true},
+		},
+		{
+			Name:           "Expect warning for values increasing faster than the warning slope",
+			InTrend:        &slo.SLOTrend{MaxSlope: "+5", WarningSlope: "+2"},
+			InValues:       []float64{100, 103, 106},
+			ExpectedStatus: "warning",
+			ExpectedTarget: &keptnevents.SLITarget{Criteria: "slope+2", TargetValue: 3, Violated: true},
+		},
+		{
+			Name:           "Expect fail for decreasing throughput",
+			InTrend:        &slo.SLOTrend{MaxSlope: "-10"},
+			InValues:       []float64{1000, 950, 900},
+			ExpectedStatus: "fail",
+			ExpectedTarget: &keptnevents.SLITarget{Criteria: "slope-10", TargetValue: -50, Violated: true},
+		},
+		{
+			Name:           "Expect pass if less than two previous values are available",
+			InTrend:        &slo.SLOTrend{MaxSlope: "+2%"},
+			InValues:       []float64{100, 200},
+			ExpectedStatus: "pass",
+			ExpectedTarget: &keptnevents.SLITarget{Criteria: "slope+2%", TargetValue: 0, Violated: false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			status, target := evaluateTrend(test.InTrend, test.InValues)
+			assert.EqualValues(t, test.ExpectedStatus, status)
+			assert.EqualValues(t, test.ExpectedTarget.Criteria, target.Criteria)
+			assert.InDelta(t, test.ExpectedTarget.TargetValue, target.TargetValue, 0.0001)
+			assert.EqualValues(t, test.ExpectedTarget.Violated, target.Violated)
+		})
+	}
+}
+
+func TestEvaluateObjectivesWithTrend(t *testing.T) {
+	var previousEvaluationEvents []*keptnevents.EvaluationDoneEventData
+	// previous evaluations are ordered from the most recent to the oldest evaluation
+	for _, value := range []float64{130, 120, 110, 100} {
+		previousEvaluationEvents = append(previousEvaluationEvents, &keptnevents.EvaluationDoneEventData{
+			EvaluationDetails: &keptnevents.EvaluationDetails{
+				IndicatorResults: []*keptnevents.SLIEvaluationResult{
+					{Value: &keptnevents.SLIResult{Metric: "response_time_p95", Value: value, Success: true}, Status: "pass"},
+				},
+			},
+		})
+	}
+	sloConfig := &slo.ServiceLevelObjectives{
+		Comparison: &slo.SLOComparison{CompareWith: "single_result", IncludeResultWithScore: "all", AggregateFunction: "avg"},
+		Objectives: []*slo.SLO{
+			{
+				SLI:    "response_time_p95",
+				Pass:   []*slo.SLOCriteria{{Criteria: []string{"<=200"}}},
+				Weight: 1,
+				Trend:  &slo.SLOTrend{NumberOfResults: 3, MaxSlope: "+5%"},
+			},
+		},
+	}
+	e := &keptnevents.InternalGetSLIDoneEventData{
+		IndicatorValues: []*keptnevents.SLIResult{{Metric: "response_time_p95", Value: 140, Success: true}},
+	}
+
+	evaluationResult, maximumScore, _ := evaluateObjectives(e, sloConfig, previousEvaluationEvents, nil)
+
+	// the trend over 110, 120, 130, 140 is +8% per evaluation although the value passes the criteria
+	sliEvaluationResult := evaluationResult.EvaluationDetails.IndicatorResults[0]
+	assert.EqualValues(t, "fail", sliEvaluationResult.Status)
+	assert.EqualValues(t, 0, sliEvaluationResult.Score)
+	assert.EqualValues(t, 1, maximumScore)
+	assert.Len(t, sliEvaluationResult.Targets, 2)
+	assert.EqualValues(t, "slope+5%", sliEvaluationResult.Targets[1].Criteria)
+	assert.InDelta(t, 8, sliEvaluationResult.Targets[1].TargetValue, 0.0001)
+	assert.True(t, sliEvaluationResult.Targets[1].Violated)
+}
+
+func TestSelectComparisonEvaluations(t *testing.T) {
+	previousEvaluationEvents := []*keptnevents.EvaluationDoneEventData{
+		{Result: "pass"}, {Result: "warning"}, {Result: "fail"},
+	}
+	baselineEvaluationEvents := map[string][]*keptnevents.EvaluationDoneEventData{
+		"labels=release=stable": {{Result: "pass", Labels: map[string]string{"release": "stable"}}},
+	}
+
+	evaluations := selectComparisonEvaluations(&slo.SLOComparison{CompareWith: "several_results", NumberOfComparisonResults: 2},
+		previousEvaluationEvents, baselineEvaluationEvents)
+	assert.EqualValues(t, previousEvaluationEvents[:2], evaluations)
+
+	evaluations = selectComparisonEvaluations(&slo.SLOComparison{
+		CompareWith: "single_result",
+		Baseline:    &slo.SLOBaseline{Labels: map[string]string{"release": "stable"}},
+	}, previousEvaluationEvents, baselineEvaluationEvents)
+	assert.EqualValues(t, baselineEvaluationEvents["labels=release=stable"], evaluations)
+
+	evaluations = selectComparisonEvaluations(&slo.SLOComparison{
+		CompareWith: "single_result",
+		Baseline:    &slo.SLOBaseline{KeptnContext: "unknown"},
+	}, previousEvaluationEvents, baselineEvaluationEvents)
+	assert.Empty(t, evaluations)
+}
+
+func TestGetComparisonEvaluations(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		result := map[string]interface{}{}
+		switch {
+		case r.URL.Query().Get("keptnContext") != "":
+			result["events"] = []map[string]interface{}{
+				{"shkeptncontext": "my-context", "data": keptnevents.EvaluationDoneEventData{Result: "pinned"}},
+			}
+		case r.URL.Query().Get("nextPageKey") == "":
+			result["events"] = []map[string]interface{}{
+				// evaluation of the current context and a duplicate evaluation are not used for the comparison
+				{"shkeptncontext": "current-context", "data": keptnevents.EvaluationDoneEventData{Result: "current"}},
+				{"shkeptncontext": "context-2", "data": keptnevents.EvaluationDoneEventData{Result: "canary", Labels: map[string]string{"release": "canary"}}},
+				{"shkeptncontext": "context-2", "data": keptnevents.EvaluationDoneEventData{Result: "duplicate", Labels: map[string]string{"release": "canary"}}},
+			}
+			result["nextPageKey"] = "3"
+		default:
+			result["events"] = []map[string]interface{}{
+				{"shkeptncontext": "context-1", "data": keptnevents.EvaluationDoneEventData{Result: "stable", Labels: map[string]string{"release": "stable"}}},
+			}
+		}
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer ts.Close()
+	os.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+	defer os.Unsetenv("MONGODB_DATASTORE")
+
+	eh := &EvaluateSLIHandler{
+		Logger:     keptnutils.NewLogger("", "", "lighthouse-service"),
+		HTTPClient: &http.Client{},
+	}
+	sloConfig := &slo.ServiceLevelObjectives{
+		Comparison: &slo.SLOComparison{CompareWith: "several_results", NumberOfComparisonResults: 3},
+		Objectives: []*slo.SLO{
+			{SLI: "response_time_p95"},
+			{SLI: "error_rate", Comparison: &slo.SLOComparison{
+				CompareWith: "single_result",
+				Baseline:    &slo.SLOBaseline{Labels: map[string]string{"release": "stable"}},
+			}},
+			{SLI: "throughput", Comparison: &slo.SLOComparison{
+				Baseline: &slo.SLOBaseline{KeptnContext: "my-context"},
+			}},
+		},
+	}
+
+	previousEvaluationEvents, baselineEvaluationEvents, err := eh.getComparisonEvaluations(&keptnevents.InternalGetSLIDoneEventData{
+		Project: "sockshop",
+		Stage:   "dev",
+		Service: "carts",
+	}, "current-context", sloConfig)
+
+	assert.Nil(t, err)
+	assert.Len(t, previousEvaluationEvents, 2)
+	assert.EqualValues(t, "canary", previousEvaluationEvents[0].Result)
+	assert.EqualValues(t, "stable", previousEvaluationEvents[1].Result)
+	assert.Len(t, baselineEvaluationEvents["labels=release=stable"], 1)
+	assert.EqualValues(t, "stable", baselineEvaluationEvents["labels=release=stable"][0].Result)
+	assert.Len(t, baselineEvaluationEvents["keptn_context=my-context"], 1)
+	assert.EqualValues(t, "pinned", baselineEvaluationEvents["keptn_context=my-context"][0].Result)
+	assert.Len(t, queries, 5)
+}
+
 type calculateScoreTestObject struct {
 	Name                     string
 	InMaximumScore           float64
 	InEvaluationResult       *keptnevents.EvaluationDoneEventData
-	InSLOConfig              *keptnmodelsv2.ServiceLevelObjectives
+	InSLOConfig              *slo.ServiceLevelObjectives
 	InKeySLIFailed           bool
 	ExpectedEvaluationResult *keptnevents.EvaluationDoneEventData
 	ExpectedError            error
@@ -2033,19 +2814,19 @@ func TestCalculateScore(t *testing.T) {
 				Stage:        "dev",
 				TestStrategy: "",
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI: "my-test-metric-1",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=15.0"},
 							},
@@ -2053,7 +2834,7 @@ func TestCalculateScore(t *testing.T) {
 								Criteria: []string{"<=+10%"},
 							},
 						},
-						Warning: []*keptnmodelsv2.SLOCriteria{
+						Warning: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=20.0"},
 							},
@@ -2065,7 +2846,7 @@ func TestCalculateScore(t *testing.T) {
 						KeySLI: false,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
@@ -2139,19 +2920,19 @@ func TestCalculateScore(t *testing.T) {
 				Stage:        "dev",
 				TestStrategy: "",
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI: "my-test-metric-1",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=15.0"},
 							},
@@ -2159,7 +2940,7 @@ func TestCalculateScore(t *testing.T) {
 								Criteria: []string{"<=+10%"},
 							},
 						},
-						Warning: []*keptnmodelsv2.SLOCriteria{
+						Warning: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=20.0"},
 							},
@@ -2172,7 +2953,7 @@ func TestCalculateScore(t *testing.T) {
 					},
 					{
 						SLI: "my-key-metric",
-						Pass: []*keptnmodelsv2.SLOCriteria{
+						Pass: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=15.0"},
 							},
@@ -2180,7 +2961,7 @@ func TestCalculateScore(t *testing.T) {
 								Criteria: []string{"<=+10%"},
 							},
 						},
-						Warning: []*keptnmodelsv2.SLOCriteria{
+						Warning: []*slo.SLOCriteria{
 							{
 								Criteria: []string{"<=20.0"},
 							},
@@ -2192,7 +2973,7 @@ func TestCalculateScore(t *testing.T) {
 						KeySLI: true,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
@@ -2277,16 +3058,16 @@ func TestCalculateScore(t *testing.T) {
 				Stage:        "dev",
 				TestStrategy: "",
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &slo.ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &slo.SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
 					AggregateFunction:         "avg",
 				},
-				Objectives: []*keptnmodelsv2.SLO{
+				Objectives: []*slo.SLO{
 					{
 						SLI:    "my-test-metric-1",
 						Weight: 1,
@@ -2298,7 +3079,7 @@ func TestCalculateScore(t *testing.T) {
 						KeySLI: false,
 					},
 				},
-				TotalScore: &keptnmodelsv2.SLOScore{
+				TotalScore: &slo.SLOScore{
 					Pass:    "90%",
 					Warning: "75%",
 				},
@@ -2353,3 +3134,29 @@ func TestCalculateScore(t *testing.T) {
 		})
 	}
 }
+
+// TestGetInvalidSLOResult checks whether an invalid SLO file fails the evaluation with the validation errors
+func TestGetInvalidSLOResult(t *testing.T) {
+	e := &keptnevents.InternalGetSLIDoneEventData{
+		Project:      "sockshop",
+		Stage:        "staging",
+		Service:      "carts",
+		Start:        "2020-01-20T10:00:00Z",
+		End:          "2020-01-20T10:10:00Z",
+		TestStrategy: "performance",
+		Labels:       map[string]string{"build": "42"},
+	}
+	err := fmt.Errorf("%w for service carts in stage staging in project sockshop: objective 1 has no SLI", errInvalidSLOFile)
+	assert.True(t, errors.Is(err, errInvalidSLOFile))
+
+	result := getInvalidSLOResult(e, err)
+	assert.Equal(t, "fail", result.Result)
+	assert.Equal(t, "evaluation failed: Could not parse SLO file for service carts in stage staging in project sockshop: objective 1 has no SLI",
+		result.EvaluationDetails.Result)
+	assert.Equal(t, "2020-01-20T10:00:00Z", result.EvaluationDetails.TimeStart)
+	assert.Equal(t, "sockshop", result.Project)
+	assert.Equal(t, "staging", result.Stage)
+	assert.Equal(t, "carts", result.Service)
+	assert.Equal(t, "performance", result.TestStrategy)
+	assert.Equal(t, map[string]string{"build": "42"}, result.Labels)
+}
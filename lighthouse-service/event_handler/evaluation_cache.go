@@ -0,0 +1,265 @@
+package event_handler
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	"github.com/google/uuid"
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const evaluationCacheConfigMap = "lighthouse-evaluation-cache"
+
+// maxConfigMapUpdateAttempts limits the attempts to update the ConfigMap of the evaluation cache if it is changed
+// concurrently
+const maxConfigMapUpdateAttempts = 5
+
+// maxEvaluationCacheEntries limits the size of the evaluation cache; the oldest entries are evicted first
+const maxEvaluationCacheEntries = 500
+
+// EvaluationCacheEntry describes an evaluation that has already been sent as evaluation-done event
+type EvaluationCacheEntry struct {
+	EventID string    `json:"eventId"`
+	Result  string    `json:"result"`
+	Time    time.Time `json:"time"`
+}
+
+// EvaluationCache remembers the evaluations that have been completed, so that re-delivered get-sli.done events do
+// not result in another evaluation-done event
+type EvaluationCache interface {
+	Get(keptnContext string, stage string, service string) (*EvaluationCacheEntry, error)
+	// Claim stores the entry of an evaluation unless the evaluation has already been claimed, which is checked
+	// atomically (also across replicas of the service). It returns the existing entry if the evaluation has already
+	// been claimed, and nil if the entry has been stored
+	Claim(keptnContext string, stage string, service string, entry *EvaluationCacheEntry) (*EvaluationCacheEntry, error)
+	// Release removes the entry of an evaluation if it belongs to the given evaluation-done event, e.g. because the
+	// event could not be sent
+	Release(keptnContext string, stage string, service string, eventID string) error
+}
+
+var invalidCacheKeyCharacters = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// evaluationCacheKey returns a key for the evaluation of a service in a stage that is valid in a ConfigMap
+func evaluationCacheKey(keptnContext string, stage string, service string) string {
+	return invalidCacheKeyCharacters.ReplaceAllString(keptnContext+"."+stage+"."+service, "_")
+}
+
+// InMemoryEvaluationCache is an EvaluationCache that does not survive restarts of the service
+type InMemoryEvaluationCache struct {
+	mutex   sync.Mutex
+	entries map[string]*EvaluationCacheEntry
+}
+
+// NewInMemoryEvaluationCache creates an empty InMemoryEvaluationCache
+func NewInMemoryEvaluationCache() *InMemoryEvaluationCache {
+	return &InMemoryEvaluationCache{entries: map[string]*EvaluationCacheEntry{}}
+}
+
+func (c *InMemoryEvaluationCache) Get(keptnContext string, stage string, service string) (*EvaluationCacheEntry, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.entries[evaluationCacheKey(keptnContext, stage, service)], nil
+}
+
+func (c *InMemoryEvaluationCache) Claim(keptnContext string, stage string, service string, entry *EvaluationCacheEntry) (*EvaluationCacheEntry, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := evaluationCacheKey(keptnContext, stage, service)
+	if existing, ok := c.entries[key]; ok {
+		return existing, nil
+	}
+	c.entries[key] = entry
+	evictEvaluationCacheEntries(c.entries)
+	return nil, nil
+}
+
+func (c *InMemoryEvaluationCache) Release(keptnContext string, stage string, service string, eventID string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := evaluationCacheKey(keptnContext, stage, service)
+	if entry, ok := c.entries[key]; ok && entry.EventID == eventID {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// ConfigMapEvaluationCache is an EvaluationCache that persists its entries in a ConfigMap in the keptn namespace
+type ConfigMapEvaluationCache struct {
+	mutex sync.Mutex
+	store *configMapStore
+}
+
+// NewConfigMapEvaluationCache creates a ConfigMapEvaluationCache using the in-cluster configuration
+func NewConfigMapEvaluationCache() (*ConfigMapEvaluationCache, error) {
+	kubeAPI, err := keptnutils.GetKubeAPI(true)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigMapEvaluationCache(kubeAPI.ConfigMaps("keptn")), nil
+}
+
+func newConfigMapEvaluationCache(configMaps configMapClient) *ConfigMapEvaluationCache {
+	return &ConfigMapEvaluationCache{store: &configMapStore{configMaps: configMaps, name: evaluationCacheConfigMap}}
+}
+
+func (c *ConfigMapEvaluationCache) Get(keptnContext string, stage string, service string) (*EvaluationCacheEntry, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entries, _, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return entries[evaluationCacheKey(keptnContext, stage, service)], nil
+}
+
+func (c *ConfigMapEvaluationCache) Claim(keptnContext string, stage string, service string, entry *EvaluationCacheEntry) (*EvaluationCacheEntry, error) {
+	key := evaluationCacheKey(keptnContext, stage, service)
+	var existing *EvaluationCacheEntry
+	err := c.update(func(entries map[string]*EvaluationCacheEntry) bool {
+		if existing = entries[key]; existing != nil {
+			return false
+		}
+		entries[key] = entry
+		evictEvaluationCacheEntries(entries)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (c *ConfigMapEvaluationCache) Release(keptnContext string, stage string, service string, eventID string) error {
+	key := evaluationCacheKey(keptnContext, stage, service)
+	return c.update(func(entries map[string]*EvaluationCacheEntry) bool {
+		if entry, ok := entries[key]; !ok || entry.EventID != eventID {
+			return false
+		}
+		delete(entries, key)
+		return true
+	})
+}
+
+// update applies change to the entries of the ConfigMap and saves them if change returns true. The ConfigMap is only
+// replaced if it has not been changed since it has been read, as the update contains its resourceVersion (and
+// creating it fails if it has been created in the meantime). Hence, concurrent changes, e.g. by other replicas of
+// the service, are not overwritten, but change is applied again to the changed entries
+func (c *ConfigMapEvaluationCache) update(change func(entries map[string]*EvaluationCacheEntry) bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var err error
+	for attempt := 0; attempt < maxConfigMapUpdateAttempts; attempt++ {
+		entries, configMap, loadErr := c.load()
+		if loadErr != nil {
+			return loadErr
+		}
+		if !change(entries) {
+			return nil
+		}
+		storedEntries := map[string]interface{}{}
+		for key, entry := range entries {
+			storedEntries[key] = entry
+		}
+		err = c.store.save(storedEntries, configMap)
+		if !k8serrors.IsConflict(err) && !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// load reads the entries of the ConfigMap
+func (c *ConfigMapEvaluationCache) load() (map[string]*EvaluationCacheEntry, *v1.ConfigMap, error) {
+	storedEntries, configMap, err := c.store.load(newEvaluationCacheEntry)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries := map[string]*EvaluationCacheEntry{}
+	for key, storedEntry := range storedEntries {
+		entries[key] = storedEntry.(*EvaluationCacheEntry)
+	}
+	return entries, configMap, nil
+}
+
+func newEvaluationCacheEntry() interface{} {
+	return &EvaluationCacheEntry{}
+}
+
+func evictEvaluationCacheEntries(entries map[string]*EvaluationCacheEntry) {
+	if len(entries) <= maxEvaluationCacheEntries {
+		return
+	}
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return entries[keys[i]].Time.Before(entries[keys[j]].Time)
+	})
+	for _, key := range keys[:len(keys)-maxEvaluationCacheEntries] {
+		delete(entries, key)
+	}
+}
+
+// sendEvaluationDoneOnce sends the evaluation-done event of an evaluation unless it has already been sent. The
+// evaluation is claimed in the cache before the event is sent, hence get-sli.done events that are re-delivered and
+// processed concurrently (also by other replicas) result in one event only. If the event cannot be sent, the claim is
+// released, so that the evaluation can be sent again. False is returned if the event has already been sent before
+func sendEvaluationDoneOnce(logger *keptnutils.Logger, cache EvaluationCache, shkeptncontext string, data *keptnevents.EvaluationDoneEventData,
+	now time.Time, send func(event cloudevents.Event) error) (bool, error) {
+
+	eventID := uuid.New().String()
+	if cache != nil {
+		claimedEvaluation, err := cache.Claim(shkeptncontext, data.Stage, data.Service, &EvaluationCacheEntry{
+			EventID: eventID,
+			Result:  data.Result,
+			Time:    now,
+		})
+		if err != nil {
+			// sending the event twice is preferred to not sending it at all
+			logger.Error("Could not write evaluation cache: " + err.Error())
+		} else if claimedEvaluation != nil {
+			logger.Info(fmt.Sprintf("Evaluation of service %s in stage %s has already been sent in event %s, not sending it again",
+				data.Service, data.Stage, claimedEvaluation.EventID))
+			return false, nil
+		}
+	}
+
+	logger.Debug("Send event: " + keptnevents.EvaluationDoneEventType)
+	if err := send(getEvaluationDoneEvent(shkeptncontext, eventID, data)); err != nil {
+		if cache != nil {
+			if releaseErr := cache.Release(shkeptncontext, data.Stage, data.Service, eventID); releaseErr != nil {
+				logger.Error("Could not write evaluation cache: " + releaseErr.Error())
+			}
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+var defaultEvaluationCache EvaluationCache
+var defaultEvaluationCacheOnce sync.Once
+
+// getDefaultEvaluationCache returns the evaluation cache shared by all handlers. If the ConfigMap cannot be accessed,
+// e.g. when running outside of the cluster, the cache is kept in memory
+func getDefaultEvaluationCache(logger *keptnutils.Logger) EvaluationCache {
+	defaultEvaluationCacheOnce.Do(func() {
+		cache, err := NewConfigMapEvaluationCache()
+		if err != nil {
+			logger.Info("Could not access the Kubernetes API, evaluation cache is kept in memory: " + err.Error())
+			defaultEvaluationCache = NewInMemoryEvaluationCache()
+			return
+		}
+		defaultEvaluationCache = cache
+	})
+	return defaultEvaluationCache
+}
@@ -0,0 +1,201 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fakeConfigMapClient rejects updates of outdated versions of the ConfigMap like the Kubernetes API
+type fakeConfigMapClient struct {
+	mutex     sync.Mutex
+	configMap *v1.ConfigMap
+	updates   int
+}
+
+func (c *fakeConfigMapClient) Get(name string, options metav1.GetOptions) (*v1.ConfigMap, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.configMap == nil {
+		return nil, k8serrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+	}
+	return c.configMap.DeepCopy(), nil
+}
+
+func (c *fakeConfigMapClient) Create(configMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.configMap != nil {
+		return nil, k8serrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, configMap.Name)
+	}
+	c.configMap = configMap.DeepCopy()
+	c.configMap.ResourceVersion = "1"
+	return c.configMap.DeepCopy(), nil
+}
+
+func (c *fakeConfigMapClient) Update(configMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.configMap == nil || configMap.ResourceVersion != c.configMap.ResourceVersion {
+		return nil, k8serrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, configMap.Name, errors.New("the object has been modified"))
+	}
+	c.updates++
+	c.configMap = configMap.DeepCopy()
+	c.configMap.ResourceVersion = fmt.Sprint(c.updates + 1)
+	return c.configMap.DeepCopy(), nil
+}
+
+func TestConfigMapEvaluationCache(t *testing.T) {
+	configMaps := &fakeConfigMapClient{}
+	cache := newConfigMapEvaluationCache(configMaps)
+
+	entry, err := cache.Get("b8dd2a4c-9e1d-4d6a-9a0c-6c0b3ad6b4a1", "dev", "carts")
+	assert.Nil(t, err)
+	assert.Nil(t, entry)
+
+	evaluationTime := time.Date(2020, 1, 20, 10, 0, 0, 0, time.UTC)
+	entry, err = cache.Claim("b8dd2a4c-9e1d-4d6a-9a0c-6c0b3ad6b4a1", "dev", "carts", &EvaluationCacheEntry{EventID: "1", Result: "pass", Time: evaluationTime})
+	assert.Nil(t, err)
+	assert.Nil(t, entry)
+	entry, err = cache.Claim("b8dd2a4c-9e1d-4d6a-9a0c-6c0b3ad6b4a1", "staging", "carts", &EvaluationCacheEntry{EventID: "2", Result: "fail", Time: evaluationTime})
+	assert.Nil(t, err)
+	assert.Nil(t, entry)
+	assert.Equal(t, 1, configMaps.updates)
+
+	// an evaluation that has been claimed cannot be claimed again
+	entry, err = cache.Claim("b8dd2a4c-9e1d-4d6a-9a0c-6c0b3ad6b4a1", "dev", "carts", &EvaluationCacheEntry{EventID: "3", Result: "fail", Time: evaluationTime})
+	assert.Nil(t, err)
+	assert.EqualValues(t, &EvaluationCacheEntry{EventID: "1", Result: "pass", Time: evaluationTime}, entry)
+	assert.Len(t, configMaps.configMap.Data, 2)
+
+	// a new cache instance, e.g. after a restart, reads the persisted entries
+	cache = newConfigMapEvaluationCache(configMaps)
+	entry, err = cache.Get("b8dd2a4c-9e1d-4d6a-9a0c-6c0b3ad6b4a1", "dev", "carts")
+	assert.Nil(t, err)
+	assert.EqualValues(t, &EvaluationCacheEntry{EventID: "1", Result: "pass", Time: evaluationTime}, entry)
+
+	entry, err = cache.Get("b8dd2a4c-9e1d-4d6a-9a0c-6c0b3ad6b4a1", "production", "carts")
+	assert.Nil(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestEvictEvaluationCacheEntries(t *testing.T) {
+	cache := NewInMemoryEvaluationCache()
+	start := time.Date(2020, 1, 20, 10, 0, 0, 0, time.UTC)
+	for i := 0; i <= maxEvaluationCacheEntries; i++ {
+		_, _ = cache.Claim(fmt.Sprintf("context-%d", i), "dev", "carts", &EvaluationCacheEntry{EventID: fmt.Sprint(i), Time: start.Add(time.Duration(i) * time.Minute)})
+	}
+
+	assert.Len(t, cache.entries, maxEvaluationCacheEntries)
+	entry, _ := cache.Get("context-0", "dev", "carts")
+	assert.Nil(t, entry)
+	entry, _ = cache.Get(fmt.Sprintf("context-%d", maxEvaluationCacheEntries), "dev", "carts")
+	assert.NotNil(t, entry)
+}
+
+func TestEvaluationCacheKey(t *testing.T) {
+	assert.Equal(t, "my-context.dev.carts", evaluationCacheKey("my-context", "dev", "carts"))
+	assert.Equal(t, "my_context.dev.carts", evaluationCacheKey("my:context", "dev", "carts"))
+}
+
+func TestEvaluateSLIHandlerIgnoresRedeliveredEvent(t *testing.T) {
+	cache := NewInMemoryEvaluationCache()
+	_, _ = cache.Claim("my-context", "dev", "carts", &EvaluationCacheEntry{EventID: "1", Result: "pass", Time: time.Now()})
+
+	event := cloudevents.New(cloudevents.CloudEventsVersionV02)
+	event.SetType(keptnevents.InternalGetSLIDoneEventType)
+	event.SetExtension("shkeptncontext", "my-context")
+	_ = event.SetData(&keptnevents.InternalGetSLIDoneEventData{Project: "sockshop", Stage: "dev", Service: "carts"})
+
+	eh := &EvaluateSLIHandler{
+		Logger:          keptnutils.NewLogger("my-context", "", "lighthouse-service"),
+		Event:           event,
+		EvaluationCache: cache,
+	}
+
+	// the evaluation would fail without a configuration-service if the event were not recognized as re-delivered
+	assert.Nil(t, eh.HandleEvent())
+}
+
+func TestEvaluationCacheRelease(t *testing.T) {
+	for name, cache := range map[string]EvaluationCache{
+		"in memory": NewInMemoryEvaluationCache(),
+		"ConfigMap": newConfigMapEvaluationCache(&fakeConfigMapClient{}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _ = cache.Claim("my-context", "dev", "carts", &EvaluationCacheEntry{EventID: "1", Result: "pass", Time: time.Now()})
+
+			// the claim of another event is kept
+			assert.Nil(t, cache.Release("my-context", "dev", "carts", "2"))
+			entry, _ := cache.Get("my-context", "dev", "carts")
+			assert.NotNil(t, entry)
+
+			assert.Nil(t, cache.Release("my-context", "dev", "carts", "1"))
+			entry, _ = cache.Get("my-context", "dev", "carts")
+			assert.Nil(t, entry)
+		})
+	}
+}
+
+// TestSendEvaluationDoneOnceWithConcurrentRedeliveries checks whether get-sli.done events that are processed
+// concurrently by several replicas, each with its own cache instance, result in one evaluation-done event
+func TestSendEvaluationDoneOnceWithConcurrentRedeliveries(t *testing.T) {
+	configMaps := &fakeConfigMapClient{}
+	logger := keptnutils.NewLogger("my-context", "", "lighthouse-service")
+	data := &keptnevents.EvaluationDoneEventData{Project: "sockshop", Stage: "dev", Service: "carts", Result: "pass"}
+
+	var sent int32
+	send := func(event cloudevents.Event) error {
+		atomic.AddInt32(&sent, 1)
+		return nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache := newConfigMapEvaluationCache(configMaps)
+			_, err := sendEvaluationDoneOnce(logger, cache, "my-context", data, time.Now(), send)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, sent)
+}
+
+// TestSendEvaluationDoneOnceWithFailedEvent checks whether an evaluation-done event that could not be sent is sent by
+// the next attempt
+func TestSendEvaluationDoneOnceWithFailedEvent(t *testing.T) {
+	cache := NewInMemoryEvaluationCache()
+	logger := keptnutils.NewLogger("my-context", "", "lighthouse-service")
+	data := &keptnevents.EvaluationDoneEventData{Project: "sockshop", Stage: "dev", Service: "carts", Result: "pass"}
+
+	sent, err := sendEvaluationDoneOnce(logger, cache, "my-context", data, time.Now(), func(event cloudevents.Event) error {
+		return errors.New("event broker not available")
+	})
+	assert.False(t, sent)
+	assert.NotNil(t, err)
+
+	sent, err = sendEvaluationDoneOnce(logger, cache, "my-context", data, time.Now(), func(event cloudevents.Event) error {
+		return nil
+	})
+	assert.True(t, sent)
+	assert.Nil(t, err)
+	sent, err = sendEvaluationDoneOnce(logger, cache, "my-context", data, time.Now(), func(event cloudevents.Event) error {
+		return nil
+	})
+	assert.False(t, sent)
+	assert.Nil(t, err)
+}
@@ -17,11 +17,11 @@ func NewEventHandler(event cloudevents.Event, logger *keptnutils.Logger) (Evalua
 	logger.Debug("Received event: " + event.Type())
 	switch event.Type() {
 	case keptnevents.TestsFinishedEventType:
-		return &StartEvaluationHandler{Logger: logger, Event: event}, nil
+		return &StartEvaluationHandler{Logger: logger, Event: event, PendingEvaluations: getDefaultPendingEvaluationStore(logger)}, nil
 	case keptnevents.StartEvaluationEventType:
-		return &StartEvaluationHandler{Logger: logger, Event: event}, nil // new event type in Keptn versions >= 0.6
+		return &StartEvaluationHandler{Logger: logger, Event: event, PendingEvaluations: getDefaultPendingEvaluationStore(logger)}, nil // new event type in Keptn versions >= 0.6
 	case keptnevents.InternalGetSLIDoneEventType:
-		return &EvaluateSLIHandler{Logger: logger, Event: event, HTTPClient: &http.Client{}}, nil
+		return &EvaluateSLIHandler{Logger: logger, Event: event, HTTPClient: &http.Client{}, EvaluationCache: getDefaultEvaluationCache(logger), PendingEvaluations: getDefaultPendingEvaluationStore(logger)}, nil
 	case keptnevents.ConfigureMonitoringEventType:
 		return &ConfigureMonitoringHandler{Logger: logger, Event: event}, nil
 	default:
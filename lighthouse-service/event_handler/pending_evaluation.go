@@ -0,0 +1,282 @@
+package event_handler
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+)
+
+const pendingEvaluationsConfigMap = "lighthouse-pending-evaluations"
+
+// PendingEvaluation is an evaluation whose SLI values have been requested from the SLI providers
+type PendingEvaluation struct {
+	KeptnContext       string            `json:"keptnContext"`
+	Project            string            `json:"project"`
+	Stage              string            `json:"stage"`
+	Service            string            `json:"service"`
+	Start              string            `json:"start"`
+	End                string            `json:"end"`
+	TestStrategy       string            `json:"teststrategy"`
+	DeploymentStrategy string            `json:"deploymentstrategy"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	// Providers contains the indicators requested from each SLI provider
+	Providers map[string][]string `json:"providers"`
+	// Received contains the SLI providers that have sent their get-sli.done event
+	Received        []string                 `json:"received"`
+	IndicatorValues []*keptnevents.SLIResult `json:"indicatorValues"`
+	Created         time.Time                `json:"created"`
+	// Deadline is the time until all SLI providers have to respond
+	Deadline time.Time `json:"deadline"`
+}
+
+// addResult merges the SLI values of the get-sli.done event of an SLI provider into the pending evaluation. Values of
+// SLI providers that have not been requested or already responded are ignored. If a single SLI provider has been
+// requested, it does not need to be identified by the event
+func (p *PendingEvaluation) addResult(provider string, indicatorValues []*keptnevents.SLIResult) {
+	if _, ok := p.Providers[provider]; !ok {
+		if len(p.Providers) != 1 {
+			return
+		}
+		for name := range p.Providers {
+			provider = name
+		}
+	}
+	for _, received := range p.Received {
+		if received == provider {
+			return
+		}
+	}
+	p.Received = append(p.Received, provider)
+	p.IndicatorValues = append(p.IndicatorValues, indicatorValues...)
+}
+
+// getSLIProvider returns the SLI provider that sent a get-sli.done event. SLI providers echo the sliProvider of the
+// get-sli event; for SLI providers that do not, it is derived from the source of the event, e.g. dynatrace for
+// dynatrace-sli-service
+func getSLIProvider(event cloudevents.Event) string {
+	data := &struct {
+		SLIProvider string `json:"sliProvider"`
+	}{}
+	if err := event.DataAs(data); err == nil && data.SLIProvider != "" {
+		return data.SLIProvider
+	}
+	return strings.TrimSuffix(event.Source(), "-sli-service")
+}
+
+// isComplete returns true once all SLI providers have sent their get-sli.done event
+func (p *PendingEvaluation) isComplete() bool {
+	return len(p.Received) >= len(p.Providers)
+}
+
+// missingProviders returns the SLI providers that have not sent their get-sli.done event
+func (p *PendingEvaluation) missingProviders() []string {
+	received := map[string]bool{}
+	for _, provider := range p.Received {
+		received[provider] = true
+	}
+	var providers []string
+	for provider := range p.Providers {
+		if !received[provider] {
+			providers = append(providers, provider)
+		}
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// PendingEvaluationStore keeps track of the evaluations that are waiting for the SLI values of the SLI providers
+type PendingEvaluationStore interface {
+	// Add stores a new pending evaluation
+	Add(evaluation *PendingEvaluation) error
+	// AddResult merges the SLI values of the get-sli.done event of an SLI provider into the pending evaluation of the
+	// service. Once all SLI providers have responded, the pending evaluation is returned with complete set to true. It
+	// is kept until it is removed after its evaluation-done event has been sent, hence it still times out if the
+	// evaluation fails. If no evaluation is pending, nil is returned
+	AddResult(keptnContext string, stage string, service string, provider string, indicatorValues []*keptnevents.SLIResult) (evaluation *PendingEvaluation, complete bool, err error)
+	// Remove removes the pending evaluation of the service
+	Remove(keptnContext string, stage string, service string) error
+	// RemoveExpired removes and returns the pending evaluations whose deadline has passed
+	RemoveExpired(now time.Time) ([]*PendingEvaluation, error)
+}
+
+// InMemoryPendingEvaluationStore is a PendingEvaluationStore that does not survive restarts of the service
+type InMemoryPendingEvaluationStore struct {
+	mutex       sync.Mutex
+	evaluations map[string]*PendingEvaluation
+}
+
+// NewInMemoryPendingEvaluationStore creates an empty InMemoryPendingEvaluationStore
+func NewInMemoryPendingEvaluationStore() *InMemoryPendingEvaluationStore {
+	return &InMemoryPendingEvaluationStore{evaluations: map[string]*PendingEvaluation{}}
+}
+
+func (s *InMemoryPendingEvaluationStore) Add(evaluation *PendingEvaluation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.evaluations[evaluationCacheKey(evaluation.KeptnContext, evaluation.Stage, evaluation.Service)] = evaluation
+	return nil
+}
+
+func (s *InMemoryPendingEvaluationStore) AddResult(keptnContext string, stage string, service string, provider string, indicatorValues []*keptnevents.SLIResult) (*PendingEvaluation, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := evaluationCacheKey(keptnContext, stage, service)
+	evaluation, ok := s.evaluations[key]
+	if !ok {
+		return nil, false, nil
+	}
+	evaluation.addResult(provider, indicatorValues)
+	return evaluation, evaluation.isComplete(), nil
+}
+
+func (s *InMemoryPendingEvaluationStore) Remove(keptnContext string, stage string, service string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.evaluations, evaluationCacheKey(keptnContext, stage, service))
+	return nil
+}
+
+func (s *InMemoryPendingEvaluationStore) RemoveExpired(now time.Time) ([]*PendingEvaluation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return removeExpiredEvaluations(s.evaluations, now), nil
+}
+
+func removeExpiredEvaluations(evaluations map[string]*PendingEvaluation, now time.Time) []*PendingEvaluation {
+	var expired []*PendingEvaluation
+	for key, evaluation := range evaluations {
+		if !evaluation.Deadline.IsZero() && now.After(evaluation.Deadline) {
+			expired = append(expired, evaluation)
+			delete(evaluations, key)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].Deadline.Before(expired[j].Deadline)
+	})
+	return expired
+}
+
+// ConfigMapPendingEvaluationStore is a PendingEvaluationStore that persists the pending evaluations in a ConfigMap in
+// the keptn namespace, so that outstanding SLI requests are still tracked after a restart of the service
+type ConfigMapPendingEvaluationStore struct {
+	mutex sync.Mutex
+	store *configMapStore
+}
+
+// NewConfigMapPendingEvaluationStore creates a ConfigMapPendingEvaluationStore using the in-cluster configuration
+func NewConfigMapPendingEvaluationStore() (*ConfigMapPendingEvaluationStore, error) {
+	kubeAPI, err := keptnutils.GetKubeAPI(true)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigMapPendingEvaluationStore(kubeAPI.ConfigMaps("keptn")), nil
+}
+
+func newConfigMapPendingEvaluationStore(configMaps configMapClient) *ConfigMapPendingEvaluationStore {
+	return &ConfigMapPendingEvaluationStore{store: &configMapStore{configMaps: configMaps, name: pendingEvaluationsConfigMap}}
+}
+
+func (s *ConfigMapPendingEvaluationStore) Add(evaluation *PendingEvaluation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	evaluations, configMap, err := s.load()
+	if err != nil {
+		return err
+	}
+	evaluations[evaluationCacheKey(evaluation.KeptnContext, evaluation.Stage, evaluation.Service)] = evaluation
+	return s.save(evaluations, configMap)
+}
+
+func (s *ConfigMapPendingEvaluationStore) AddResult(keptnContext string, stage string, service string, provider string, indicatorValues []*keptnevents.SLIResult) (*PendingEvaluation, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	evaluations, configMap, err := s.load()
+	if err != nil {
+		return nil, false, err
+	}
+	key := evaluationCacheKey(keptnContext, stage, service)
+	evaluation, ok := evaluations[key]
+	if !ok {
+		return nil, false, nil
+	}
+	evaluation.addResult(provider, indicatorValues)
+	return evaluation, evaluation.isComplete(), s.save(evaluations, configMap)
+}
+
+func (s *ConfigMapPendingEvaluationStore) Remove(keptnContext string, stage string, service string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	evaluations, configMap, err := s.load()
+	if err != nil {
+		return err
+	}
+	key := evaluationCacheKey(keptnContext, stage, service)
+	if _, ok := evaluations[key]; !ok {
+		return nil
+	}
+	delete(evaluations, key)
+	return s.save(evaluations, configMap)
+}
+
+func (s *ConfigMapPendingEvaluationStore) RemoveExpired(now time.Time) ([]*PendingEvaluation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	evaluations, configMap, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	expired := removeExpiredEvaluations(evaluations, now)
+	if len(expired) == 0 {
+		return nil, nil
+	}
+	return expired, s.save(evaluations, configMap)
+}
+
+func (s *ConfigMapPendingEvaluationStore) load() (map[string]*PendingEvaluation, *v1.ConfigMap, error) {
+	storedEvaluations, configMap, err := s.store.load(func() interface{} { return &PendingEvaluation{} })
+	if err != nil {
+		return nil, nil, err
+	}
+	evaluations := map[string]*PendingEvaluation{}
+	for key, storedEvaluation := range storedEvaluations {
+		evaluations[key] = storedEvaluation.(*PendingEvaluation)
+	}
+	return evaluations, configMap, nil
+}
+
+func (s *ConfigMapPendingEvaluationStore) save(evaluations map[string]*PendingEvaluation, configMap *v1.ConfigMap) error {
+	storedEvaluations := map[string]interface{}{}
+	for key, evaluation := range evaluations {
+		storedEvaluations[key] = evaluation
+	}
+	return s.store.save(storedEvaluations, configMap)
+}
+
+var defaultPendingEvaluationStore PendingEvaluationStore
+var defaultPendingEvaluationStoreOnce sync.Once
+
+// getDefaultPendingEvaluationStore returns the store of pending evaluations shared by all handlers. If the ConfigMap
+// cannot be accessed, e.g. when running outside of the cluster, the pending evaluations are kept in memory
+func getDefaultPendingEvaluationStore(logger *keptnutils.Logger) PendingEvaluationStore {
+	defaultPendingEvaluationStoreOnce.Do(func() {
+		store, err := NewConfigMapPendingEvaluationStore()
+		if err != nil {
+			logger.Info("Could not access the Kubernetes API, pending evaluations are kept in memory: " + err.Error())
+			defaultPendingEvaluationStore = NewInMemoryPendingEvaluationStore()
+			return
+		}
+		defaultPendingEvaluationStore = store
+	})
+	return defaultPendingEvaluationStore
+}
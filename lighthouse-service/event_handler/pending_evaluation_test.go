@@ -0,0 +1,155 @@
+package event_handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPendingEvaluation() *PendingEvaluation {
+	return &PendingEvaluation{
+		KeptnContext: "my-context",
+		Project:      "sockshop",
+		Stage:        "staging",
+		Service:      "carts",
+		Providers: map[string][]string{
+			"dynatrace":  {"response_time_p95", "error_rate"},
+			"prometheus": {"throughput"},
+		},
+		Created: time.Now(),
+	}
+}
+
+func TestInMemoryPendingEvaluationStore(t *testing.T) {
+	store := NewInMemoryPendingEvaluationStore()
+
+	evaluation, complete, err := store.AddResult("my-context", "staging", "carts", "dynatrace", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, evaluation)
+	assert.False(t, complete)
+
+	_ = store.Add(newPendingEvaluation())
+
+	throughput := &keptnevents.SLIResult{Metric: "throughput", Value: 1000, Success: true}
+	evaluation, complete, err = store.AddResult("my-context", "staging", "carts", "prometheus", []*keptnevents.SLIResult{throughput})
+	assert.Nil(t, err)
+	assert.False(t, complete)
+	assert.EqualValues(t, []string{"prometheus"}, evaluation.Received)
+
+	// a re-delivered event of the same SLI provider is ignored
+	evaluation, complete, err = store.AddResult("my-context", "staging", "carts", "prometheus", []*keptnevents.SLIResult{throughput})
+	assert.Nil(t, err)
+	assert.False(t, complete)
+	assert.Len(t, evaluation.IndicatorValues, 1)
+
+	responseTime := &keptnevents.SLIResult{Metric: "response_time_p95", Value: 200, Success: true}
+	errorRate := &keptnevents.SLIResult{Metric: "error_rate", Success: false, Message: "no data"}
+	evaluation, complete, err = store.AddResult("my-context", "staging", "carts", "dynatrace", []*keptnevents.SLIResult{responseTime, errorRate})
+	assert.Nil(t, err)
+	assert.True(t, complete)
+	assert.EqualValues(t, []*keptnevents.SLIResult{throughput, responseTime, errorRate}, evaluation.IndicatorValues)
+
+	// the completed evaluation is kept until it is removed, hence a re-delivered event is evaluated with all values
+	evaluation, complete, err = store.AddResult("my-context", "staging", "carts", "prometheus", []*keptnevents.SLIResult{throughput})
+	assert.Nil(t, err)
+	assert.True(t, complete)
+	assert.EqualValues(t, []*keptnevents.SLIResult{throughput, responseTime, errorRate}, evaluation.IndicatorValues)
+
+	assert.Nil(t, store.Remove("my-context", "staging", "carts"))
+	evaluation, _, _ = store.AddResult("my-context", "staging", "carts", "dynatrace", nil)
+	assert.Nil(t, evaluation)
+}
+
+func TestPendingEvaluationWithEmptyResult(t *testing.T) {
+	evaluation := newPendingEvaluation()
+	// SLI values of SLI providers that have not been requested are ignored
+	evaluation.addResult("datadog", []*keptnevents.SLIResult{{Metric: "throughput", Value: 1000, Success: true}})
+	assert.Empty(t, evaluation.Received)
+
+	// the SLI provider is identified by its name, not by the SLI values it returned
+	evaluation.addResult("prometheus", nil)
+	evaluation.addResult("dynatrace", []*keptnevents.SLIResult{{Metric: "throughput", Value: 1000, Success: true}})
+	assert.EqualValues(t, []string{"prometheus", "dynatrace"}, evaluation.Received)
+	assert.True(t, evaluation.isComplete())
+
+	// a single SLI provider does not need to be identified
+	evaluation = newPendingEvaluation()
+	evaluation.Providers = map[string][]string{"dynatrace": {"response_time_p95"}}
+	evaluation.addResult("dynatrace-service", nil)
+	assert.EqualValues(t, []string{"dynatrace"}, evaluation.Received)
+}
+
+func TestGetSLIProvider(t *testing.T) {
+	event := cloudevents.New(cloudevents.CloudEventsVersionV02)
+	event.SetSource("prometheus-sli-service")
+	_ = event.SetData(map[string]interface{}{"sliProvider": "prometheus-eu"})
+	assert.Equal(t, "prometheus-eu", getSLIProvider(event))
+
+	// SLI providers that do not echo the sliProvider of the get-sli event are identified by the source of the event
+	_ = event.SetData(&keptnevents.InternalGetSLIDoneEventData{Project: "sockshop"})
+	assert.Equal(t, "prometheus", getSLIProvider(event))
+}
+
+func TestEvaluateSLIHandlerWaitsForAllSLIProviders(t *testing.T) {
+	store := NewInMemoryPendingEvaluationStore()
+	_ = store.Add(newPendingEvaluation())
+
+	event := cloudevents.New(cloudevents.CloudEventsVersionV02)
+	event.SetType(keptnevents.InternalGetSLIDoneEventType)
+	event.SetSource("prometheus-sli-service")
+	event.SetExtension("shkeptncontext", "my-context")
+	_ = event.SetData(&keptnevents.InternalGetSLIDoneEventData{
+		Project:         "sockshop",
+		Stage:           "staging",
+		Service:         "carts",
+		IndicatorValues: []*keptnevents.SLIResult{{Metric: "throughput", Value: 1000, Success: true}},
+	})
+
+	eh := &EvaluateSLIHandler{
+		Logger:             keptnutils.NewLogger("my-context", "", "lighthouse-service"),
+		Event:              event,
+		PendingEvaluations: store,
+	}
+
+	// the evaluation would fail without a configuration-service if it were not waiting for the dynatrace SLIs
+	assert.Nil(t, eh.HandleEvent())
+}
+
+func TestEvaluateSLIHandlerKeepsFailedEvaluation(t *testing.T) {
+	store := NewInMemoryPendingEvaluationStore()
+	pendingEvaluation := newPendingEvaluation()
+	pendingEvaluation.Deadline = pendingEvaluation.Created.Add(time.Minute)
+	_ = store.Add(pendingEvaluation)
+	_, _, _ = store.AddResult("my-context", "staging", "carts", "prometheus", []*keptnevents.SLIResult{{Metric: "throughput", Value: 1000, Success: true}})
+
+	event := cloudevents.New(cloudevents.CloudEventsVersionV02)
+	event.SetType(keptnevents.InternalGetSLIDoneEventType)
+	event.SetSource("dynatrace-sli-service")
+	event.SetExtension("shkeptncontext", "my-context")
+	_ = event.SetData(&keptnevents.InternalGetSLIDoneEventData{
+		Project:         "sockshop",
+		Stage:           "staging",
+		Service:         "carts",
+		IndicatorValues: []*keptnevents.SLIResult{{Metric: "response_time_p95", Value: 200, Success: true}},
+	})
+
+	eh := &EvaluateSLIHandler{
+		Logger:             keptnutils.NewLogger("my-context", "", "lighthouse-service"),
+		Event:              event,
+		PendingEvaluations: store,
+	}
+
+	// the evaluation fails without a configuration-service, but the merged SLI values are kept and time out
+	assert.NotNil(t, eh.HandleEvent())
+	expired, err := store.RemoveExpired(pendingEvaluation.Deadline.Add(time.Second))
+	assert.Nil(t, err)
+	if assert.Len(t, expired, 1) {
+		assert.Len(t, expired[0].IndicatorValues, 2)
+		assert.Equal(t, "evaluation failed: the SLI values could not be evaluated within 1m0s",
+			getTimeoutEvaluationResult(expired[0]).EvaluationDetails.Result)
+	}
+}
@@ -0,0 +1,126 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const sliProviderKey = "sli-provider"
+
+// getSLIProviders determines the SLI providers (e.g. 'dynatrace' or 'prometheus') that have been configured for a
+// service in a stage and assigns the indicators to them
+func getSLIProviders(project string, stage string, service string, indicators []string) (map[string][]string, error) {
+	kubeClient, err := keptnutils.GetKubeAPI(true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	configMap, err := kubeClient.ConfigMaps("keptn").Get("lighthouse-config-"+project, v1.GetOptions{})
+
+	if err != nil {
+		return nil, errors.New("No SLI provider specified for project " + project)
+	}
+
+	return resolveSLIProviders(configMap.Data, project, stage, service, indicators)
+}
+
+// resolveSLIProviders resolves the SLI providers of a service from the data of a lighthouse-config-<project> ConfigMap.
+// The most specific of the following keys is used:
+// - sli-provider.<stage>.<service>
+// - sli-provider.<stage>
+// - sli-provider
+// The value is either the name of a single SLI provider, or a YAML map assigning lists of indicators to SLI providers.
+// An SLI provider with an empty list retrieves all indicators that are not assigned to another SLI provider
+func resolveSLIProviders(data map[string]string, project string, stage string, service string, indicators []string) (map[string][]string, error) {
+	keys := []string{
+		sliProviderKey + "." + stage + "." + service,
+		sliProviderKey + "." + stage,
+		sliProviderKey,
+	}
+	for _, key := range keys {
+		value, ok := data[key]
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		providerConfig, err := parseSLIProviderConfig(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SLI provider configuration %s for project %s: %s", key, project, err.Error())
+		}
+		return assignIndicators(providerConfig, indicators)
+	}
+	return nil, fmt.Errorf("No SLI provider specified for service %s in stage %s in project %s", service, stage, project)
+}
+
+func parseSLIProviderConfig(value string) (map[string][]string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, ":") {
+		return map[string][]string{value: nil}, nil
+	}
+	providerConfig := map[string][]string{}
+	if err := yaml.Unmarshal([]byte(value), &providerConfig); err != nil {
+		return nil, err
+	}
+	return providerConfig, nil
+}
+
+// assignIndicators splits the indicators among the SLI providers. SLI providers without indicators are omitted, unless
+// no indicators are requested at all, in which case the default SLI provider is returned
+func assignIndicators(providerConfig map[string][]string, indicators []string) (map[string][]string, error) {
+	defaultProvider := ""
+	assignedProviders := map[string]string{}
+	providers := make([]string, 0, len(providerConfig))
+	for provider := range providerConfig {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	if len(providers) == 0 {
+		return nil, errors.New("no SLI provider configured")
+	}
+
+	for _, provider := range providers {
+		if len(providerConfig[provider]) == 0 {
+			if defaultProvider != "" {
+				return nil, fmt.Errorf("SLI providers %s and %s both retrieve the remaining SLIs", defaultProvider, provider)
+			}
+			defaultProvider = provider
+			continue
+		}
+		for _, indicator := range providerConfig[provider] {
+			if assignedProvider, ok := assignedProviders[indicator]; ok {
+				return nil, fmt.Errorf("SLI %s is assigned to SLI providers %s and %s", indicator, assignedProvider, provider)
+			}
+			assignedProviders[indicator] = provider
+		}
+	}
+
+	assignment := map[string][]string{}
+	var unassigned []string
+	for _, indicator := range indicators {
+		provider, ok := assignedProviders[indicator]
+		if !ok {
+			provider = defaultProvider
+		}
+		if provider == "" {
+			unassigned = append(unassigned, indicator)
+			continue
+		}
+		assignment[provider] = append(assignment[provider], indicator)
+	}
+	if len(unassigned) > 0 {
+		return nil, fmt.Errorf("no SLI provider configured for SLIs %s", strings.Join(unassigned, ", "))
+	}
+	if len(assignment) == 0 {
+		if defaultProvider == "" {
+			defaultProvider = providers[0]
+		}
+		assignment[defaultProvider] = []string{}
+	}
+	return assignment, nil
+}
@@ -0,0 +1,86 @@
+package event_handler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSLIProviders(t *testing.T) {
+	data := map[string]string{
+		"sli-provider":                 "prometheus",
+		"sli-provider.production":      "dynatrace",
+		"sli-provider.staging.carts":   "dynatrace:\n  - response_time_p95\n  - error_rate\nprometheus: []\n",
+		"sli-provider.staging.orders":  "dynatrace: [response_time_p95]\nprometheus: [throughput]",
+		"sli-provider.hardening.carts": "dynatrace: []\nprometheus: []",
+	}
+	indicators := []string{"response_time_p95", "error_rate", "throughput"}
+
+	tests := []struct {
+		Name              string
+		InStage           string
+		InService         string
+		InIndicators      []string
+		ExpectedProviders map[string][]string
+		ExpectedError     error
+	}{
+		{
+			Name:              "project default",
+			InStage:           "dev",
+			InService:         "carts",
+			InIndicators:      indicators,
+			ExpectedProviders: map[string][]string{"prometheus": indicators},
+		},
+		{
+			Name:              "stage overrides project default",
+			InStage:           "production",
+			InService:         "carts",
+			InIndicators:      indicators,
+			ExpectedProviders: map[string][]string{"dynatrace": indicators},
+		},
+		{
+			Name:         "SLIs of a service split among SLI providers",
+			InStage:      "staging",
+			InService:    "carts",
+			InIndicators: indicators,
+			ExpectedProviders: map[string][]string{
+				"dynatrace":  {"response_time_p95", "error_rate"},
+				"prometheus": {"throughput"},
+			},
+		},
+		{
+			Name:          "SLI without SLI provider",
+			InStage:       "staging",
+			InService:     "orders",
+			InIndicators:  indicators,
+			ExpectedError: errors.New("no SLI provider configured for SLIs error_rate"),
+		},
+		{
+			Name:          "several SLI providers for the remaining SLIs",
+			InStage:       "hardening",
+			InService:     "carts",
+			InIndicators:  indicators,
+			ExpectedError: errors.New("SLI providers dynatrace and prometheus both retrieve the remaining SLIs"),
+		},
+		{
+			Name:              "no indicators requested",
+			InStage:           "staging",
+			InService:         "carts",
+			InIndicators:      []string{},
+			ExpectedProviders: map[string][]string{"prometheus": {}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			providers, err := resolveSLIProviders(data, "sockshop", test.InStage, test.InService, test.InIndicators)
+			assert.EqualValues(t, test.ExpectedError, err)
+			assert.EqualValues(t, test.ExpectedProviders, providers)
+		})
+	}
+}
+
+func TestResolveSLIProvidersWithoutConfiguration(t *testing.T) {
+	_, err := resolveSLIProviders(map[string]string{}, "sockshop", "dev", "carts", []string{"throughput"})
+	assert.EqualValues(t, errors.New("No SLI provider specified for service carts in stage dev in project sockshop"), err)
+}
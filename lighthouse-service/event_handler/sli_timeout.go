@@ -0,0 +1,117 @@
+package event_handler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	"github.com/cloudevents/sdk-go/pkg/cloudevents/types"
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+)
+
+const sliTimeout = "SLI_TIMEOUT"
+const sliTimeoutResult = "SLI_TIMEOUT_RESULT"
+
+const defaultSLITimeout = 10 * time.Minute
+
+// getSLITimeout returns the time the SLI providers have to respond to a get-sli event, e.g. SLI_TIMEOUT=15m
+func getSLITimeout() time.Duration {
+	if timeout, err := time.ParseDuration(os.Getenv(sliTimeout)); err == nil && timeout > 0 {
+		return timeout
+	}
+	return defaultSLITimeout
+}
+
+// getSLITimeoutResult returns the result of evaluations whose SLI providers did not respond in time (fail or error)
+func getSLITimeoutResult() string {
+	if os.Getenv(sliTimeoutResult) == "error" {
+		return "error"
+	}
+	return "fail"
+}
+
+// WatchPendingEvaluations periodically checks for pending evaluations whose SLI providers did not respond in time and
+// sends an evaluation-done event for them, until the context is cancelled
+func WatchPendingEvaluations(ctx context.Context, interval time.Duration) {
+	logger := keptnutils.NewLogger("", "", "lighthouse-service")
+	store := getDefaultPendingEvaluationStore(logger)
+	cache := getDefaultEvaluationCache(logger)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			handleExpiredEvaluations(logger, store, cache, time.Now().UTC(), sendEvent)
+		}
+	}
+}
+
+// handleExpiredEvaluations sends an evaluation-done event for each expired pending evaluation. The evaluation is
+// remembered in the evaluation cache, so that get-sli.done events arriving after the deadline are ignored
+func handleExpiredEvaluations(logger *keptnutils.Logger, store PendingEvaluationStore, cache EvaluationCache, now time.Time, send func(event cloudevents.Event) error) {
+	expired, err := store.RemoveExpired(now)
+	if err != nil {
+		logger.Error("Could not read pending evaluations: " + err.Error())
+		return
+	}
+	for _, evaluation := range expired {
+		evaluationResult := getTimeoutEvaluationResult(evaluation)
+		logger.Error(fmt.Sprintf("Evaluation of service %s in stage %s in project %s timed out: %s",
+			evaluation.Service, evaluation.Stage, evaluation.Project, evaluationResult.EvaluationDetails.Result))
+
+		if _, err := sendEvaluationDoneOnce(logger, cache, evaluation.KeptnContext, evaluationResult, now, send); err != nil {
+			logger.Error("Could not send evaluation-done event: " + err.Error())
+		}
+	}
+}
+
+func getTimeoutEvaluationResult(evaluation *PendingEvaluation) *keptnevents.EvaluationDoneEventData {
+	result := getSLITimeoutResult()
+	timeout := evaluation.Deadline.Sub(evaluation.Created).String()
+	message := fmt.Sprintf("evaluation failed: SLI provider %s did not respond within %s",
+		strings.Join(evaluation.missingProviders(), ", "), timeout)
+	if evaluation.isComplete() {
+		// all SLI providers responded, but the evaluation of their values failed
+		message = fmt.Sprintf("evaluation failed: the SLI values could not be evaluated within %s", timeout)
+	}
+	return &keptnevents.EvaluationDoneEventData{
+		EvaluationDetails: &keptnevents.EvaluationDetails{
+			IndicatorResults: nil,
+			TimeStart:        evaluation.Start,
+			TimeEnd:          evaluation.End,
+			Result:           message,
+		},
+		Result:             result,
+		Project:            evaluation.Project,
+		Service:            evaluation.Service,
+		Stage:              evaluation.Stage,
+		TestStrategy:       evaluation.TestStrategy,
+		DeploymentStrategy: evaluation.DeploymentStrategy,
+		Labels:             evaluation.Labels,
+	}
+}
+
+func getEvaluationDoneEvent(shkeptncontext string, eventID string, data *keptnevents.EvaluationDoneEventData) cloudevents.Event {
+	source, _ := url.Parse("lighthouse-service")
+	contentType := "application/json"
+
+	return cloudevents.Event{
+		Context: cloudevents.EventContextV02{
+			ID:          eventID,
+			Time:        &types.Timestamp{Time: time.Now()},
+			Type:        keptnevents.EvaluationDoneEventType,
+			Source:      types.URLRef{URL: *source},
+			ContentType: &contentType,
+			Extensions:  map[string]interface{}{"shkeptncontext": shkeptncontext},
+		}.AsV02(),
+		Data: data,
+	}
+}
@@ -0,0 +1,92 @@
+package event_handler
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSLITimeout(t *testing.T) {
+	defer os.Unsetenv(sliTimeout)
+
+	os.Setenv(sliTimeout, "")
+	assert.Equal(t, defaultSLITimeout, getSLITimeout())
+	os.Setenv(sliTimeout, "15m")
+	assert.Equal(t, 15*time.Minute, getSLITimeout())
+	os.Setenv(sliTimeout, "fifteen minutes")
+	assert.Equal(t, defaultSLITimeout, getSLITimeout())
+}
+
+func TestHandleExpiredEvaluations(t *testing.T) {
+	created := time.Date(2020, 1, 20, 10, 0, 0, 0, time.UTC)
+	store := newConfigMapPendingEvaluationStore(&fakeConfigMapClient{})
+	cache := NewInMemoryEvaluationCache()
+
+	expiredEvaluation := newPendingEvaluation()
+	expiredEvaluation.TestStrategy = "performance"
+	expiredEvaluation.Created = created
+	expiredEvaluation.Deadline = created.Add(10 * time.Minute)
+	_ = store.Add(expiredEvaluation)
+
+	pendingEvaluation := newPendingEvaluation()
+	pendingEvaluation.Service = "orders"
+	pendingEvaluation.Created = created.Add(5 * time.Minute)
+	pendingEvaluation.Deadline = created.Add(15 * time.Minute)
+	_ = store.Add(pendingEvaluation)
+
+	// the SLI values of prometheus have been received in time
+	_, _, _ = store.AddResult("my-context", "staging", "carts", "prometheus", []*keptnevents.SLIResult{{Metric: "throughput", Value: 1000, Success: true}})
+
+	var sentEvents []cloudevents.Event
+	send := func(event cloudevents.Event) error {
+		sentEvents = append(sentEvents, event)
+		return nil
+	}
+	logger := keptnutils.NewLogger("", "", "lighthouse-service")
+
+	handleExpiredEvaluations(logger, store, cache, created.Add(12*time.Minute), send)
+
+	assert.Len(t, sentEvents, 1)
+	assert.Equal(t, keptnevents.EvaluationDoneEventType, sentEvents[0].Type())
+	evaluationResult := sentEvents[0].Data.(*keptnevents.EvaluationDoneEventData)
+	assert.Equal(t, "fail", evaluationResult.Result)
+	assert.Equal(t, "carts", evaluationResult.Service)
+	assert.Equal(t, "performance", evaluationResult.TestStrategy)
+	assert.Equal(t, "evaluation failed: SLI provider dynatrace did not respond within 10m0s", evaluationResult.EvaluationDetails.Result)
+
+	// get-sli.done events arriving after the deadline are ignored
+	entry, _ := cache.Get("my-context", "staging", "carts")
+	assert.NotNil(t, entry)
+	assert.Equal(t, sentEvents[0].ID(), entry.EventID)
+
+	// the evaluation of orders is still pending
+	handleExpiredEvaluations(logger, store, cache, created.Add(12*time.Minute), send)
+	assert.Len(t, sentEvents, 1)
+	handleExpiredEvaluations(logger, store, cache, created.Add(16*time.Minute), send)
+	assert.Len(t, sentEvents, 2)
+}
+
+func TestConfigMapPendingEvaluationStoreSurvivesRestart(t *testing.T) {
+	configMaps := &fakeConfigMapClient{}
+	evaluation := newPendingEvaluation()
+	evaluation.Deadline = evaluation.Created.Add(time.Minute)
+	_ = newConfigMapPendingEvaluationStore(configMaps).Add(evaluation)
+
+	// a new store, e.g. after a restart, continues tracking the pending evaluation
+	store := newConfigMapPendingEvaluationStore(configMaps)
+	pendingEvaluation, complete, err := store.AddResult("my-context", "staging", "carts", "prometheus", []*keptnevents.SLIResult{{Metric: "throughput", Value: 1000, Success: true}})
+	assert.Nil(t, err)
+	assert.False(t, complete)
+	assert.EqualValues(t, []string{"dynatrace"}, pendingEvaluation.missingProviders())
+
+	expired, err := newConfigMapPendingEvaluationStore(configMaps).RemoveExpired(evaluation.Deadline.Add(time.Second))
+	assert.Nil(t, err)
+	assert.Len(t, expired, 1)
+	assert.EqualValues(t, []string{"prometheus"}, expired[0].Received)
+	assert.Empty(t, configMaps.configMap.Data)
+}
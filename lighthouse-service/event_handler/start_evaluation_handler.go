@@ -11,12 +11,12 @@ import (
 	"github.com/google/uuid"
 	keptnevents "github.com/keptn/go-utils/pkg/events"
 	keptnutils "github.com/keptn/go-utils/pkg/utils"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type StartEvaluationHandler struct {
-	Logger *keptnutils.Logger
-	Event  cloudevents.Event
+	Logger             *keptnutils.Logger
+	Event              cloudevents.Event
+	PendingEvaluations PendingEvaluationStore
 }
 
 func (eh *StartEvaluationHandler) HandleEvent() error {
@@ -57,7 +57,29 @@ func (eh *StartEvaluationHandler) HandleEvent() error {
 
 	// get SLO file
 	objectives, err := getSLOs(e.Project, e.Stage, e.Service)
-	if err != nil {
+	if err != nil && !errors.Is(err, errSLOFileNotFound) {
+		// an invalid SLO file must not let the quality gate pass
+		eh.Logger.Error("Invalid SLO file: " + err.Error())
+		evaluationDetails := keptnevents.EvaluationDetails{
+			IndicatorResults: nil,
+			TimeStart:        e.Start,
+			TimeEnd:          e.End,
+			Result:           fmt.Sprintf("evaluation failed: %s", err.Error()),
+		}
+		evaluationResult := keptnevents.EvaluationDoneEventData{
+			EvaluationDetails:  &evaluationDetails,
+			Result:             "fail",
+			Project:            e.Project,
+			Service:            e.Service,
+			Stage:              e.Stage,
+			TestStrategy:       e.TestStrategy,
+			DeploymentStrategy: e.DeploymentStrategy,
+			Labels:             e.Labels,
+		}
+
+		err = eh.sendEvaluationDoneEvent(keptnContext, &evaluationResult)
+		return err
+	} else if err != nil {
 		// No SLO file found -> no need to evaluate
 		eh.Logger.Debug("No SLO file found, no evaluation conducted")
 		evaluationDetails := keptnevents.EvaluationDetails{
@@ -113,16 +135,61 @@ func (eh *StartEvaluationHandler) HandleEvent() error {
 		}
 	}
 
-	// get the SLI provider that has been configured for the project (e.g. 'dynatrace' or 'prometheus')
-	sliProvider, err := getSLIProvider(e.Project)
+	// get the SLI providers that have been configured for the service (e.g. 'dynatrace' or 'prometheus')
+	sliProviders, err := getSLIProviders(e.Project, e.Stage, e.Service, indicators)
+	if err != nil {
+		// the quality gate must not wait for SLI values that are never requested, hence it fails like a timed out evaluation
+		eh.Logger.Error("Could not determine SLI provider for service " + e.Service + " in stage " + e.Stage + " in project " + e.Project + ": " + err.Error())
+		evaluationDetails := keptnevents.EvaluationDetails{
+			IndicatorResults: nil,
+			TimeStart:        e.Start,
+			TimeEnd:          e.End,
+			Result:           fmt.Sprintf("evaluation failed: could not determine SLI provider: %s", err.Error()),
+		}
+		evaluationResult := keptnevents.EvaluationDoneEventData{
+			EvaluationDetails:  &evaluationDetails,
+			Result:             getSLITimeoutResult(),
+			Project:            e.Project,
+			Service:            e.Service,
+			Stage:              e.Stage,
+			TestStrategy:       e.TestStrategy,
+			DeploymentStrategy: e.DeploymentStrategy,
+			Labels:             e.Labels,
+		}
+
+		err = eh.sendEvaluationDoneEvent(keptnContext, &evaluationResult)
+		return err
+	}
+
+	// the evaluation starts when all SLI providers have sent their get-sli.done event, or fails if they do not respond in time
+	now := time.Now().UTC()
+	err = eh.PendingEvaluations.Add(&PendingEvaluation{
+		KeptnContext:       keptnContext,
+		Project:            e.Project,
+		Stage:              e.Stage,
+		Service:            e.Service,
+		Start:              e.Start,
+		End:                e.End,
+		TestStrategy:       e.TestStrategy,
+		DeploymentStrategy: e.DeploymentStrategy,
+		Labels:             e.Labels,
+		Providers:          sliProviders,
+		Created:            now,
+		Deadline:           now.Add(getSLITimeout()),
+	})
 	if err != nil {
-		// ToDo: We need to provide feedback to the user that this failed becuase no sli provider was set for project
-		eh.Logger.Error("Could not determine SLI provider for project " + e.Project)
+		eh.Logger.Error("Could not store pending evaluation: " + err.Error())
 		return err
 	}
-	// send a new event to trigger the SLI retrieval
-	eh.Logger.Debug("SLI provider for project " + e.Project + " is: " + sliProvider)
-	err = eh.sendInternalGetSLIEvent(keptnContext, e.Project, e.Stage, e.Service, sliProvider, indicators, e.Start, e.End, e.TestStrategy, e.DeploymentStrategy, filters, e.Labels, deployment)
+
+	// send a new event per SLI provider to trigger the SLI retrieval
+	for sliProvider, sliProviderIndicators := range sliProviders {
+		eh.Logger.Debug(fmt.Sprintf("SLI provider for SLIs %v of service %s in stage %s is: %s", sliProviderIndicators, e.Service, e.Stage, sliProvider))
+		err = eh.sendInternalGetSLIEvent(keptnContext, e.Project, e.Stage, e.Service, sliProvider, sliProviderIndicators, e.Start, e.End, e.TestStrategy, e.DeploymentStrategy, filters, e.Labels, deployment)
+		if err != nil {
+			eh.Logger.Error("Could not send get-sli event to SLI provider " + sliProvider + ": " + err.Error())
+		}
+	}
 	return nil
 }
 
@@ -146,24 +213,6 @@ func (eh *StartEvaluationHandler) sendEvaluationDoneEvent(shkeptncontext string,
 	return sendEvent(event)
 }
 
-func getSLIProvider(project string) (string, error) {
-	kubeClient, err := keptnutils.GetKubeAPI(true)
-
-	if err != nil {
-		return "", err
-	}
-
-	configMap, err := kubeClient.ConfigMaps("keptn").Get("lighthouse-config-"+project, v1.GetOptions{})
-
-	if err != nil {
-		return "", errors.New("No SLI provider specified for project " + project)
-	}
-
-	sliProvider := configMap.Data["sli-provider"]
-
-	return sliProvider, nil
-}
-
 func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(shkeptncontext string, project string, stage string, service string, sliProvider string, indicators []string, start string, end string, teststrategy string, deploymentStrategy string, filters []*keptnevents.SLIFilter, labels map[string]string, deployment string) error {
 	source, _ := url.Parse("lighthouse-service")
 	contentType := "application/json"
@@ -7,11 +7,16 @@ import (
 	cloudeventshttp "github.com/cloudevents/sdk-go/pkg/cloudevents/transport/http"
 	"github.com/kelseyhightower/envconfig"
 	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/keptn/lighthouse-service/api"
 	"github.com/keptn/keptn/lighthouse-service/event_handler"
 	"log"
+	"net/http"
 	"os"
+	"time"
 )
 
+const pendingEvaluationsInterval = 30 * time.Second
+
 type envConfig struct {
 	// Port on which to listen for cloudevents
 	Port int    `envconfig:"RCV_PORT" default:"8080"`
@@ -38,6 +43,15 @@ func _main(args []string, env envConfig) int {
 	if err != nil {
 		log.Fatalf("failed to create transport, %v", err)
 	}
+
+	// the SLO validation and dry-run endpoints are served on the same port as the cloudevents receiver
+	mux := http.NewServeMux()
+	api.Register(mux)
+	t.Handler = mux
+
+	// outstanding get-sli requests are checked periodically, evaluations fail if the SLI providers do not respond in time
+	go event_handler.WatchPendingEvaluations(ctx, pendingEvaluationsInterval)
+
 	c, err := client.New(t)
 	if err != nil {
 		log.Fatalf("failed to create client, %v", err)
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"html/template"
+	"io"
+)
+
+var htmlTemplate = template.Must(template.New("html").Funcs(template.FuncMap{
+	"score": formatFloat,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Evaluation of {{ .Service }} in stage {{ .Stage }} of project {{ .Project }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #333; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.4em 0.8em; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+.pass { color: #2e7d32; }
+.warning { color: #ef6c00; }
+.fail { color: #c62828; }
+.violated { color: #c62828; text-decoration: line-through; }
+pre { background: #f7f7f7; padding: 1em; overflow: auto; }
+</style>
+</head>
+<body>
+<h1>Evaluation of {{ .Service }} in stage {{ .Stage }} of project {{ .Project }}</h1>
+<table>
+<tr><th>Result</th><td class="{{ .Result }}">{{ .Result }}</td></tr>
+{{- if .Indicators }}
+<tr><th>Score</th><td>{{ score .Score }}</td></tr>
+{{- end }}
+{{- if or .TimeStart .TimeEnd }}
+<tr><th>Evaluation timeframe</th><td>{{ .TimeStart }} - {{ .TimeEnd }}</td></tr>
+{{- end }}
+{{- if .TestStrategy }}
+<tr><th>Test strategy</th><td>{{ .TestStrategy }}</td></tr>
+{{- end }}
+{{- if .DeploymentStrategy }}
+<tr><th>Deployment strategy</th><td>{{ .DeploymentStrategy }}</td></tr>
+{{- end }}
+{{- range .Labels }}
+<tr><th>{{ .Key }}</th><td>{{ .Value }}</td></tr>
+{{- end }}
+</table>
+{{- if .Indicators }}
+<h2>Service Level Indicators</h2>
+<table>
+<tr><th>SLI</th><th>Value</th><th>Status</th><th>Score</th><th>Criteria</th></tr>
+{{- range .Indicators }}
+<tr>
+<td>{{ .Name }}</td>
+<td>{{ if .Value }}{{ .Value }}{{ else }}-{{ end }}</td>
+<td class="{{ .Status }}">{{ .Status }}</td>
+<td>{{ score .Score }}</td>
+<td>{{ range $i, $target := .Targets }}{{ if $i }}<br>{{ end }}<span{{ if .Violated }} class="violated"{{ end }}>{{ .String }}</span>{{ end }}{{ if .Message }}{{ if .Targets }}<br>{{ end }}{{ .Message }}{{ end }}</td>
+</tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .SLOFile }}
+<h2>SLO file</h2>
+<pre>{{ .SLOFile }}</pre>
+{{- end }}
+</body>
+</html>
+`))
+
+func renderHTML(w io.Writer, report *Report) error {
+	return htmlTemplate.Execute(w, report)
+}
@@ -0,0 +1,128 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// renderJUnit writes one testcase per SLI. SLIs with status fail are reported as failures listing the violated
+// targets; SLIs with status warning pass and mention the violated targets in their output
+func renderJUnit(w io.Writer, report *Report) error {
+	className := strings.Join([]string{report.Project, report.Stage, report.Service}, ".")
+	suite := junitTestSuite{
+		Name:      className,
+		Timestamp: report.TimeEnd,
+		Properties: []junitProperty{
+			{Name: "result", Value: report.Result},
+			{Name: "score", Value: formatFloat(report.Score)},
+		},
+	}
+	if report.TestStrategy != "" {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "teststrategy", Value: report.TestStrategy})
+	}
+	if report.DeploymentStrategy != "" {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "deploymentstrategy", Value: report.DeploymentStrategy})
+	}
+	for _, label := range report.Labels {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "label." + label.Key, Value: label.Value})
+	}
+
+	for _, indicator := range report.Indicators {
+		testCase := junitTestCase{
+			Name:      indicator.Name,
+			ClassName: className,
+			SystemOut: junitOutput(indicator),
+		}
+		if indicator.Failed() {
+			testCase.Failure = &junitFailure{
+				Message: indicator.FailureMessage(),
+				Type:    "SLIViolation",
+				Content: junitTargets(indicator),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	suites := junitTestSuites{
+		Name:       "keptn evaluation",
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		TestSuites: []junitTestSuite{suite},
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitOutput(indicator *Indicator) string {
+	value := indicator.Value
+	if value == "" {
+		value = "-"
+	}
+	output := fmt.Sprintf("value: %s\nstatus: %s\nscore: %s\n", value, indicator.Status, formatFloat(indicator.Score))
+	if indicator.Status == "warning" {
+		output += "warning: " + indicator.FailureMessage() + "\n"
+	}
+	return output
+}
+
+func junitTargets(indicator *Indicator) string {
+	var lines []string
+	for _, target := range indicator.Targets {
+		state := "met"
+		if target.Violated {
+			state = "violated"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", target.String(), state))
+	}
+	if indicator.Message != "" {
+		lines = append(lines, indicator.Message)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"io"
+	"strings"
+	"text/template"
+)
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(template.FuncMap{
+	"cell":  markdownCell,
+	"score": formatFloat,
+	"trim":  func(value string) string { return strings.TrimRight(value, "\n") },
+}).Parse(`# Evaluation of {{ .Service }} in stage {{ .Stage }} of project {{ .Project }}
+
+**Result:** {{ .Result }}{{ if .Indicators }} | **Score:** {{ score .Score }}{{ end }}
+{{ if or .TimeStart .TimeEnd }}
+**Evaluation timeframe:** {{ .TimeStart }} - {{ .TimeEnd }}
+{{ end }}{{ if .TestStrategy }}
+**Test strategy:** {{ .TestStrategy }}
+{{ end }}{{ if .DeploymentStrategy }}
+**Deployment strategy:** {{ .DeploymentStrategy }}
+{{ end }}{{ if .Labels }}
+**Labels:**{{ range .Labels }} {{ .Key }}={{ .Value }}{{ end }}
+{{ end }}{{ if .Indicators }}
+| SLI | Value | Status | Score | Criteria |
+| --- | --- | --- | --- | --- |
+{{ range .Indicators }}| {{ cell .Name }} | {{ if .Value }}{{ cell .Value }}{{ else }}-{{ end }} | {{ cell .Status }} | {{ score .Score }} | {{ range $i, $target := .Targets }}{{ if $i }}<br>{{ end }}{{ if .Violated }}~~{{ cell .String }}~~{{ else }}{{ cell .String }}{{ end }}{{ end }}{{ if .Message }}{{ if .Targets }}<br>{{ end }}{{ cell .Message }}{{ end }} |
+{{ end }}{{ end }}{{ if .SLOFile }}
+<details>
+<summary>SLO file</summary>
+
+` + "```yaml" + `
+{{ trim .SLOFile }}
+` + "```" + `
+
+</details>
+{{ end }}`))
+
+// markdownCell escapes characters that would end a cell of a Markdown table
+func markdownCell(value string) string {
+	value = strings.Replace(value, "|", "\\|", -1)
+	return strings.Replace(value, "\n", " ", -1)
+}
+
+func renderMarkdown(w io.Writer, report *Report) error {
+	return markdownTemplate.Execute(w, report)
+}
@@ -0,0 +1,251 @@
+package report
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+)
+
+// Format is an output format of an evaluation report
+type Format string
+
+const (
+	// Markdown renders the report as Markdown, e.g. for pull request comments
+	Markdown Format = "markdown"
+	// HTML renders the report as standalone HTML page
+	HTML Format = "html"
+	// JUnit renders the report as JUnit XML with one testcase per SLI
+	JUnit Format = "junit"
+)
+
+// Formats contains all supported output formats
+var Formats = []Format{Markdown, HTML, JUnit}
+
+// ParseFormat parses the name of an output format
+func ParseFormat(format string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(format, string(f)) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown report format %s, expected markdown, html or junit", format)
+}
+
+// ContentType returns the MIME type of reports in the format
+func (f Format) ContentType() string {
+	switch f {
+	case HTML:
+		return "text/html; charset=utf-8"
+	case JUnit:
+		return "application/xml; charset=utf-8"
+	default:
+		return "text/markdown; charset=utf-8"
+	}
+}
+
+// Report is the evaluation result of an evaluation-done event prepared for rendering
+type Report struct {
+	Project            string
+	Stage              string
+	Service            string
+	TestStrategy       string
+	DeploymentStrategy string
+	Labels             []Label
+	Result             string
+	Score              float64
+	TimeStart          string
+	TimeEnd            string
+	Indicators         []*Indicator
+	// SLOFile is the decoded content of the SLO file the evaluation is based on
+	SLOFile string
+}
+
+// Label is a label of the evaluated deployment
+type Label struct {
+	Key   string
+	Value string
+}
+
+// Indicator is the evaluation result of a single SLI
+type Indicator struct {
+	Name   string
+	Value  string
+	Status string
+	Score  float64
+	// Message describes why no value could be retrieved
+	Message string
+	Targets []*Target
+}
+
+// Target is a criterion an SLI value has been evaluated against
+type Target struct {
+	Criteria    string
+	TargetValue string
+	Violated    bool
+}
+
+// Failed returns true if the SLI failed the evaluation
+func (i *Indicator) Failed() bool {
+	return i.Status == "fail"
+}
+
+// ViolatedTargets returns the targets the SLI value did not meet
+func (i *Indicator) ViolatedTargets() []*Target {
+	var violated []*Target
+	for _, target := range i.Targets {
+		if target.Violated {
+			violated = append(violated, target)
+		}
+	}
+	return violated
+}
+
+// FailureMessage summarizes why the SLI did not pass, e.g. "response_time_p95 = 640 violates <600"
+func (i *Indicator) FailureMessage() string {
+	violated := i.ViolatedTargets()
+	if len(violated) == 0 {
+		if i.Message != "" {
+			return i.Message
+		}
+		return fmt.Sprintf("%s has status %s", i.Name, i.Status)
+	}
+	criteria := make([]string, 0, len(violated))
+	for _, target := range violated {
+		criteria = append(criteria, target.String())
+	}
+	return fmt.Sprintf("%s = %s violates %s", i.Name, i.Value, strings.Join(criteria, ", "))
+}
+
+// String returns the criteria and, if it differs from the criteria, the value it has been resolved to
+func (t *Target) String() string {
+	if t.TargetValue == "" || strings.HasSuffix(t.Criteria, t.TargetValue) {
+		return t.Criteria
+	}
+	return fmt.Sprintf("%s (%s)", t.Criteria, t.TargetValue)
+}
+
+// NewReport prepares the data of an evaluation-done event for rendering
+func NewReport(data *keptnevents.EvaluationDoneEventData) (*Report, error) {
+	if data == nil {
+		return nil, errors.New("no evaluation-done event data")
+	}
+	report := &Report{
+		Project:            data.Project,
+		Stage:              data.Stage,
+		Service:            data.Service,
+		TestStrategy:       data.TestStrategy,
+		DeploymentStrategy: data.DeploymentStrategy,
+		Result:             data.Result,
+	}
+	for key, value := range data.Labels {
+		report.Labels = append(report.Labels, Label{Key: key, Value: value})
+	}
+	sort.Slice(report.Labels, func(i, j int) bool {
+		return report.Labels[i].Key < report.Labels[j].Key
+	})
+
+	details := data.EvaluationDetails
+	if details == nil {
+		return report, nil
+	}
+	if report.Result == "" {
+		report.Result = details.Result
+	}
+	report.Score = details.Score
+	report.TimeStart = details.TimeStart
+	report.TimeEnd = details.TimeEnd
+	if details.SLOFileContent != "" {
+		sloFile, err := base64.StdEncoding.DecodeString(details.SLOFileContent)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode SLO file: %s", err.Error())
+		}
+		report.SLOFile = string(sloFile)
+	}
+
+	for _, result := range details.IndicatorResults {
+		if result == nil {
+			continue
+		}
+		report.Indicators = append(report.Indicators, newIndicator(result))
+	}
+	return report, nil
+}
+
+func newIndicator(result *keptnevents.SLIEvaluationResult) *Indicator {
+	indicator := &Indicator{
+		Status: result.Status,
+		Score:  result.Score,
+	}
+	if result.Value != nil {
+		indicator.Name = result.Value.Metric
+		indicator.Message = result.Value.Message
+		if result.Value.Success {
+			indicator.Value = formatFloat(result.Value.Value)
+		} else if indicator.Message == "" {
+			indicator.Message = "SLI provider failed to retrieve value"
+		}
+	}
+	for _, target := range result.Targets {
+		if target == nil {
+			continue
+		}
+		indicator.Targets = append(indicator.Targets, &Target{
+			Criteria:    target.Criteria,
+			TargetValue: formatFloat(target.TargetValue),
+			Violated:    target.Violated,
+		})
+	}
+	return indicator
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// ParseEvaluationDoneEvent reads the data of an evaluation-done event. Both the whole event, with the evaluation result
+// in its data field, and the data on its own are accepted
+func ParseEvaluationDoneEvent(content []byte) (*keptnevents.EvaluationDoneEventData, error) {
+	event := &struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(content, event); err != nil {
+		return nil, fmt.Errorf("could not parse evaluation-done event: %s", err.Error())
+	}
+	if event.Type != "" && event.Type != keptnevents.EvaluationDoneEventType {
+		return nil, fmt.Errorf("event of type %s is not an evaluation-done event", event.Type)
+	}
+	if len(event.Data) > 0 {
+		content = event.Data
+	}
+
+	data := &keptnevents.EvaluationDoneEventData{}
+	if err := json.Unmarshal(content, data); err != nil {
+		return nil, fmt.Errorf("could not parse evaluation-done event: %s", err.Error())
+	}
+	return data, nil
+}
+
+// Render writes the report of an evaluation-done event in the given format
+func Render(w io.Writer, data *keptnevents.EvaluationDoneEventData, format Format) error {
+	report, err := NewReport(data)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case Markdown:
+		return renderMarkdown(w, report)
+	case HTML:
+		return renderHTML(w, report)
+	case JUnit:
+		return renderJUnit(w, report)
+	}
+	return fmt.Errorf("unknown report format %s, expected markdown, html or junit", format)
+}
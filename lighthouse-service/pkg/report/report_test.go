@@ -0,0 +1,146 @@
+package report
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"testing"
+
+	keptnevents "github.com/keptn/go-utils/pkg/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSLOFile = `spec_version: '1.0'
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<600"
+`
+
+func newTestEvaluation() *keptnevents.EvaluationDoneEventData {
+	return &keptnevents.EvaluationDoneEventData{
+		Project:      "sockshop",
+		Stage:        "staging",
+		Service:      "carts",
+		TestStrategy: "performance",
+		Labels:       map[string]string{"buildId": "42"},
+		Result:       "fail",
+		EvaluationDetails: &keptnevents.EvaluationDetails{
+			TimeStart:      "2020-01-27T13:54:15Z",
+			TimeEnd:        "2020-01-27T13:54:53Z",
+			Result:         "fail",
+			Score:          50,
+			SLOFileContent: base64.StdEncoding.EncodeToString([]byte(testSLOFile)),
+			IndicatorResults: []*keptnevents.SLIEvaluationResult{
+				{
+					Score:  0,
+					Value:  &keptnevents.SLIResult{Metric: "response_time_p95", Value: 640.5, Success: true},
+					Status: "fail",
+					Targets: []*keptnevents.SLITarget{
+						{Criteria: "<=+10%", TargetValue: 440, Violated: true},
+						{Criteria: "<600", TargetValue: 600, Violated: true},
+					},
+				},
+				{
+					Score:   1,
+					Value:   &keptnevents.SLIResult{Metric: "error|rate", Value: 0, Success: true},
+					Status:  "pass",
+					Targets: []*keptnevents.SLITarget{{Criteria: "<1", TargetValue: 1}},
+				},
+				{
+					Score:  0,
+					Value:  &keptnevents.SLIResult{Metric: "throughput", Success: false, Message: "query timed out"},
+					Status: "fail",
+				},
+			},
+		},
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	report, err := NewReport(newTestEvaluation())
+	assert.Nil(t, err)
+	assert.Equal(t, testSLOFile, report.SLOFile)
+	assert.Equal(t, []Label{{Key: "buildId", Value: "42"}}, report.Labels)
+	assert.Len(t, report.Indicators, 3)
+
+	assert.Equal(t, "640.5", report.Indicators[0].Value)
+	assert.Equal(t, "response_time_p95 = 640.5 violates <=+10% (440), <600", report.Indicators[0].FailureMessage())
+	assert.Equal(t, "", report.Indicators[2].Value)
+	assert.Equal(t, "query timed out", report.Indicators[2].FailureMessage())
+
+	_, err = NewReport(&keptnevents.EvaluationDoneEventData{EvaluationDetails: &keptnevents.EvaluationDetails{SLOFileContent: "%%%"}})
+	assert.NotNil(t, err)
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.Nil(t, Render(buf, newTestEvaluation(), Markdown))
+
+	markdown := buf.String()
+	assert.Contains(t, markdown, "# Evaluation of carts in stage staging of project sockshop")
+	assert.Contains(t, markdown, "**Result:** fail | **Score:** 50")
+	assert.Contains(t, markdown, "| response_time_p95 | 640.5 | fail | 0 | ~~<=+10% (440)~~<br>~~<600~~ |")
+	assert.Contains(t, markdown, "| error\\|rate | 0 | pass | 1 | <1 |")
+	assert.Contains(t, markdown, "| throughput | - | fail | 0 | query timed out |")
+	assert.Contains(t, markdown, "```yaml\n"+testSLOFile)
+}
+
+func TestRenderHTML(t *testing.T) {
+	evaluation := newTestEvaluation()
+	evaluation.Labels["note"] = "<script>alert(1)</script>"
+	buf := &bytes.Buffer{}
+	assert.Nil(t, Render(buf, evaluation, HTML))
+
+	html := buf.String()
+	assert.Contains(t, html, "<!DOCTYPE html>")
+	assert.Contains(t, html, `<td class="fail">fail</td>`)
+	assert.Contains(t, html, `<span class="violated">&lt;600</span>`)
+	assert.Contains(t, html, "&lt;script&gt;")
+	assert.NotContains(t, html, "<script>")
+}
+
+func TestRenderJUnit(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.Nil(t, Render(buf, newTestEvaluation(), JUnit))
+
+	suites := &junitTestSuites{}
+	assert.Nil(t, xml.Unmarshal(buf.Bytes(), suites))
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	assert.Len(t, suites.TestSuites, 1)
+
+	suite := suites.TestSuites[0]
+	assert.Equal(t, "sockshop.staging.carts", suite.Name)
+	assert.Contains(t, suite.Properties, junitProperty{Name: "label.buildId", Value: "42"})
+	assert.Equal(t, "response_time_p95", suite.TestCases[0].Name)
+	assert.Equal(t, "response_time_p95 = 640.5 violates <=+10% (440), <600", suite.TestCases[0].Failure.Message)
+	assert.Equal(t, "<=+10% (440): violated\n<600: violated", suite.TestCases[0].Failure.Content)
+	assert.Nil(t, suite.TestCases[1].Failure)
+	assert.Equal(t, "query timed out", suite.TestCases[2].Failure.Message)
+}
+
+func TestParseEvaluationDoneEvent(t *testing.T) {
+	event := []byte(`{"type": "sh.keptn.events.evaluation-done", "shkeptncontext": "my-context", "data": {"project": "sockshop", "result": "pass"}}`)
+	data, err := ParseEvaluationDoneEvent(event)
+	assert.Nil(t, err)
+	assert.Equal(t, "sockshop", data.Project)
+	assert.Equal(t, "pass", data.Result)
+
+	data, err = ParseEvaluationDoneEvent([]byte(`{"project": "sockshop", "result": "warning"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "warning", data.Result)
+
+	_, err = ParseEvaluationDoneEvent([]byte(`{"type": "sh.keptn.events.tests-finished", "data": {}}`))
+	assert.NotNil(t, err)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("JUnit")
+	assert.Nil(t, err)
+	assert.Equal(t, JUnit, format)
+
+	_, err = ParseFormat("pdf")
+	assert.NotNil(t, err)
+}
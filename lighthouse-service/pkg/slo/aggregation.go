@@ -0,0 +1,178 @@
+package slo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// aggregationFunc reduces the values of previous evaluations to a single value. The values are ordered from the
+// most recent to the oldest evaluation
+type aggregationFunc func(values []float64) float64
+
+var aggregationFunctions = map[string]aggregationFunc{
+	"avg":    CalculateAverage,
+	"min":    calculateMin,
+	"max":    calculateMax,
+	"median": func(values []float64) float64 { return CalculatePercentile(sortedCopy(values), 0.5) },
+	"stddev": CalculateStandardDeviation,
+	"trimmed_mean": func(values []float64) float64 {
+		return calculateTrimmedMean(values, defaultTrimPercentage)
+	},
+	"ewma": func(values []float64) float64 {
+		return calculateEWMA(values, defaultEWMAAlpha)
+	},
+}
+
+const defaultTrimPercentage = 10.0
+const defaultEWMAAlpha = 0.5
+
+var percentileAggregationRegex = regexp.MustCompile(`^p(\d{1,2}(\.\d+)?|100)$`)
+
+// GetAggregationFunction resolves the aggregate_function of a SLO comparison. Besides the registered functions,
+// the following parameterized functions are supported:
+// - pNN: NN-th percentile, e.g. p50, p90, p99.9
+// - trimmed_mean_NN: mean after discarding NN% of the values at both ends, e.g. trimmed_mean_20
+// - ewma_A: exponentially weighted moving average with smoothing factor A (0 < A <= 1), e.g. ewma_0.3
+func GetAggregationFunction(name string) (aggregationFunc, error) {
+	if fn, ok := aggregationFunctions[name]; ok {
+		return fn, nil
+	}
+
+	if percentileAggregationRegex.MatchString(name) {
+		perc, err := strconv.ParseFloat(strings.TrimPrefix(name, "p"), 64)
+		if err != nil || perc <= 0 || perc > 100 {
+			return nil, fmt.Errorf("invalid percentile in aggregate function %s", name)
+		}
+		return func(values []float64) float64 {
+			return CalculatePercentile(sortedCopy(values), perc/100.0)
+		}, nil
+	}
+
+	if strings.HasPrefix(name, "trimmed_mean_") {
+		trim, err := strconv.ParseFloat(strings.TrimPrefix(name, "trimmed_mean_"), 64)
+		if err != nil || trim < 0 || trim >= 50 {
+			return nil, fmt.Errorf("invalid trim percentage in aggregate function %s: must be in [0, 50)", name)
+		}
+		return func(values []float64) float64 {
+			return calculateTrimmedMean(values, trim)
+		}, nil
+	}
+
+	if strings.HasPrefix(name, "ewma_") {
+		alpha, err := strconv.ParseFloat(strings.TrimPrefix(name, "ewma_"), 64)
+		if err != nil || alpha <= 0 || alpha > 1 {
+			return nil, fmt.Errorf("invalid smoothing factor in aggregate function %s: must be in (0, 1]", name)
+		}
+		return func(values []float64) float64 {
+			return calculateEWMA(values, alpha)
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown aggregate function %s", name)
+}
+
+func sortedCopy(values []float64) sort.Float64Slice {
+	sorted := make(sort.Float64Slice, len(values))
+	copy(sorted, values)
+	sort.Sort(sorted)
+	return sorted
+}
+
+func CalculateAverage(values []float64) float64 {
+	sum := 0.0
+
+	for _, value := range values {
+		sum += value
+	}
+	if len(values) > 0 {
+		return sum / float64(len(values))
+	}
+	return 0.0
+}
+
+func calculateMin(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	min := values[0]
+	for _, value := range values[1:] {
+		min = math.Min(min, value)
+	}
+	return min
+}
+
+func calculateMax(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	max := values[0]
+	for _, value := range values[1:] {
+		max = math.Max(max, value)
+	}
+	return max
+}
+
+func CalculatePercentile(values sort.Float64Slice, perc float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	ps := []float64{perc}
+
+	scores := make([]float64, len(ps))
+	size := len(values)
+	if size > 0 {
+		sort.Sort(values)
+		for i, p := range ps {
+			pos := p * float64(size+1) //ALTERNATIVELY, DROP THE +1
+			if pos < 1.0 {
+				scores[i] = float64(values[0])
+			} else if pos >= float64(size) {
+				scores[i] = float64(values[size-1])
+			} else {
+				lower := float64(values[int(pos)-1])
+				upper := float64(values[int(pos)])
+				scores[i] = lower + (pos-math.Floor(pos))*(upper-lower)
+			}
+		}
+	}
+	return scores[0]
+}
+
+// CalculateStandardDeviation returns the sample standard deviation of the values
+func CalculateStandardDeviation(values []float64) float64 {
+	if len(values) < 2 {
+		return 0.0
+	}
+	mean := CalculateAverage(values)
+	sumOfSquares := 0.0
+	for _, value := range values {
+		sumOfSquares += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sumOfSquares / float64(len(values)-1))
+}
+
+// calculateTrimmedMean discards trimPercentage percent of the values at both ends before calculating the mean
+func calculateTrimmedMean(values []float64, trimPercentage float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	sorted := sortedCopy(values)
+	trim := int(math.Floor(float64(len(sorted)) * trimPercentage / 100.0))
+	return CalculateAverage(sorted[trim : len(sorted)-trim])
+}
+
+// calculateEWMA calculates the exponentially weighted moving average, starting with the oldest value
+func calculateEWMA(values []float64, alpha float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	ewma := values[len(values)-1]
+	for i := len(values) - 2; i >= 0; i-- {
+		ewma = alpha*values[i] + (1-alpha)*ewma
+	}
+	return ewma
+}
@@ -0,0 +1,164 @@
+package slo
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type calculatePercentileTestObject struct {
+	Name          string
+	InValue       sort.Float64Slice
+	InPercentile  float64
+	ExpectedValue float64
+}
+
+func TestCalculatePercentile(t *testing.T) {
+	tests := []*calculatePercentileTestObject{
+		{
+			Name:          "Should return 5.0",
+			InValue:       []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			InPercentile:  0.5,
+			ExpectedValue: 5.0,
+		},
+		{
+			Name:          "Should return 9.0",
+			InValue:       []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			InPercentile:  0.9,
+			ExpectedValue: 9.8,
+		},
+		{
+			Name:          "Should return 10.0",
+			InValue:       []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			InPercentile:  0.95,
+			ExpectedValue: 10.0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			percentile := CalculatePercentile(test.InValue, test.InPercentile)
+			assert.EqualValues(t, test.ExpectedValue, percentile)
+		})
+	}
+}
+
+type aggregationFunctionTestObject struct {
+	Name          string
+	InFunction    string
+	InValues      []float64
+	ExpectedValue float64
+	ExpectedError error
+}
+
+func TestGetAggregationFunction(t *testing.T) {
+	tests := []*aggregationFunctionTestObject{
+		{
+			Name:          "avg",
+			InFunction:    "avg",
+			InValues:      []float64{1, 2, 3, 6},
+			ExpectedValue: 3.0,
+		},
+		{
+			Name:          "min",
+			InFunction:    "min",
+			InValues:      []float64{4, 2, 3, 6},
+			ExpectedValue: 2.0,
+		},
+		{
+			Name:          "max",
+			InFunction:    "max",
+			InValues:      []float64{4, 2, 3, 6},
+			ExpectedValue: 6.0,
+		},
+		{
+			Name:          "median",
+			InFunction:    "median",
+			InValues:      []float64{5, 1, 3},
+			ExpectedValue: 3.0,
+		},
+		{
+			Name:          "p50 equals median",
+			InFunction:    "p50",
+			InValues:      []float64{5, 1, 3},
+			ExpectedValue: 3.0,
+		},
+		{
+			Name:          "p99.9 returns the largest value",
+			InFunction:    "p99.9",
+			InValues:      []float64{5, 1, 3},
+			ExpectedValue: 5.0,
+		},
+		{
+			Name:          "stddev",
+			InFunction:    "stddev",
+			InValues:      []float64{2, 4, 4, 4, 5, 5, 7, 9},
+			ExpectedValue: 2.138089935299395,
+		},
+		{
+			Name:          "stddev of a single value is 0",
+			InFunction:    "stddev",
+			InValues:      []float64{2},
+			ExpectedValue: 0.0,
+		},
+		{
+			Name:          "trimmed_mean_20 discards the outliers",
+			InFunction:    "trimmed_mean_20",
+			InValues:      []float64{100, 1, 2, 3, -50},
+			ExpectedValue: 2.0,
+		},
+		{
+			Name:          "trimmed_mean keeps all values of small samples",
+			InFunction:    "trimmed_mean",
+			InValues:      []float64{1, 2, 6},
+			ExpectedValue: 3.0,
+		},
+		{
+			Name:          "ewma_0.5 weights recent values higher",
+			InFunction:    "ewma_0.5",
+			InValues:      []float64{8, 4, 0},
+			ExpectedValue: 5.0,
+		},
+		{
+			Name:          "ewma_1 returns the most recent value",
+			InFunction:    "ewma_1",
+			InValues:      []float64{8, 4, 0},
+			ExpectedValue: 8.0,
+		},
+		{
+			Name:          "unknown function",
+			InFunction:    "average",
+			ExpectedError: errors.New("unknown aggregate function average"),
+		},
+		{
+			Name:          "empty function",
+			InFunction:    "",
+			ExpectedError: errors.New("unknown aggregate function "),
+		},
+		{
+			Name:          "invalid percentile",
+			InFunction:    "p0",
+			ExpectedError: errors.New("invalid percentile in aggregate function p0"),
+		},
+		{
+			Name:          "invalid trim percentage",
+			InFunction:    "trimmed_mean_50",
+			ExpectedError: errors.New("invalid trim percentage in aggregate function trimmed_mean_50: must be in [0, 50)"),
+		},
+		{
+			Name:          "invalid smoothing factor",
+			InFunction:    "ewma_2",
+			ExpectedError: errors.New("invalid smoothing factor in aggregate function ewma_2: must be in (0, 1]"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			aggregate, err := GetAggregationFunction(test.InFunction)
+			assert.EqualValues(t, test.ExpectedError, err)
+			if err == nil {
+				assert.InDelta(t, test.ExpectedValue, aggregate(test.InValues), 0.000001)
+			}
+		})
+	}
+}
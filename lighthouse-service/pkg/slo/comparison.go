@@ -0,0 +1,97 @@
+package slo
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultComparisonResults is the number of previous evaluations compared with several_results if not specified otherwise
+const DefaultComparisonResults = 3
+
+// NumberOfPreviousResults returns the number of previous evaluations that are needed for the comparison
+func (c *SLOComparison) NumberOfPreviousResults() int {
+	if c == nil {
+		return 3
+	}
+	switch c.CompareWith {
+	case "single_result":
+		return 1
+	case "several_results":
+		return c.NumberOfComparisonResults
+	}
+	return 3
+}
+
+// EffectiveComparison returns the comparison settings for the given objective, i.e. the global comparison settings
+// of the SLO file overridden by the properties set in the comparison block of the objective
+func (slo *ServiceLevelObjectives) EffectiveComparison(objective *SLO) *SLOComparison {
+	if objective == nil || objective.Comparison == nil {
+		return slo.Comparison
+	}
+	comparison := &SLOComparison{}
+	if slo.Comparison != nil {
+		*comparison = *slo.Comparison
+	}
+	override := objective.Comparison
+	switch override.CompareWith {
+	case "single_result":
+		comparison.NumberOfComparisonResults = 1
+	case "several_results":
+		if comparison.CompareWith != "several_results" {
+			// the number of results of a global single_result comparison does not apply to several results
+			comparison.NumberOfComparisonResults = DefaultComparisonResults
+		}
+	}
+	if override.CompareWith != "" {
+		comparison.CompareWith = override.CompareWith
+	}
+	if override.IncludeResultWithScore != "" {
+		comparison.IncludeResultWithScore = override.IncludeResultWithScore
+	}
+	if override.NumberOfComparisonResults != 0 {
+		comparison.NumberOfComparisonResults = override.NumberOfComparisonResults
+	}
+	if override.AggregateFunction != "" {
+		comparison.AggregateFunction = override.AggregateFunction
+	}
+	if override.Mode != "" {
+		comparison.Mode = override.Mode
+	}
+	if override.Baseline != nil {
+		comparison.Baseline = override.Baseline
+	}
+	return comparison
+}
+
+// Key returns a string identifying the baseline, e.g. keptn_context=abc or labels=buildId=1,release=stable.
+// The key of a nil baseline (i.e. the last evaluations of the service) is empty
+func (b *SLOBaseline) Key() string {
+	if b == nil {
+		return ""
+	}
+	if b.KeptnContext != "" {
+		return "keptn_context=" + b.KeptnContext
+	}
+	if len(b.Labels) == 0 {
+		return ""
+	}
+	labels := make([]string, 0, len(b.Labels))
+	for key, value := range b.Labels {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	return "labels=" + strings.Join(labels, ",")
+}
+
+// MatchesLabels checks if the given labels contain all labels of the baseline
+func (b *SLOBaseline) MatchesLabels(labels map[string]string) bool {
+	if b == nil {
+		return true
+	}
+	for key, value := range b.Labels {
+		if labelValue, ok := labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
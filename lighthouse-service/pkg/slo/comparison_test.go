@@ -0,0 +1,102 @@
+package slo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveComparison(t *testing.T) {
+	sloConfig := &ServiceLevelObjectives{
+		Comparison: &SLOComparison{
+			CompareWith:               "several_results",
+			IncludeResultWithScore:    "all",
+			NumberOfComparisonResults: 3,
+			AggregateFunction:         "avg",
+		},
+	}
+
+	tests := []struct {
+		Name               string
+		InObjective        *SLO
+		ExpectedComparison *SLOComparison
+	}{
+		{
+			Name:               "objective without comparison uses the global comparison",
+			InObjective:        &SLO{SLI: "response_time_p95"},
+			ExpectedComparison: sloConfig.Comparison,
+		},
+		{
+			Name: "objective overrides aggregate function and included results",
+			InObjective: &SLO{
+				SLI: "response_time_p95",
+				Comparison: &SLOComparison{
+					IncludeResultWithScore: "pass",
+					AggregateFunction:      "p90",
+				},
+			},
+			ExpectedComparison: &SLOComparison{
+				CompareWith:               "several_results",
+				IncludeResultWithScore:    "pass",
+				NumberOfComparisonResults: 3,
+				AggregateFunction:         "p90",
+			},
+		},
+		{
+			Name: "objective compares with a single pinned result",
+			InObjective: &SLO{
+				SLI: "response_time_p95",
+				Comparison: &SLOComparison{
+					CompareWith: "single_result",
+					Baseline:    &SLOBaseline{Labels: map[string]string{"release": "stable"}},
+				},
+			},
+			ExpectedComparison: &SLOComparison{
+				CompareWith:               "single_result",
+				IncludeResultWithScore:    "all",
+				NumberOfComparisonResults: 1,
+				AggregateFunction:         "avg",
+				Baseline:                  &SLOBaseline{Labels: map[string]string{"release": "stable"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			comparison := sloConfig.EffectiveComparison(test.InObjective)
+			assert.EqualValues(t, test.ExpectedComparison, comparison)
+			assert.EqualValues(t, "avg", sloConfig.Comparison.AggregateFunction)
+		})
+	}
+}
+
+// TestEffectiveComparisonWithSeveralResults checks whether an objective comparing with several results does not inherit
+// the number of results of the default single_result comparison
+func TestEffectiveComparisonWithSeveralResults(t *testing.T) {
+	sloConfig, err := Parse([]byte("objectives:\n- sli: response_time_p95\n"))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	comparison := sloConfig.EffectiveComparison(&SLO{SLI: "response_time_p95", Comparison: &SLOComparison{CompareWith: "several_results"}})
+	assert.EqualValues(t, "several_results", comparison.CompareWith)
+	assert.EqualValues(t, DefaultComparisonResults, comparison.NumberOfComparisonResults)
+	assert.EqualValues(t, DefaultComparisonResults, comparison.NumberOfPreviousResults())
+
+	comparison = sloConfig.EffectiveComparison(&SLO{SLI: "response_time_p95", Comparison: &SLOComparison{CompareWith: "several_results", NumberOfComparisonResults: 5}})
+	assert.EqualValues(t, 5, comparison.NumberOfPreviousResults())
+	assert.EqualValues(t, 1, sloConfig.Comparison.NumberOfComparisonResults)
+}
+
+func TestSLOBaselineKey(t *testing.T) {
+	var baseline *SLOBaseline
+	assert.EqualValues(t, "", baseline.Key())
+	assert.EqualValues(t, "keptn_context=abc", (&SLOBaseline{KeptnContext: "abc"}).Key())
+	assert.EqualValues(t, "labels=buildId=1,release=stable", (&SLOBaseline{Labels: map[string]string{"release": "stable", "buildId": "1"}}).Key())
+}
+
+func TestSLOBaselineMatchesLabels(t *testing.T) {
+	baseline := &SLOBaseline{Labels: map[string]string{"release": "stable"}}
+	assert.True(t, baseline.MatchesLabels(map[string]string{"release": "stable", "buildId": "1"}))
+	assert.False(t, baseline.MatchesLabels(map[string]string{"release": "canary"}))
+	assert.False(t, baseline.MatchesLabels(nil))
+}
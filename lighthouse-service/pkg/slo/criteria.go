@@ -0,0 +1,568 @@
+package slo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CriteriaExpression is the parsed form of a criteria string. It is either a single *Criteria or a
+// *LogicalExpression combining several criteria
+type CriteriaExpression interface {
+	// References returns the names of the SLIs referenced by the expression
+	References() []string
+}
+
+const (
+	LogicalAnd = "AND"
+	LogicalOr  = "OR"
+)
+
+// LogicalExpression combines two criteria expressions with AND or OR, e.g. "<=+10% AND <600"
+type LogicalExpression struct {
+	Operator string
+	Left     CriteriaExpression
+	Right    CriteriaExpression
+}
+
+func (e *LogicalExpression) References() []string {
+	return append(e.Left.References(), e.Right.References()...)
+}
+
+// Criteria compares a value with a target, e.g. "<=+10%", "<600" or "error_rate < 0.01 * throughput"
+type Criteria struct {
+	Operator        string
+	Value           float64
+	CheckPercentage bool
+	IsComparison    bool
+	CheckIncrease   bool
+	// Left is the compared value. If nil, the value of the evaluated SLI is compared
+	Left ValueExpression
+	// Target is the target value if it is not a constant. If nil, Value (or the comparison with previous
+	// results) is the target
+	Target ValueExpression
+}
+
+func (c *Criteria) References() []string {
+	var references []string
+	if c.Left != nil {
+		references = append(references, c.Left.References()...)
+	}
+	if c.Target != nil {
+		references = append(references, c.Target.References()...)
+	}
+	return references
+}
+
+// CriteriaLeaves returns the single criteria contained in a criteria expression
+func CriteriaLeaves(expression CriteriaExpression) []*Criteria {
+	switch e := expression.(type) {
+	case *Criteria:
+		return []*Criteria{e}
+	case *LogicalExpression:
+		return append(CriteriaLeaves(e.Left), CriteriaLeaves(e.Right)...)
+	default:
+		return nil
+	}
+}
+
+// SLIResolver returns the value of the SLI with the given name
+type SLIResolver func(sli string) (float64, error)
+
+// ValueExpression is an arithmetic expression on constants and SLI values
+type ValueExpression interface {
+	Evaluate(resolve SLIResolver) (float64, error)
+	References() []string
+}
+
+// NumberValue is a constant value
+type NumberValue struct {
+	Value float64
+}
+
+func (v *NumberValue) Evaluate(resolve SLIResolver) (float64, error) {
+	return v.Value, nil
+}
+
+func (v *NumberValue) References() []string {
+	return nil
+}
+
+// SLIReference refers to the value of a SLI
+type SLIReference struct {
+	SLI string
+}
+
+func (r *SLIReference) Evaluate(resolve SLIResolver) (float64, error) {
+	return resolve(r.SLI)
+}
+
+func (r *SLIReference) References() []string {
+	return []string{r.SLI}
+}
+
+// ArithmeticExpression applies one of the operators +, -, * and / to two value expressions
+type ArithmeticExpression struct {
+	Operator string
+	Left     ValueExpression
+	Right    ValueExpression
+}
+
+func (e *ArithmeticExpression) Evaluate(resolve SLIResolver) (float64, error) {
+	left, err := e.Left.Evaluate(resolve)
+	if err != nil {
+		return 0, err
+	}
+	right, err := e.Right.Evaluate(resolve)
+	if err != nil {
+		return 0, err
+	}
+	switch e.Operator {
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/":
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	default:
+		return 0, fmt.Errorf("unknown arithmetic operator %s", e.Operator)
+	}
+}
+
+func (e *ArithmeticExpression) References() []string {
+	return append(e.Left.References(), e.Right.References()...)
+}
+
+// ParseCriteria parses a single criteria string of a SLO objective. Besides the basic form "<operator>[+|-]<value>[%]"
+// (e.g. "<=+10%", "<600", "=0"), the following is supported:
+// - the operators <, <=, =, ==, !=, >= and >
+// - ranges, e.g. "100..500" (inclusive bounds)
+// - combining criteria with AND/OR (or && and ||) and parentheses, e.g. "<=+10% OR <600"
+// - references to other SLIs and arithmetic expressions, e.g. "error_rate < 0.01 * throughput" or "<= 0.5 * throughput"
+func ParseCriteria(criteria string) (CriteriaExpression, error) {
+	tokens, err := tokenizeCriteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+	p := &criteriaParser{tokens: tokens}
+
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, p.errorAt(next, "unexpected %s", next.describe())
+	}
+	return expression, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdentifier
+	tokenComparisonOperator
+	tokenArithmeticOperator
+	tokenLogicalOperator
+	tokenPercent
+	tokenRange
+	tokenOpenParen
+	tokenCloseParen
+)
+
+type criteriaToken struct {
+	kind     tokenKind
+	text     string
+	number   float64
+	position int
+}
+
+func (t criteriaToken) describe() string {
+	if t.kind == tokenEOF {
+		return "end of criteria"
+	}
+	return "'" + t.text + "'"
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func tokenizeCriteria(criteria string) ([]criteriaToken, error) {
+	var tokens []criteriaToken
+	for i := 0; i < len(criteria); {
+		c := criteria[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case isDigit(c) || (c == '.' && i+1 < len(criteria) && isDigit(criteria[i+1])):
+			for i < len(criteria) && isDigit(criteria[i]) {
+				i++
+			}
+			// a decimal point must not be confused with the range operator
+			if i < len(criteria) && criteria[i] == '.' && (i+1 >= len(criteria) || criteria[i+1] != '.') {
+				i++
+				for i < len(criteria) && isDigit(criteria[i]) {
+					i++
+				}
+			}
+			if i < len(criteria) && (criteria[i] == 'e' || criteria[i] == 'E') {
+				j := i + 1
+				if j < len(criteria) && (criteria[j] == '+' || criteria[j] == '-') {
+					j++
+				}
+				if j < len(criteria) && isDigit(criteria[j]) {
+					i = j
+					for i < len(criteria) && isDigit(criteria[i]) {
+						i++
+					}
+				}
+			}
+			value, err := strconv.ParseFloat(criteria[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number '%s' at position %d", criteria[start:i], start+1)
+			}
+			tokens = append(tokens, criteriaToken{kind: tokenNumber, text: criteria[start:i], number: value, position: start})
+			continue
+		case isIdentifierChar(c):
+			for i < len(criteria) && isIdentifierChar(criteria[i]) {
+				i++
+			}
+			text := criteria[start:i]
+			if upper := strings.ToUpper(text); upper == LogicalAnd || upper == LogicalOr {
+				tokens = append(tokens, criteriaToken{kind: tokenLogicalOperator, text: upper, position: start})
+			} else {
+				tokens = append(tokens, criteriaToken{kind: tokenIdentifier, text: text, position: start})
+			}
+			continue
+		}
+
+		twoChars := ""
+		if i+1 < len(criteria) {
+			twoChars = criteria[i : i+2]
+		}
+		switch {
+		case twoChars == "<=" || twoChars == ">=" || twoChars == "!=" || twoChars == "==":
+			tokens = append(tokens, criteriaToken{kind: tokenComparisonOperator, text: twoChars, position: start})
+			i += 2
+		case twoChars == "&&":
+			tokens = append(tokens, criteriaToken{kind: tokenLogicalOperator, text: LogicalAnd, position: start})
+			i += 2
+		case twoChars == "||":
+			tokens = append(tokens, criteriaToken{kind: tokenLogicalOperator, text: LogicalOr, position: start})
+			i += 2
+		case twoChars == "..":
+			tokens = append(tokens, criteriaToken{kind: tokenRange, text: twoChars, position: start})
+			i += 2
+		case c == '<' || c == '>' || c == '=':
+			tokens = append(tokens, criteriaToken{kind: tokenComparisonOperator, text: string(c), position: start})
+			i++
+		case c == '+' || c == '-' || c == '*' || c == '/':
+			tokens = append(tokens, criteriaToken{kind: tokenArithmeticOperator, text: string(c), position: start})
+			i++
+		case c == '%':
+			tokens = append(tokens, criteriaToken{kind: tokenPercent, text: "%", position: start})
+			i++
+		case c == '(':
+			tokens = append(tokens, criteriaToken{kind: tokenOpenParen, text: "(", position: start})
+			i++
+		case c == ')':
+			tokens = append(tokens, criteriaToken{kind: tokenCloseParen, text: ")", position: start})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", c, start+1)
+		}
+	}
+	return append(tokens, criteriaToken{kind: tokenEOF, position: len(criteria)}), nil
+}
+
+type criteriaParser struct {
+	tokens []criteriaToken
+	pos    int
+}
+
+func (p *criteriaParser) peek() criteriaToken {
+	return p.tokens[p.pos]
+}
+
+func (p *criteriaParser) peekAt(offset int) criteriaToken {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *criteriaParser) next() criteriaToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *criteriaParser) errorAt(t criteriaToken, format string, args ...interface{}) error {
+	return &criteriaSyntaxError{position: t.position, message: fmt.Sprintf(format, args...)}
+}
+
+type criteriaSyntaxError struct {
+	position int
+	message  string
+}
+
+func (e *criteriaSyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.message, e.position+1)
+}
+
+func errorPosition(err error) int {
+	if syntaxError, ok := err.(*criteriaSyntaxError); ok {
+		return syntaxError.position
+	}
+	return -1
+}
+
+// parseOr parses: and-expression { OR and-expression }
+func (p *criteriaParser) parseOr() (CriteriaExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenLogicalOperator && p.peek().text == LogicalOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &LogicalExpression{Operator: LogicalOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: group { AND group }
+func (p *criteriaParser) parseAnd() (CriteriaExpression, error) {
+	left, err := p.parseGroup()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenLogicalOperator && p.peek().text == LogicalAnd {
+		p.next()
+		right, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		left = &LogicalExpression{Operator: LogicalAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseGroup parses: "(" or-expression ")" | comparison
+func (p *criteriaParser) parseGroup() (CriteriaExpression, error) {
+	if p.peek().kind != tokenOpenParen {
+		return p.parseComparison()
+	}
+
+	// a parenthesis either groups criteria, e.g. "(<5 OR >10) AND <100", or an arithmetic expression on the
+	// left-hand side of a comparison, e.g. "(a + b) < 10"
+	start := p.pos
+	p.next()
+	expression, groupErr := p.parseOr()
+	if groupErr == nil {
+		if t := p.peek(); t.kind != tokenCloseParen {
+			groupErr = p.errorAt(t, "expected ')' but found %s", t.describe())
+		} else {
+			p.next()
+			return expression, nil
+		}
+	}
+	groupPos := p.pos
+
+	p.pos = start
+	expression, comparisonErr := p.parseComparison()
+	if comparisonErr == nil {
+		return expression, nil
+	}
+	// report the error of the alternative that got further
+	if errorPosition(groupErr) >= errorPosition(comparisonErr) {
+		p.pos = groupPos
+		return nil, groupErr
+	}
+	return nil, comparisonErr
+}
+
+// parseComparison parses: [value-expression] operator target | [sign] number ".." [sign] number
+func (p *criteriaParser) parseComparison() (CriteriaExpression, error) {
+	if rangeExpression, ok, err := p.parseRange(); ok || err != nil {
+		return rangeExpression, err
+	}
+
+	criteria := &Criteria{}
+	if p.peek().kind != tokenComparisonOperator {
+		left, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		criteria.Left = left
+		if t := p.peek(); t.kind != tokenComparisonOperator {
+			return nil, p.errorAt(t, "expected comparison operator (<, <=, =, !=, >=, >) but found %s", t.describe())
+		}
+	}
+	operator := p.next()
+	criteria.Operator = operator.text
+	if criteria.Operator == "==" {
+		criteria.Operator = "="
+	}
+
+	// a signed value without left-hand side is a comparison with previous results, e.g. "<=+10%"
+	if t := p.peek(); criteria.Left == nil && t.kind == tokenArithmeticOperator && (t.text == "+" || t.text == "-") {
+		p.next()
+		value := p.next()
+		if value.kind != tokenNumber {
+			return nil, p.errorAt(value, "expected number after '%s' but found %s", t.text, value.describe())
+		}
+		criteria.IsComparison = true
+		criteria.CheckIncrease = t.text == "+"
+		criteria.Value = value.number
+		if p.peek().kind == tokenPercent {
+			p.next()
+			criteria.CheckPercentage = true
+		}
+		return criteria, nil
+	}
+	if t := p.peek(); criteria.Left != nil && t.kind == tokenArithmeticOperator && (t.text == "+" || (t.text == "-" && p.peekAt(2).kind == tokenPercent)) {
+		return nil, p.errorAt(t, "comparisons with previous results are not supported for explicit left-hand sides")
+	}
+
+	target, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if number, ok := target.(*NumberValue); ok {
+		criteria.Value = number.Value
+		if p.peek().kind == tokenPercent {
+			// the percent sign of absolute values has no effect, e.g. "=0%" equals "=0"
+			p.next()
+			criteria.CheckPercentage = true
+		}
+	} else {
+		criteria.Target = target
+		if t := p.peek(); t.kind == tokenPercent {
+			return nil, p.errorAt(t, "percent sign is only allowed after a number")
+		}
+	}
+	return criteria, nil
+}
+
+// parseRange parses a range "<lower>..<upper>" and translates it into ">=lower AND <=upper"
+func (p *criteriaParser) parseRange() (CriteriaExpression, bool, error) {
+	start := p.pos
+	lower, ok := p.parseSignedNumber()
+	if !ok || p.peek().kind != tokenRange {
+		p.pos = start
+		return nil, false, nil
+	}
+	p.next()
+	t := p.peek()
+	upper, ok := p.parseSignedNumber()
+	if !ok {
+		return nil, false, p.errorAt(t, "expected upper bound of range but found %s", t.describe())
+	}
+	if lower > upper {
+		return nil, false, p.errorAt(p.tokens[start], "lower bound %g of range is greater than upper bound %g", lower, upper)
+	}
+	return &LogicalExpression{
+		Operator: LogicalAnd,
+		Left:     &Criteria{Operator: ">=", Value: lower},
+		Right:    &Criteria{Operator: "<=", Value: upper},
+	}, true, nil
+}
+
+func (p *criteriaParser) parseSignedNumber() (float64, bool) {
+	sign := 1.0
+	if t := p.peek(); t.kind == tokenArithmeticOperator && (t.text == "+" || t.text == "-") {
+		if t.text == "-" {
+			sign = -1.0
+		}
+		p.next()
+	}
+	t := p.next()
+	if t.kind != tokenNumber {
+		return 0, false
+	}
+	return sign * t.number, true
+}
+
+// parseSum parses: product { ("+" | "-") product }
+func (p *criteriaParser) parseSum() (ValueExpression, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == tokenArithmeticOperator && (t.text == "+" || t.text == "-"); t = p.peek() {
+		p.next()
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = &ArithmeticExpression{Operator: t.text, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseProduct parses: factor { ("*" | "/") factor }
+func (p *criteriaParser) parseProduct() (ValueExpression, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == tokenArithmeticOperator && (t.text == "*" || t.text == "/"); t = p.peek() {
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &ArithmeticExpression{Operator: t.text, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseFactor parses: number | sli | "(" sum ")" | "-" factor
+func (p *criteriaParser) parseFactor() (ValueExpression, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenNumber:
+		return &NumberValue{Value: t.number}, nil
+	case t.kind == tokenIdentifier:
+		return &SLIReference{SLI: t.text}, nil
+	case t.kind == tokenArithmeticOperator && t.text == "-":
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		if number, ok := operand.(*NumberValue); ok {
+			return &NumberValue{Value: -number.Value}, nil
+		}
+		return &ArithmeticExpression{Operator: "-", Left: &NumberValue{Value: 0}, Right: operand}, nil
+	case t.kind == tokenOpenParen:
+		expression, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenCloseParen {
+			return nil, p.errorAt(closing, "expected ')' but found %s", closing.describe())
+		}
+		return expression, nil
+	default:
+		return nil, p.errorAt(t, "expected number or SLI name but found %s", t.describe())
+	}
+}
@@ -0,0 +1,277 @@
+package slo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type operatorParserTest struct {
+	Criteria               string
+	ExpectedCriteriaObject *Criteria
+}
+
+func TestParseCriteria(t *testing.T) {
+	tests := []*operatorParserTest{
+		{
+			Criteria: "<10",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        "<",
+				Value:           10,
+				CheckPercentage: false,
+				IsComparison:    false,
+				CheckIncrease:   false,
+			},
+		}, {
+			Criteria: "<=10",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        "<=",
+				Value:           10,
+				CheckPercentage: false,
+				IsComparison:    false,
+				CheckIncrease:   false,
+			},
+		}, {
+			Criteria: "=10",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        "=",
+				Value:           10,
+				CheckPercentage: false,
+				IsComparison:    false,
+				CheckIncrease:   false,
+			},
+		}, {
+			Criteria: ">=10",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        ">=",
+				Value:           10,
+				CheckPercentage: false,
+				IsComparison:    false,
+				CheckIncrease:   false,
+			},
+		}, {
+			Criteria: ">10",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        ">",
+				Value:           10,
+				CheckPercentage: false,
+				IsComparison:    false,
+				CheckIncrease:   false,
+			},
+		}, {
+			Criteria: ">-10%",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        ">",
+				Value:           10,
+				CheckPercentage: true,
+				IsComparison:    true,
+				CheckIncrease:   false,
+			},
+		}, {
+			Criteria: "<=+10.5%",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        "<=",
+				Value:           10.5,
+				CheckPercentage: true,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+		}, {
+			Criteria: "<=+10",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        "<=",
+				Value:           10,
+				CheckPercentage: false,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+		},
+		{
+			Criteria: "  <=+10   %",
+			ExpectedCriteriaObject: &Criteria{
+				Operator:        "<=",
+				Value:           10,
+				CheckPercentage: true,
+				IsComparison:    true,
+				CheckIncrease:   true,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Criteria, func(t *testing.T) {
+			expression, _ := ParseCriteria(test.Criteria)
+			co := expression.(*Criteria)
+			assert.EqualValues(t, test.ExpectedCriteriaObject.Operator, co.Operator)
+			assert.EqualValues(t, test.ExpectedCriteriaObject.Value, co.Value)
+			assert.EqualValues(t, test.ExpectedCriteriaObject.CheckPercentage, co.CheckPercentage)
+			assert.EqualValues(t, test.ExpectedCriteriaObject.IsComparison, co.IsComparison)
+			assert.EqualValues(t, test.ExpectedCriteriaObject.CheckIncrease, co.CheckIncrease)
+		})
+	}
+}
+
+type criteriaExpressionTest struct {
+	Criteria           string
+	ExpectedExpression CriteriaExpression
+	ExpectedError      string
+}
+
+func TestParseCriteriaExpression(t *testing.T) {
+	tests := []*criteriaExpressionTest{
+		{
+			Criteria:           "=0%",
+			ExpectedExpression: &Criteria{Operator: "=", Value: 0, CheckPercentage: true},
+		},
+		{
+			Criteria:           "<.5",
+			ExpectedExpression: &Criteria{Operator: "<", Value: 0.5},
+		},
+		{
+			Criteria:           "!=0",
+			ExpectedExpression: &Criteria{Operator: "!=", Value: 0},
+		},
+		{
+			Criteria:           "==1e3",
+			ExpectedExpression: &Criteria{Operator: "=", Value: 1000},
+		},
+		{
+			Criteria: "100..500",
+			ExpectedExpression: &LogicalExpression{
+				Operator: LogicalAnd,
+				Left:     &Criteria{Operator: ">=", Value: 100},
+				Right:    &Criteria{Operator: "<=", Value: 500},
+			},
+		},
+		{
+			Criteria: "-0.5 .. 0.5",
+			ExpectedExpression: &LogicalExpression{
+				Operator: LogicalAnd,
+				Left:     &Criteria{Operator: ">=", Value: -0.5},
+				Right:    &Criteria{Operator: "<=", Value: 0.5},
+			},
+		},
+		{
+			Criteria: "<=+10% OR <600 AND >0",
+			ExpectedExpression: &LogicalExpression{
+				Operator: LogicalOr,
+				Left:     &Criteria{Operator: "<=", Value: 10, CheckPercentage: true, IsComparison: true, CheckIncrease: true},
+				Right: &LogicalExpression{
+					Operator: LogicalAnd,
+					Left:     &Criteria{Operator: "<", Value: 600},
+					Right:    &Criteria{Operator: ">", Value: 0},
+				},
+			},
+		},
+		{
+			Criteria: "(<5 || >10) && <100",
+			ExpectedExpression: &LogicalExpression{
+				Operator: LogicalAnd,
+				Left: &LogicalExpression{
+					Operator: LogicalOr,
+					Left:     &Criteria{Operator: "<", Value: 5},
+					Right:    &Criteria{Operator: ">", Value: 10},
+				},
+				Right: &Criteria{Operator: "<", Value: 100},
+			},
+		},
+		{
+			Criteria: "error_rate < 0.01 * throughput",
+			ExpectedExpression: &Criteria{
+				Operator: "<",
+				Left:     &SLIReference{SLI: "error_rate"},
+				Target: &ArithmeticExpression{
+					Operator: "*",
+					Left:     &NumberValue{Value: 0.01},
+					Right:    &SLIReference{SLI: "throughput"},
+				},
+			},
+		},
+		{
+			Criteria: "(errors + timeouts) / requests <= 0.05",
+			ExpectedExpression: &Criteria{
+				Operator: "<=",
+				Value:    0.05,
+				Left: &ArithmeticExpression{
+					Operator: "/",
+					Left: &ArithmeticExpression{
+						Operator: "+",
+						Left:     &SLIReference{SLI: "errors"},
+						Right:    &SLIReference{SLI: "timeouts"},
+					},
+					Right: &SLIReference{SLI: "requests"},
+				},
+			},
+		},
+		{
+			Criteria: "<= 2 * response_time_p50 - 10",
+			ExpectedExpression: &Criteria{
+				Operator: "<=",
+				Target: &ArithmeticExpression{
+					Operator: "-",
+					Left: &ArithmeticExpression{
+						Operator: "*",
+						Left:     &NumberValue{Value: 2},
+						Right:    &SLIReference{SLI: "response_time_p50"},
+					},
+					Right: &NumberValue{Value: 10},
+				},
+			},
+		},
+		{
+			Criteria:      "|<10",
+			ExpectedError: "unexpected character '|' at position 1",
+		},
+		{
+			Criteria:      "<<10",
+			ExpectedError: "expected number or SLI name but found '<' at position 2",
+		},
+		{
+			Criteria:      "10",
+			ExpectedError: "expected comparison operator (<, <=, =, !=, >=, >) but found end of criteria at position 3",
+		},
+		{
+			Criteria:      "<+",
+			ExpectedError: "expected number after '+' but found end of criteria at position 3",
+		},
+		{
+			Criteria:      "<10 AND",
+			ExpectedError: "expected number or SLI name but found end of criteria at position 8",
+		},
+		{
+			Criteria:      "<10 >5",
+			ExpectedError: "unexpected '>' at position 5",
+		},
+		{
+			Criteria:      "500..100",
+			ExpectedError: "lower bound 500 of range is greater than upper bound 100 at position 1",
+		},
+		{
+			Criteria:      "100..",
+			ExpectedError: "expected upper bound of range but found end of criteria at position 6",
+		},
+		{
+			Criteria:      "(<10 OR >20",
+			ExpectedError: "expected ')' but found end of criteria at position 12",
+		},
+		{
+			Criteria:      "error_rate <= +10%",
+			ExpectedError: "comparisons with previous results are not supported for explicit left-hand sides at position 15",
+		},
+		{
+			Criteria:      "< throughput %",
+			ExpectedError: "percent sign is only allowed after a number at position 14",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Criteria, func(t *testing.T) {
+			expression, err := ParseCriteria(test.Criteria)
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				assert.Nil(t, expression)
+				return
+			}
+			assert.Nil(t, err)
+			assert.EqualValues(t, test.ExpectedExpression, expression)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package slo
+
+// The SLO model mirrors github.com/keptn/go-utils/pkg/models/v2 and extends it with the
+// settings that are only interpreted by the lighthouse-service.
+
+type SLOComparison struct {
+	CompareWith               string       `json:"compare_with" yaml:"compare_with"`                           // single_result|several_results
+	IncludeResultWithScore    string       `json:"include_result_with_score" yaml:"include_result_with_score"` // all|pass|pass_or_warn
+	NumberOfComparisonResults int          `json:"number_of_comparison_results" yaml:"number_of_comparison_results"`
+	AggregateFunction         string       `json:"aggregate_function" yaml:"aggregate_function"`
+	Mode                      string       `json:"mode,omitempty" yaml:"mode,omitempty"` // aggregate|zscore
+	Baseline                  *SLOBaseline `json:"baseline,omitempty" yaml:"baseline,omitempty"`
+}
+
+// SLOBaseline pins the evaluations used for the comparison to a given keptnContext or to the evaluations carrying
+// the given labels, instead of the last evaluations of the service
+type SLOBaseline struct {
+	KeptnContext string            `json:"keptn_context,omitempty" yaml:"keptn_context,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+type SLOCriteria struct {
+	Criteria []string `json:"criteria" yaml:"criteria"`
+}
+
+type SLO struct {
+	SLI     string         `json:"sli" yaml:"sli"`
+	Pass    []*SLOCriteria `json:"pass" yaml:"pass"`
+	Warning []*SLOCriteria `json:"warning" yaml:"warning"`
+	Weight  int            `json:"weight" yaml:"weight"`
+	KeySLI  bool           `json:"key_sli" yaml:"key_sli"`
+	// Comparison overrides the global comparison settings for this objective
+	Comparison *SLOComparison `json:"comparison,omitempty" yaml:"comparison,omitempty"`
+	// OnMissing defines how the objective is scored if no value has been retrieved for the SLI
+	OnMissing string `json:"on_missing,omitempty" yaml:"on_missing,omitempty"` // fail|ignore|warn|key_sli
+	// Trend fails the objective if the SLI values of the previous evaluations are changing too fast
+	Trend *SLOTrend `json:"trend,omitempty" yaml:"trend,omitempty"`
+}
+
+// SLOTrend defines the maximum slope of a linear regression over the SLI values of the previous evaluations and the
+// current evaluation. Slopes are specified per evaluation with a sign indicating the direction, either relative to
+// the average value (e.g. +2%) or absolute (e.g. -50)
+type SLOTrend struct {
+	NumberOfResults int    `json:"number_of_results,omitempty" yaml:"number_of_results,omitempty"`
+	MaxSlope        string `json:"max_slope" yaml:"max_slope"`
+	WarningSlope    string `json:"warning_slope,omitempty" yaml:"warning_slope,omitempty"`
+}
+
+type SLOScore struct {
+	Pass    string `json:"pass" yaml:"pass"`
+	Warning string `json:"warning" yaml:"warning"`
+}
+
+// ServiceLevelObjectives describes SLO requirements
+type ServiceLevelObjectives struct {
+	SpecVersion string            `json:"spec_version" yaml:"spec_version"`
+	Filter      map[string]string `json:"filter" yaml:"filter"`
+	Comparison  *SLOComparison    `json:"comparison" yaml:"comparison"`
+	Objectives  []*SLO            `json:"objectives" yaml:"objectives"`
+	TotalScore  *SLOScore         `json:"total_score" yaml:"total_score"`
+}
+
+const (
+	// ComparisonModeAggregate compares the SLI value with the aggregated previous values (default)
+	ComparisonModeAggregate = "aggregate"
+	// ComparisonModeZScore interprets relative criteria as number of standard deviations from the mean of the previous values
+	ComparisonModeZScore = "zscore"
+)
+
+const (
+	// MissingSLIFail fails the objective if the SLI value is missing (default)
+	MissingSLIFail = "fail"
+	// MissingSLIIgnore excludes the objective from the total score if the SLI value is missing
+	MissingSLIIgnore = "ignore"
+	// MissingSLIWarn scores the objective with a warning if the SLI value is missing
+	MissingSLIWarn = "warn"
+	// MissingSLIKeySLI fails the objective and the whole evaluation if the SLI value is missing
+	MissingSLIKeySLI = "key_sli"
+)
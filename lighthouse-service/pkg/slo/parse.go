@@ -0,0 +1,72 @@
+package slo
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+)
+
+// Parse unmarshals the content of a slo.yaml file and applies the default values
+func Parse(input []byte) (*ServiceLevelObjectives, error) {
+	slo := &ServiceLevelObjectives{}
+
+	err := yaml.Unmarshal(input, &slo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if slo.Comparison == nil {
+		slo.Comparison = &SLOComparison{
+			CompareWith:               "single_result",
+			IncludeResultWithScore:    "all",
+			NumberOfComparisonResults: 1,
+			AggregateFunction:         "avg",
+		}
+	}
+
+	if slo.Comparison != nil {
+		if slo.Comparison.IncludeResultWithScore == "" {
+			slo.Comparison.IncludeResultWithScore = "all"
+		}
+		if slo.Comparison.NumberOfComparisonResults == 0 {
+			slo.Comparison.NumberOfComparisonResults = DefaultComparisonResults
+		}
+		if slo.Comparison.AggregateFunction == "" {
+			slo.Comparison.AggregateFunction = "avg"
+		}
+	}
+	for _, objective := range slo.Objectives {
+		if objective.Weight == 0 {
+			objective.Weight = 1
+		}
+		if objective.Trend != nil && objective.Trend.NumberOfResults == 0 {
+			objective.Trend.NumberOfResults = DefaultTrendResults
+		}
+	}
+
+	return slo, nil
+}
+
+// ParseAndValidate parses the content of a slo.yaml file and returns all problems found in it. In contrast to Parse,
+// unknown properties (e.g. misspelled keys) are reported as well
+func ParseAndValidate(input []byte) (*ServiceLevelObjectives, []*ValidationError) {
+	jsonContent, err := yaml.YAMLToJSON(input)
+	if err != nil {
+		return nil, []*ValidationError{{Message: err.Error()}}
+	}
+
+	var validationErrors []*ValidationError
+	decoder := json.NewDecoder(bytes.NewReader(jsonContent))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&ServiceLevelObjectives{}); err != nil {
+		validationErrors = append(validationErrors, &ValidationError{Message: err.Error()})
+	}
+
+	slo, err := Parse(input)
+	if err != nil {
+		return nil, append(validationErrors, &ValidationError{Message: err.Error()})
+	}
+	return slo, append(validationErrors, Validate(slo)...)
+}
@@ -0,0 +1,97 @@
+package slo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultTrendResults is the number of previous evaluations a trend is calculated on if not specified otherwise
+const DefaultTrendResults = 10
+
+// Slope is a parsed slope of a trend, e.g. +2% or -50
+type Slope struct {
+	// Value is the maximum change per evaluation, negative for decreasing values
+	Value float64
+	// CheckPercentage indicates that the value is relative to the average SLI value
+	CheckPercentage bool
+}
+
+// ParseSlope parses the max_slope or warning_slope of a trend. The sign is mandatory
+func ParseSlope(slope string) (*Slope, error) {
+	slope = strings.TrimSpace(slope)
+	if !strings.HasPrefix(slope, "+") && !strings.HasPrefix(slope, "-") {
+		return nil, fmt.Errorf("slope %s requires a sign (+ or -)", slope)
+	}
+	parsed := &Slope{}
+	if strings.HasSuffix(slope, "%") {
+		parsed.CheckPercentage = true
+		slope = strings.TrimSuffix(slope, "%")
+	}
+	value, err := strconv.ParseFloat(slope, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse slope %s", slope)
+	}
+	parsed.Value = value
+	return parsed, nil
+}
+
+// Increasing returns true if the slope limits the increase of the SLI values
+func (s *Slope) Increasing() bool {
+	return s.Value >= 0
+}
+
+// Exceeds checks if the given slope per evaluation exceeds this slope in its direction
+func (s *Slope) Exceeds(slope float64) bool {
+	if s.Increasing() {
+		return slope > s.Value
+	}
+	return slope < s.Value
+}
+
+// String returns the slope with sign, e.g. +2%
+func (s *Slope) String() string {
+	result := strconv.FormatFloat(s.Value, 'f', -1, 64)
+	if s.Increasing() {
+		result = "+" + result
+	}
+	if s.CheckPercentage {
+		result += "%"
+	}
+	return result
+}
+
+// CalculateSlope fits a linear regression over the given values, ordered from the oldest to the most recent value, and
+// returns the change per value. If relative is set, the change is returned in percent of the average value
+func CalculateSlope(values []float64, relative bool) float64 {
+	n := float64(len(values))
+	if len(values) < 2 {
+		return 0
+	}
+	meanX := (n - 1) / 2
+	meanY := CalculateAverage(values)
+	numerator := 0.0
+	denominator := 0.0
+	for i, value := range values {
+		dx := float64(i) - meanX
+		numerator += dx * (value - meanY)
+		denominator += dx * dx
+	}
+	slope := numerator / denominator
+	if relative {
+		if meanY == 0 {
+			return 0
+		}
+		return 100 * slope / math.Abs(meanY)
+	}
+	return slope
+}
+
+// NumberOfPreviousResults returns the number of previous evaluations the trend is calculated on
+func (t *SLOTrend) NumberOfPreviousResults() int {
+	if t.NumberOfResults <= 0 {
+		return DefaultTrendResults
+	}
+	return t.NumberOfResults
+}
@@ -0,0 +1,66 @@
+package slo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSlope(t *testing.T) {
+	tests := []struct {
+		Name          string
+		InSlope       string
+		ExpectedSlope *Slope
+		ExpectedError error
+	}{
+		{
+			Name:          "relative increase",
+			InSlope:       "+2%",
+			ExpectedSlope: &Slope{Value: 2, CheckPercentage: true},
+		},
+		{
+			Name:          "absolute decrease",
+			InSlope:       "-50",
+			ExpectedSlope: &Slope{Value: -50},
+		},
+		{
+			Name:          "missing sign",
+			InSlope:       "2%",
+			ExpectedError: errors.New("slope 2% requires a sign (+ or -)"),
+		},
+		{
+			Name:          "invalid number",
+			InSlope:       "+two",
+			ExpectedError: errors.New("could not parse slope +two"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			slope, err := ParseSlope(test.InSlope)
+			assert.EqualValues(t, test.ExpectedError, err)
+			assert.EqualValues(t, test.ExpectedSlope, slope)
+		})
+	}
+}
+
+func TestSlopeExceeds(t *testing.T) {
+	increase := &Slope{Value: 2, CheckPercentage: true}
+	assert.True(t, increase.Exceeds(2.5))
+	assert.False(t, increase.Exceeds(2))
+	assert.False(t, increase.Exceeds(-10))
+	assert.Equal(t, "+2%", increase.String())
+
+	decrease := &Slope{Value: -50}
+	assert.True(t, decrease.Exceeds(-60))
+	assert.False(t, decrease.Exceeds(10))
+	assert.Equal(t, "-50", decrease.String())
+}
+
+func TestCalculateSlope(t *testing.T) {
+	assert.InDelta(t, 2.0, CalculateSlope([]float64{10, 12, 14, 16}, false), 0.0001)
+	assert.InDelta(t, 15.3846, CalculateSlope([]float64{10, 12, 14, 16}, true), 0.0001)
+	assert.InDelta(t, -1.0, CalculateSlope([]float64{3, 2, 1}, false), 0.0001)
+	assert.InDelta(t, 0.0, CalculateSlope([]float64{5}, false), 0.0001)
+	assert.InDelta(t, 0.0, CalculateSlope([]float64{0, 0, 0}, true), 0.0001)
+}
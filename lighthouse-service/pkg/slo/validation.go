@@ -0,0 +1,212 @@
+package slo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes a problem in a SLO file
+type ValidationError struct {
+	// Field is the path of the invalid property, e.g. objectives[0].pass[1].criteria[0]
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Validate checks a parsed SLO file for problems that would otherwise only surface during the evaluation
+func Validate(slo *ServiceLevelObjectives) []*ValidationError {
+	var validationErrors []*ValidationError
+	addError := func(field string, format string, args ...interface{}) {
+		validationErrors = append(validationErrors, &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if slo.Comparison != nil {
+		validationErrors = append(validationErrors, validateComparison("comparison", slo.Comparison)...)
+	}
+
+	slis := map[string]bool{}
+	for _, objective := range slo.Objectives {
+		slis[objective.SLI] = true
+	}
+
+	scoredObjectives := false
+	for i, objective := range slo.Objectives {
+		field := fmt.Sprintf("objectives[%d]", i)
+		if objective.SLI == "" {
+			addError(field+".sli", "no SLI defined")
+		}
+		if objective.Weight < 0 {
+			addError(field+".weight", "must be a positive number")
+		}
+		if len(objective.Pass) > 0 || objective.Trend != nil {
+			scoredObjectives = true
+		}
+		switch objective.OnMissing {
+		case "", MissingSLIFail, MissingSLIIgnore, MissingSLIWarn, MissingSLIKeySLI:
+		default:
+			addError(field+".on_missing", "unknown value %s, expected fail, ignore, warn or key_sli", objective.OnMissing)
+		}
+		if objective.Trend != nil {
+			validationErrors = append(validationErrors, validateTrend(field+".trend", objective.Trend)...)
+		}
+		if objective.Comparison != nil {
+			validationErrors = append(validationErrors, validateComparison(field+".comparison", objective.Comparison)...)
+		}
+		comparison := slo.EffectiveComparison(objective)
+		for j, criteriaSet := range objective.Pass {
+			validationErrors = append(validationErrors, validateCriteriaSet(fmt.Sprintf("%s.pass[%d]", field, j), criteriaSet, comparison, slis)...)
+		}
+		for j, criteriaSet := range objective.Warning {
+			validationErrors = append(validationErrors, validateCriteriaSet(fmt.Sprintf("%s.warning[%d]", field, j), criteriaSet, comparison, slis)...)
+		}
+	}
+
+	if slo.TotalScore == nil || slo.TotalScore.Pass == "" {
+		if scoredObjectives {
+			addError("total_score.pass", "no target score defined")
+		}
+		return validationErrors
+	}
+	passTarget, err := parseScorePercentage(slo.TotalScore.Pass)
+	if err != nil {
+		addError("total_score.pass", "%s", err.Error())
+	}
+	if slo.TotalScore.Warning != "" {
+		warningTarget, err := parseScorePercentage(slo.TotalScore.Warning)
+		if err != nil {
+			addError("total_score.warning", "%s", err.Error())
+		} else if warningTarget > passTarget {
+			addError("total_score.warning", "warning target %s is higher than pass target %s", slo.TotalScore.Warning, slo.TotalScore.Pass)
+		}
+	}
+	return validationErrors
+}
+
+// validateComparison checks a comparison block. Empty properties are valid because they are either defaulted or, for the
+// comparison block of an objective, inherited from the global comparison settings
+func validateComparison(field string, comparison *SLOComparison) []*ValidationError {
+	var validationErrors []*ValidationError
+	addError := func(property string, format string, args ...interface{}) {
+		validationErrors = append(validationErrors, &ValidationError{Field: field + "." + property, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch comparison.CompareWith {
+	case "", "single_result", "several_results":
+	default:
+		addError("compare_with", "unknown value %s, expected single_result or several_results", comparison.CompareWith)
+	}
+	switch comparison.IncludeResultWithScore {
+	case "", "all", "pass", "pass_or_warn":
+	default:
+		addError("include_result_with_score", "unknown value %s, expected all, pass or pass_or_warn", comparison.IncludeResultWithScore)
+	}
+	if comparison.NumberOfComparisonResults < 0 {
+		addError("number_of_comparison_results", "must be a positive number")
+	}
+	switch comparison.Mode {
+	case "", ComparisonModeAggregate:
+		if comparison.AggregateFunction == "" {
+			break
+		}
+		if _, err := GetAggregationFunction(comparison.AggregateFunction); err != nil {
+			addError("aggregate_function", "%s", err.Error())
+		}
+	case ComparisonModeZScore:
+	default:
+		addError("mode", "unknown comparison mode %s", comparison.Mode)
+	}
+	if comparison.Baseline != nil {
+		if comparison.Baseline.KeptnContext != "" && len(comparison.Baseline.Labels) > 0 {
+			addError("baseline", "keptn_context and labels cannot be combined")
+		} else if comparison.Baseline.Key() == "" {
+			addError("baseline", "either keptn_context or labels must be set")
+		}
+	}
+	return validationErrors
+}
+
+func validateTrend(field string, trend *SLOTrend) []*ValidationError {
+	var validationErrors []*ValidationError
+	addError := func(property string, format string, args ...interface{}) {
+		validationErrors = append(validationErrors, &ValidationError{Field: field + "." + property, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if trend.NumberOfResults < 0 || trend.NumberOfResults == 1 {
+		addError("number_of_results", "must be at least 2")
+	}
+	if trend.MaxSlope == "" {
+		addError("max_slope", "no maximum slope defined")
+		return validationErrors
+	}
+	maxSlope, err := ParseSlope(trend.MaxSlope)
+	if err != nil {
+		addError("max_slope", "%s", err.Error())
+		return validationErrors
+	}
+	if trend.WarningSlope == "" {
+		return validationErrors
+	}
+	warningSlope, err := ParseSlope(trend.WarningSlope)
+	if err != nil {
+		addError("warning_slope", "%s", err.Error())
+	} else if warningSlope.Increasing() != maxSlope.Increasing() || warningSlope.CheckPercentage != maxSlope.CheckPercentage {
+		addError("warning_slope", "warning slope %s must have the same direction and unit as maximum slope %s", trend.WarningSlope, trend.MaxSlope)
+	} else if !warningSlope.Exceeds(maxSlope.Value) && warningSlope.Value != maxSlope.Value {
+		addError("warning_slope", "warning slope %s is steeper than maximum slope %s", trend.WarningSlope, trend.MaxSlope)
+	}
+	return validationErrors
+}
+
+func validateCriteriaSet(field string, criteriaSet *SLOCriteria, comparison *SLOComparison, slis map[string]bool) []*ValidationError {
+	if criteriaSet == nil || len(criteriaSet.Criteria) == 0 {
+		return []*ValidationError{{Field: field + ".criteria", Message: "no criteria defined"}}
+	}
+	var validationErrors []*ValidationError
+	for i, criteria := range criteriaSet.Criteria {
+		criteriaField := fmt.Sprintf("%s.criteria[%d]", field, i)
+		expression, err := ParseCriteria(criteria)
+		if err != nil {
+			validationErrors = append(validationErrors, &ValidationError{
+				Field:   criteriaField,
+				Message: fmt.Sprintf("invalid criteria %s: %s", criteria, err.Error()),
+			})
+			continue
+		}
+		for _, co := range CriteriaLeaves(expression) {
+			if co.IsComparison && co.CheckPercentage && comparison != nil && comparison.Mode == ComparisonModeZScore {
+				validationErrors = append(validationErrors, &ValidationError{
+					Field:   criteriaField,
+					Message: "percentage criteria are not supported in zscore comparison mode: " + criteria,
+				})
+			}
+		}
+		for _, reference := range expression.References() {
+			// only the SLIs of the objectives are retrieved from the SLI provider
+			if !slis[reference] {
+				validationErrors = append(validationErrors, &ValidationError{
+					Field:   criteriaField,
+					Message: fmt.Sprintf("referenced SLI %s is not defined in the objectives: %s", reference, criteria),
+				})
+			}
+		}
+	}
+	return validationErrors
+}
+
+func parseScorePercentage(score string) (float64, error) {
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(score, "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse target percentage %s", score)
+	}
+	if percentage < 0 || percentage > 100 {
+		return 0, fmt.Errorf("target percentage %s is not between 0%% and 100%%", score)
+	}
+	return percentage, nil
+}
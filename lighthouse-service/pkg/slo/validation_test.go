@@ -0,0 +1,236 @@
+package slo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validateTestObject struct {
+	Name           string
+	SLOFileContent string
+	ExpectedErrors []*ValidationError
+}
+
+func TestParseAndValidate(t *testing.T) {
+	tests := []*validateTestObject{
+		{
+			Name: "Valid SLO file",
+			SLOFileContent: `---
+spec_version: '1.0'
+comparison:
+  compare_with: "several_results"
+  include_result_with_score: "pass"
+  number_of_comparison_results: 3
+  aggregate_function: p90
+objectives:
+  - sli: responseTime95
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+    warning:
+      - criteria:
+          - "<=800"
+  - sli: throughput
+total_score:
+  pass: "90%"
+  warning: "75%"`,
+			ExpectedErrors: nil,
+		},
+		{
+			Name: "Misspelled property",
+			SLOFileContent: `---
+spec_version: '1.0'
+comparison:
+  aggregate_funtion: avg
+objectives:
+  - sli: responseTime95
+total_score:
+  pass: "90%"`,
+			ExpectedErrors: []*ValidationError{
+				{Message: `json: unknown field "aggregate_funtion"`},
+			},
+		},
+		{
+			Name: "Invalid comparison",
+			SLOFileContent: `---
+spec_version: '1.0'
+comparison:
+  compare_with: "all_results"
+  include_result_with_score: "warning"
+  number_of_comparison_results: -1
+  aggregate_function: p900
+objectives:
+  - sli: responseTime95
+total_score:
+  pass: "90%"`,
+			ExpectedErrors: []*ValidationError{
+				{Field: "comparison.compare_with", Message: "unknown value all_results, expected single_result or several_results"},
+				{Field: "comparison.include_result_with_score", Message: "unknown value warning, expected all, pass or pass_or_warn"},
+				{Field: "comparison.number_of_comparison_results", Message: "must be a positive number"},
+				{Field: "comparison.aggregate_function", Message: "unknown aggregate function p900"},
+			},
+		},
+		{
+			Name: "Invalid objectives",
+			SLOFileContent: `---
+spec_version: '1.0'
+comparison:
+  mode: zscore
+objectives:
+  - sli: responseTime95
+    weight: -1
+    on_missing: skip
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "10"
+    warning:
+      - criteria: []
+  - pass:
+      - criteria:
+          - "<=+2"
+total_score:
+  pass: "90%"`,
+			ExpectedErrors: []*ValidationError{
+				{Field: "objectives[0].weight", Message: "must be a positive number"},
+				{Field: "objectives[0].on_missing", Message: "unknown value skip, expected fail, ignore, warn or key_sli"},
+				{Field: "objectives[0].pass[0].criteria[0]", Message: "percentage criteria are not supported in zscore comparison mode: <=+10%"},
+				{Field: "objectives[0].pass[0].criteria[1]", Message: "invalid criteria 10: expected comparison operator (<, <=, =, !=, >=, >) but found end of criteria at position 3"},
+				{Field: "objectives[0].warning[0].criteria", Message: "no criteria defined"},
+				{Field: "objectives[1].sli", Message: "no SLI defined"},
+			},
+		},
+		{
+			Name: "Invalid objective comparison",
+			SLOFileContent: `---
+spec_version: '1.0'
+objectives:
+  - sli: responseTime95
+    comparison:
+      compare_with: "all_results"
+      aggregate_function: p900
+      baseline:
+        keptn_context: "c1d8a2b4-3d3f-4c6b-a0e2-2a6c58d1a3d1"
+        labels:
+          release: stable
+  - sli: errorRate
+    comparison:
+      mode: zscore
+      baseline: {}
+    pass:
+      - criteria:
+          - "<=+10%"
+total_score:
+  pass: "90%"`,
+			ExpectedErrors: []*ValidationError{
+				{Field: "objectives[0].comparison.compare_with", Message: "unknown value all_results, expected single_result or several_results"},
+				{Field: "objectives[0].comparison.aggregate_function", Message: "unknown aggregate function p900"},
+				{Field: "objectives[0].comparison.baseline", Message: "keptn_context and labels cannot be combined"},
+				{Field: "objectives[1].comparison.baseline", Message: "either keptn_context or labels must be set"},
+				{Field: "objectives[1].pass[0].criteria[0]", Message: "percentage criteria are not supported in zscore comparison mode: <=+10%"},
+			},
+		},
+		{
+			Name: "Invalid trend",
+			SLOFileContent: `---
+spec_version: '1.0'
+objectives:
+  - sli: responseTime95
+    trend:
+      number_of_results: 1
+      max_slope: "2%"
+  - sli: throughput
+    trend:
+      max_slope: "-5%"
+      warning_slope: "-10%"
+  - sli: errorRate
+    trend:
+      warning_slope: "+1"
+total_score:
+  pass: "90%"`,
+			ExpectedErrors: []*ValidationError{
+				{Field: "objectives[0].trend.number_of_results", Message: "must be at least 2"},
+				{Field: "objectives[0].trend.max_slope", Message: "slope 2% requires a sign (+ or -)"},
+				{Field: "objectives[1].trend.warning_slope", Message: "warning slope -10% is steeper than maximum slope -5%"},
+				{Field: "objectives[2].trend.max_slope", Message: "no maximum slope defined"},
+			},
+		},
+		{
+			Name: "Reference to an undefined SLI",
+			SLOFileContent: `---
+spec_version: '1.0'
+objectives:
+  - sli: error_rate
+    pass:
+      - criteria:
+          - "< 0.01 * throughput"
+total_score:
+  pass: "90%"`,
+			ExpectedErrors: []*ValidationError{
+				{Field: "objectives[0].pass[0].criteria[0]", Message: "referenced SLI throughput is not defined in the objectives: < 0.01 * throughput"},
+			},
+		},
+		{
+			Name: "Missing total score",
+			SLOFileContent: `---
+spec_version: '1.0'
+objectives:
+  - sli: responseTime95
+    pass:
+      - criteria:
+          - "<600"`,
+			ExpectedErrors: []*ValidationError{
+				{Field: "total_score.pass", Message: "no target score defined"},
+			},
+		},
+		{
+			Name: "Missing total score without scored objectives",
+			SLOFileContent: `---
+spec_version: '1.0'
+objectives:
+  - sli: responseTime95`,
+			ExpectedErrors: nil,
+		},
+		{
+			Name: "Invalid total score",
+			SLOFileContent: `---
+spec_version: '1.0'
+objectives:
+  - sli: responseTime95
+    pass:
+      - criteria:
+          - "<600"
+total_score:
+  pass: "90%"
+  warning: "95%"`,
+			ExpectedErrors: []*ValidationError{
+				{Field: "total_score.warning", Message: "warning target 95% is higher than pass target 90%"},
+			},
+		},
+		{
+			Name: "Unparsable total score",
+			SLOFileContent: `---
+spec_version: '1.0'
+objectives:
+  - sli: responseTime95
+    pass:
+      - criteria:
+          - "<600"
+total_score:
+  pass: "ninety"
+  warning: "175%"`,
+			ExpectedErrors: []*ValidationError{
+				{Field: "total_score.pass", Message: "could not parse target percentage ninety"},
+				{Field: "total_score.warning", Message: "target percentage 175% is not between 0% and 100%"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, validationErrors := ParseAndValidate([]byte(test.SLOFileContent))
+			assert.EqualValues(t, test.ExpectedErrors, validationErrors)
+		})
+	}
+}
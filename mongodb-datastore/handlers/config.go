@@ -1,11 +1,22 @@
 package handlers
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 var mongoDBConnection = os.Getenv("MONGO_DB_CONNECTION_STRING")
 var mongoDBName = os.Getenv("MONGO_DB_NAME")
 
+// retentionConfig, retentionIntervalConfig and archiveDirectory configure the deletion of expired events and logs
+var retentionConfig = os.Getenv("RETENTION_POLICIES")
+var retentionIntervalConfig = os.Getenv("RETENTION_INTERVAL")
+var archiveDirectory = os.Getenv("ARCHIVE_DIRECTORY")
+
 const eventsCollectionName = "events"
 const logsCollectionName = "logs"
 
 const serviceName = "mongodb-datastore"
+
+// requestTimeout limits the time a request may spend in MongoDB
+const requestTimeout = 5 * time.Second
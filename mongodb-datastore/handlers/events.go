@@ -4,9 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"strings"
 	"time"
 
+	strfmt "github.com/go-openapi/strfmt"
 	"github.com/jeremywohl/flatten"
 
 	keptnutils "github.com/keptn/go-utils/pkg/utils"
@@ -15,35 +16,26 @@ import (
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // SaveEvent stores event in data store
-func SaveEvent(event *models.KeptnContextExtendedCE) error {
+func SaveEvent(ctx context.Context, event *models.KeptnContextExtendedCE) error {
 	logger := keptnutils.NewLogger("", "", serviceName)
 	logger.Debug("save event to data store")
 
-	client, err := mongo.NewClient(options.Client().ApplyURI(mongoDBConnection))
-	if err != nil {
-		err := fmt.Errorf("failed to create mongo client: %v", err)
-		logger.Error(err.Error())
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	err = client.Connect(ctx)
+	collection, err := getCollection(eventsCollectionName)
 	if err != nil {
-		err := fmt.Errorf("failed to connect: %v", err)
 		logger.Error(err.Error())
 		return err
 	}
 
-	collection := client.Database(mongoDBName).Collection(eventsCollectionName)
-
+	// times are stored in UTC, hence they can be sorted and compared as strings
+	event.Time = models.Time(time.Time(event.Time).UTC())
 	data, err := json.Marshal(event)
 	if err != nil {
 		err := fmt.Errorf("failed to marshal event: %v", err)
@@ -71,35 +63,69 @@ func SaveEvent(event *models.KeptnContextExtendedCE) error {
 }
 
 // GetEvents returns all events from the data store sorted by time
-func GetEvents(params event.GetEventsParams) (*event.GetEventsOKBody, error) {
+func GetEvents(ctx context.Context, params event.GetEventsParams) (*event.GetEventsOKBody, error) {
 	logger := keptnutils.NewLogger("", "", serviceName)
 	logger.Debug("getting events from the data store")
 
-	client, err := mongo.NewClient(options.Client().ApplyURI(mongoDBConnection))
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	collection, err := getCollection(eventsCollectionName)
 	if err != nil {
-		err := fmt.Errorf("failed to create client: %v", err)
 		logger.Error(err.Error())
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	searchOptions := getSearchOptions(params)
 
-	err = client.Connect(ctx)
-	if err != nil {
-		err := fmt.Errorf("failed to connect: %v", err)
-		logger.Error(err.Error())
-		return nil, err
+	var result event.GetEventsOKBody
+
+	if params.Root != nil {
+		result.Events, err = getRootEvents(ctx, collection, searchOptions, logger)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pageSize := *params.PageSize
+		if params.IncludeTotalCount != nil && *params.IncludeTotalCount {
+			result.TotalCount, err = collection.CountDocuments(ctx, searchOptions)
+			if err != nil {
+				err := fmt.Errorf("failed to count elements in events collection: %v", err)
+				logger.Error(err.Error())
+				return nil, err
+			}
+		}
+
+		result.NextPageKey, err = findPage(ctx, collection, searchOptions, "time", pageSize, params.NextPageKey, func(cur *mongo.Cursor) error {
+			keptnEvent, err := decodeEvent(cur, logger)
+			if keptnEvent != nil {
+				result.Events = append(result.Events, keptnEvent)
+			}
+			return err
+		})
+		if err == ErrInvalidPageKey {
+			return nil, err
+		} else if err != nil {
+			err := fmt.Errorf("failed to find elements in events collection: %v", err)
+			logger.Error(err.Error())
+			return nil, err
+		}
+		result.PageSize = pageSize
 	}
 
-	collection := client.Database(mongoDBName).Collection(eventsCollectionName)
+	return &result, nil
+}
 
+// getSearchOptions returns the filter of the events matching all given parameters
+func getSearchOptions(params event.GetEventsParams) bson.M {
 	searchOptions := bson.M{}
 	if params.KeptnContext != nil {
-		searchOptions["shkeptncontext"] = primitive.Regex{Pattern: *params.KeptnContext, Options: ""}
+		searchOptions["shkeptncontext"] = *params.KeptnContext
 	}
-	if params.Type != nil {
-		searchOptions["type"] = params.Type
+	if len(params.Type) == 1 {
+		searchOptions["type"] = params.Type[0]
+	} else if len(params.Type) > 1 {
+		searchOptions["type"] = bson.M{"$in": params.Type}
 	}
 	if params.Source != nil {
 		searchOptions["source"] = params.Source
@@ -113,105 +139,137 @@ func GetEvents(params event.GetEventsParams) (*event.GetEventsOKBody, error) {
 	if params.Service != nil {
 		searchOptions["data.service"] = params.Service
 	}
+	if params.Result != nil {
+		searchOptions["data.result"] = params.Result
+	}
+	if params.DeploymentStrategy != nil {
+		searchOptions["data.deploymentstrategy"] = params.DeploymentStrategy
+	}
+	if params.TestStrategy != nil {
+		searchOptions["data.teststrategy"] = params.TestStrategy
+	}
+	for _, label := range params.Label {
+		keyValue := strings.SplitN(label, "=", 2)
+		searchOptions["data.labels."+keyValue[0]] = keyValue[1]
+	}
 
-	var result event.GetEventsOKBody
-
-	if params.Root != nil {
-		var values []interface{}
-		values, err = collection.Distinct(ctx, "shkeptncontext", searchOptions)
-
-		if err != nil {
-			logger.Error(fmt.Sprintf("error loading distinct shkeptncontext: %v", err))
-		}
-
-		for _, value := range values {
-			var outputEvent interface{}
-
-			sortOptions := options.FindOne().SetSort(bson.D{{"time", 1}})
-			err = collection.FindOne(ctx, bson.D{{"shkeptncontext", value}}, sortOptions).Decode(&outputEvent)
-
-			if err != nil {
-				logger.Error(fmt.Sprintf("failed to decode event %v", err))
-				return nil, err
-			}
-
-			outputEvent, err = flattenRecursively(outputEvent, logger)
-			if err != nil {
-				logger.Error(fmt.Sprintf("failed to flatten %v", err))
-				return nil, err
-			}
+	// the times are stored in the same format and time zone, hence they can be compared as strings
+	timeRange := bson.M{}
+	if params.FromTime != nil {
+		timeRange["$gte"] = formatTime(time.Time(*params.FromTime))
+	}
+	if params.ToTime != nil {
+		timeRange["$lte"] = formatTime(time.Time(*params.ToTime))
+	}
+	if len(timeRange) > 0 {
+		searchOptions["time"] = timeRange
+	}
+	return searchOptions
+}
 
-			data, _ := json.Marshal(outputEvent)
+// formatTime formats a time the way the time of an event is stored
+func formatTime(t time.Time) string {
+	return t.UTC().Format(strfmt.MarshalFormat)
+}
 
-			var keptnEvent models.KeptnContextExtendedCE
-			err = keptnEvent.UnmarshalJSON(data)
-			if err != nil {
-				logger.Error(fmt.Sprintf("failed to unmarshal %v", err))
-				continue
-				// return nil, err
-			}
+// getRootEvents returns the first event of each keptn context containing events that match the filter, starting with
+// the latest one. The first aggregation determines the matching contexts, the second one their first events, hence
+// the number of round trips to MongoDB does not depend on the number of contexts
+func getRootEvents(ctx context.Context, collection *mongo.Collection, filter bson.M, logger *keptnutils.Logger) ([]*models.KeptnContextExtendedCE, error) {
+	aggregateOptions := options.Aggregate().SetAllowDiskUse(true)
+	cur, err := collection.Aggregate(ctx, bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{"_id": "$shkeptncontext"}},
+	}, aggregateOptions)
+	if err != nil {
+		err := fmt.Errorf("failed to load distinct shkeptncontext: %v", err)
+		logger.Error(err.Error())
+		return nil, err
+	}
+	defer cur.Close(ctx)
 
-			result.Events = append(result.Events, &keptnEvent)
+	keptnContexts := bson.A{}
+	for cur.Next(ctx) {
+		var group struct {
+			KeptnContext interface{} `bson:"_id"`
 		}
-	} else {
-		var newNextPageKey int64
-		var nextPageKey int64 = 0
-		if params.NextPageKey != nil {
-			tmpNextPageKey, _ := strconv.Atoi(*params.NextPageKey)
-			nextPageKey = int64(tmpNextPageKey)
-			newNextPageKey = nextPageKey + *params.PageSize
-		} else {
-			newNextPageKey = *params.PageSize
+		if err := cur.Decode(&group); err != nil {
+			err := fmt.Errorf("failed to decode shkeptncontext: %v", err)
+			logger.Error(err.Error())
+			return nil, err
 		}
+		keptnContexts = append(keptnContexts, group.KeptnContext)
+	}
+	if err := cur.Err(); err != nil {
+		err := fmt.Errorf("failed to load distinct shkeptncontext: %v", err)
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if len(keptnContexts) == 0 {
+		return nil, nil
+	}
 
-		pageSize := *params.PageSize
-		sortOptions := options.Find().SetSort(bson.D{{"time", -1}}).SetSkip(nextPageKey).SetLimit(pageSize)
+	rootCur, err := collection.Aggregate(ctx, bson.A{
+		bson.M{"$match": bson.M{"shkeptncontext": bson.M{"$in": keptnContexts}}},
+		bson.M{"$sort": bson.D{{"shkeptncontext", 1}, {"time", 1}}},
+		bson.M{"$group": bson.M{"_id": "$shkeptncontext", "event": bson.M{"$first": "$$ROOT"}}},
+		bson.M{"$replaceRoot": bson.M{"newRoot": "$event"}},
+		bson.M{"$sort": bson.D{{"time", -1}}},
+	}, aggregateOptions)
+	if err != nil {
+		err := fmt.Errorf("failed to find root events: %v", err)
+		logger.Error(err.Error())
+		return nil, err
+	}
+	return decodeEvents(ctx, rootCur, logger)
+}
 
-		totalCount, err := collection.CountDocuments(ctx, searchOptions)
-		if err != nil {
-			logger.Error(fmt.Sprintf("error counting elements in events collection: %v", err))
-		}
+// decodeEvents reads all events of the cursor and closes it
+func decodeEvents(ctx context.Context, cur *mongo.Cursor, logger *keptnutils.Logger) ([]*models.KeptnContextExtendedCE, error) {
+	defer cur.Close(ctx)
 
-		cur, err := collection.Find(ctx, searchOptions, sortOptions)
+	var events []*models.KeptnContextExtendedCE
+	for cur.Next(ctx) {
+		keptnEvent, err := decodeEvent(cur, logger)
 		if err != nil {
-			logger.Error(fmt.Sprintf("error finding elements in events collection: %v", err))
+			return nil, err
 		}
-
-		for cur.Next(ctx) {
-			var outputEvent interface{}
-			err := cur.Decode(&outputEvent)
-			if err != nil {
-				logger.Error(fmt.Sprintf("failed to decode event %v", err))
-				return nil, err
-			}
-			outputEvent, err = flattenRecursively(outputEvent, logger)
-			if err != nil {
-				logger.Error(fmt.Sprintf("failed to flatten %v", err))
-				return nil, err
-			}
-
-			data, _ := json.Marshal(outputEvent)
-
-			var keptnEvent models.KeptnContextExtendedCE
-			err = keptnEvent.UnmarshalJSON(data)
-			if err != nil {
-				logger.Error(fmt.Sprintf("failed to unmarshal %v", err))
-				continue
-				// return nil, err
-			}
-
-			result.Events = append(result.Events, &keptnEvent)
+		if keptnEvent != nil {
+			events = append(events, keptnEvent)
 		}
+	}
+	if err := cur.Err(); err != nil {
+		err := fmt.Errorf("failed to iterate over events: %v", err)
+		logger.Error(err.Error())
+		return nil, err
+	}
+	return events, nil
+}
 
-		result.PageSize = pageSize
-		result.TotalCount = totalCount
-
-		if newNextPageKey < totalCount {
-			result.NextPageKey = strconv.FormatInt(newNextPageKey, 10)
-		}
+// decodeEvent decodes the current document of the cursor. Documents that are not valid keptn events are skipped by
+// returning nil
+func decodeEvent(cur *mongo.Cursor, logger *keptnutils.Logger) (*models.KeptnContextExtendedCE, error) {
+	var outputEvent interface{}
+	err := cur.Decode(&outputEvent)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to decode event %v", err))
+		return nil, err
+	}
+	outputEvent, err = flattenRecursively(outputEvent, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to flatten %v", err))
+		return nil, err
 	}
 
-	return &result, nil
+	data, _ := json.Marshal(outputEvent)
+
+	var keptnEvent models.KeptnContextExtendedCE
+	err = keptnEvent.UnmarshalJSON(data)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to unmarshal %v", err))
+		return nil, nil
+	}
+	return &keptnEvent, nil
 }
 
 func flattenRecursively(i interface{}, logger *keptnutils.Logger) (interface{}, error) {
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
+)
+
+// The benchmarks run against the MongoDB given by MONGODB_BENCHMARK_CONNECTION, e.g., mongodb://localhost:27017, and
+// are skipped otherwise. The database keptn_benchmark is replaced by MONGODB_BENCHMARK_EVENTS (default: 100000)
+// synthetic events, each keptn context consisting of eventsPerContext events
+const benchmarkDatabase = "keptn_benchmark"
+const eventsPerContext = 10
+
+var benchmarkProjects = []string{"sockshop", "easytravel", "podtato", "hipstershop", "bookinfo"}
+var benchmarkStages = []string{"dev", "staging", "production"}
+var benchmarkServices = []string{"carts", "orders", "payment", "shipping", "user", "catalogue", "frontend", "queue", "db", "mail"}
+var benchmarkTypes = []string{
+	"sh.keptn.event.configuration.change",
+	"sh.keptn.events.deployment-finished",
+	"sh.keptn.events.tests-finished",
+	"sh.keptn.events.evaluation-done",
+	"sh.keptn.event.problem.open",
+}
+
+var seedOnce sync.Once
+var seedErr error
+var benchmarkContexts int
+
+// setupBenchmark connects to the benchmark database and seeds it once per test binary
+func setupBenchmark(b *testing.B) {
+	connection := os.Getenv("MONGODB_BENCHMARK_CONNECTION")
+	if connection == "" {
+		b.Skip("MONGODB_BENCHMARK_CONNECTION is not set")
+	}
+	seedOnce.Do(func() {
+		events := 100000
+		if value := os.Getenv("MONGODB_BENCHMARK_EVENTS"); value != "" {
+			if events, seedErr = strconv.Atoi(value); seedErr != nil {
+				return
+			}
+		}
+		mongoDBConnection = connection
+		mongoDBName = benchmarkDatabase
+		if seedErr = ConnectMongoDB(); seedErr != nil {
+			return
+		}
+		seedErr = seedEvents(events)
+	})
+	if seedErr != nil {
+		b.Fatal(seedErr)
+	}
+	b.ResetTimer()
+}
+
+// seedEvents replaces the events of the benchmark database by synthetic events and creates the indexes
+func seedEvents(events int) error {
+	ctx := context.Background()
+	collection, err := getCollection(eventsCollectionName)
+	if err != nil {
+		return err
+	}
+	if err := collection.Database().Drop(ctx); err != nil {
+		return err
+	}
+
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	benchmarkContexts = (events + eventsPerContext - 1) / eventsPerContext
+	batch := make([]interface{}, 0, 1000)
+	for i := 0; i < events; i++ {
+		keptnContext := i / eventsPerContext
+		batch = append(batch, map[string]interface{}{
+			"contenttype":    "application/json",
+			"id":             fmt.Sprintf("event-%d", i),
+			"shkeptncontext": fmt.Sprintf("context-%d", keptnContext),
+			"source":         "benchmark",
+			"specversion":    "0.2",
+			"time":           start.Add(time.Duration(i) * time.Second).Format("2006-01-02T15:04:05.000Z"),
+			"type":           benchmarkTypes[i%eventsPerContext%len(benchmarkTypes)],
+			"data": map[string]interface{}{
+				"project": benchmarkProjects[keptnContext%len(benchmarkProjects)],
+				"stage":   benchmarkStages[keptnContext%len(benchmarkStages)],
+				"service": benchmarkServices[keptnContext%len(benchmarkServices)],
+			},
+		})
+		if len(batch) == cap(batch) || i == events-1 {
+			if _, err := collection.InsertMany(ctx, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	return EnsureIndexes(ctx)
+}
+
+func BenchmarkGetEventsByKeptnContext(b *testing.B) {
+	setupBenchmark(b)
+	pageSize := int64(20)
+	for i := 0; i < b.N; i++ {
+		keptnContext := fmt.Sprintf("context-%d", i%benchmarkContexts)
+		result, err := GetEvents(context.Background(), event.GetEventsParams{KeptnContext: &keptnContext, PageSize: &pageSize})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(result.Events) == 0 {
+			b.Fatalf("expected events of %s", keptnContext)
+		}
+	}
+}
+
+func BenchmarkGetEventsByService(b *testing.B) {
+	setupBenchmark(b)
+	pageSize := int64(20)
+	for i := 0; i < b.N; i++ {
+		project := benchmarkProjects[i%len(benchmarkProjects)]
+		stage := benchmarkStages[i%len(benchmarkStages)]
+		service := benchmarkServices[i%len(benchmarkServices)]
+		_, err := GetEvents(context.Background(), event.GetEventsParams{
+			Project: &project, Stage: &stage, Service: &service, PageSize: &pageSize,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetEventsPages reads 100 consecutive pages, whose cost does not grow with the number of preceding events
+func BenchmarkGetEventsPages(b *testing.B) {
+	setupBenchmark(b)
+	pageSize := int64(20)
+	for i := 0; i < b.N; i++ {
+		var nextPageKey *string
+		for page := 0; page < 100; page++ {
+			result, err := GetEvents(context.Background(), event.GetEventsParams{PageSize: &pageSize, NextPageKey: nextPageKey})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if result.NextPageKey == "" {
+				break
+			}
+			nextPageKey = &result.NextPageKey
+		}
+	}
+}
+
+func BenchmarkGetRootEventsOfType(b *testing.B) {
+	setupBenchmark(b)
+	pageSize := int64(20)
+	root := "true"
+	eventType := benchmarkTypes[len(benchmarkTypes)-1]
+	for i := 0; i < b.N; i++ {
+		project := benchmarkProjects[i%len(benchmarkProjects)]
+		result, err := GetEvents(context.Background(), event.GetEventsParams{
+			Project: &project, Type: []string{eventType}, Root: &root, PageSize: &pageSize,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		// the root event of each context is its first event, a configuration change
+		for _, rootEvent := range result.Events {
+			if string(rootEvent.Type) != benchmarkTypes[0] {
+				b.Fatalf("expected the root event to be of type %s, got %s", benchmarkTypes[0], rootEvent.Type)
+			}
+		}
+	}
+}
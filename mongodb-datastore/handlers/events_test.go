@@ -3,8 +3,11 @@ package handlers
 import (
 	"go.mongodb.org/mongo-driver/bson"
 	"testing"
+	"time"
 
+	strfmt "github.com/go-openapi/strfmt"
 	keptnutils "github.com/keptn/go-utils/pkg/utils"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 	"github.com/magiconair/properties/assert"
 )
 
@@ -48,3 +51,29 @@ func TestFlattenRecursivelyNestedDocumentsWithArray(t *testing.T) {
 	grandchildMap := childMap[0].(map[string]interface{})
 	assert.Equal(t, grandchildMap["apple"], "red", "flatting failed")
 }
+
+// TestGetSearchOptions checks whether the filter contains all given parameters
+func TestGetSearchOptions(t *testing.T) {
+	result := "pass"
+	strategy := "blue_green_service"
+	from := strfmt.DateTime(time.Date(2019, 11, 20, 9, 0, 0, 0, time.FixedZone("CET", 3600)))
+	searchOptions := getSearchOptions(event.GetEventsParams{
+		Type:               []string{"sh.keptn.events.evaluation-done", "sh.keptn.events.tests-finished"},
+		Result:             &result,
+		DeploymentStrategy: &strategy,
+		Label:              []string{"buildId=42", "owner=team=a"},
+		FromTime:           &from,
+	})
+
+	assert.Equal(t, searchOptions["type"], bson.M{"$in": []string{"sh.keptn.events.evaluation-done", "sh.keptn.events.tests-finished"}})
+	assert.Equal(t, searchOptions["data.result"], &result)
+	assert.Equal(t, searchOptions["data.deploymentstrategy"], &strategy)
+	assert.Equal(t, searchOptions["data.labels.buildId"], "42")
+	assert.Equal(t, searchOptions["data.labels.owner"], "team=a")
+	// times are compared in UTC
+	assert.Equal(t, searchOptions["time"], bson.M{"$gte": "2019-11-20T08:00:00.000Z"})
+	assert.Equal(t, len(searchOptions), 6)
+
+	searchOptions = getSearchOptions(event.GetEventsParams{Type: []string{"sh.keptn.events.evaluation-done"}})
+	assert.Equal(t, searchOptions, bson.M{"type": "sh.keptn.events.evaluation-done"})
+}
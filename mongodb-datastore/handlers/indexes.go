@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexCreationTimeout limits the time creating the indexes of a collection may take. Creating an index on a large
+// existing collection takes considerably longer than a request
+const indexCreationTimeout = 5 * time.Minute
+
+// eventIndexes support the queries of GetEvents: the latest events, the events of a keptn context (also used to find
+// the root events), and the latest events of a type, source or project, stage and service. Pages are sorted by time
+// and _id, hence all indexes end with both
+var eventIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{"time", -1}, {"_id", -1}}, Options: options.Index().SetName("time_id")},
+	{Keys: bson.D{{"shkeptncontext", 1}, {"time", 1}, {"_id", 1}}, Options: options.Index().SetName("shkeptncontext_time_id")},
+	{Keys: bson.D{{"type", 1}, {"time", -1}, {"_id", -1}}, Options: options.Index().SetName("type_time_id")},
+	{Keys: bson.D{{"source", 1}, {"time", -1}, {"_id", -1}}, Options: options.Index().SetName("source_time_id")},
+	{
+		Keys:    bson.D{{"data.project", 1}, {"data.stage", 1}, {"data.service", 1}, {"time", -1}, {"_id", -1}},
+		Options: options.Index().SetName("project_stage_service_time_id"),
+	},
+}
+
+// logIndexes support the queries of GetLogs
+var logIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{"timestamp", -1}, {"_id", -1}}, Options: options.Index().SetName("timestamp_id")},
+	{Keys: bson.D{{"eventid", 1}}, Options: options.Index().SetName("eventid")},
+}
+
+// EnsureIndexes creates the indexes of the events and logs collections. Existing indexes with the same definition are
+// left untouched, hence it can be called on every startup
+func EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, indexCreationTimeout)
+	defer cancel()
+
+	for collectionName, indexes := range map[string][]mongo.IndexModel{
+		eventsCollectionName: eventIndexes,
+		logsCollectionName:   logIndexes,
+	} {
+		collection, err := getCollection(collectionName)
+		if err != nil {
+			return err
+		}
+		if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+			return fmt.Errorf("failed to create indexes of collection %s: %v", collectionName, err)
+		}
+	}
+	return nil
+}
@@ -3,9 +3,10 @@ package handlers
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
+	strfmt "github.com/go-openapi/strfmt"
+
 	keptnutils "github.com/keptn/go-utils/pkg/utils"
 
 	"github.com/keptn/keptn/mongodb-datastore/models"
@@ -14,35 +15,26 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // SaveLog stores logs in datastore
-func SaveLog(logEntries []*models.LogEntry) (err error) {
+func SaveLog(ctx context.Context, logEntries []*models.LogEntry) (err error) {
 	logger := keptnutils.NewLogger("", "", serviceName)
 	logger.Debug("save log to data store")
 
-	client, err := mongo.NewClient(options.Client().ApplyURI(mongoDBConnection))
-	if err != nil {
-		err := fmt.Errorf("failed to create mongo client: %v", err)
-		logger.Error(err.Error())
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	err = client.Connect(ctx)
+	collection, err := getCollection(logsCollectionName)
 	if err != nil {
-		err := fmt.Errorf("failed to connect: %v", err)
 		logger.Error(err.Error())
 		return err
 	}
 
-	collection := client.Database(mongoDBName).Collection(logsCollectionName)
-
 	for _, l := range logEntries {
 		if l.KeptnService != "" {
+			// timestamps are stored in UTC, hence they can be sorted and compared as strings
+			l.Timestamp = strfmt.DateTime(time.Time(l.Timestamp).UTC())
 			res, err := collection.InsertOne(ctx, l)
 			if err != nil {
 				err := fmt.Errorf("failed to insert log: %v", err)
@@ -59,78 +51,49 @@ func SaveLog(logEntries []*models.LogEntry) (err error) {
 }
 
 // GetLogs returns logs
-func GetLogs(params logs.GetLogsParams) (result *logs.GetLogsOKBody, err error) {
+func GetLogs(ctx context.Context, params logs.GetLogsParams) (result *logs.GetLogsOKBody, err error) {
 	logger := keptnutils.NewLogger("", "", serviceName)
 	logger.Debug("getting logs from data store")
 
-	client, err := mongo.NewClient(options.Client().ApplyURI(mongoDBConnection))
-	if err != nil {
-		err := fmt.Errorf("failed to create mongo client: %v", err)
-		logger.Error(err.Error())
-		return nil, err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	err = client.Connect(ctx)
+	collection, err := getCollection(logsCollectionName)
 	if err != nil {
-		err := fmt.Errorf("failed to connect: %v", err)
 		logger.Error(err.Error())
 		return nil, err
 	}
 
-	collection := client.Database(mongoDBName).Collection(logsCollectionName)
-
 	searchOptions := bson.M{}
 	if params.EventID != nil {
 		searchOptions["eventid"] = primitive.Regex{Pattern: *params.EventID, Options: ""}
 	}
 
-	var newNextPageKey int64
-	var nextPageKey int64 = 0
-	if params.NextPageKey != nil {
-		tmpNextPageKey, _ := strconv.Atoi(*params.NextPageKey)
-		nextPageKey = int64(tmpNextPageKey)
-		newNextPageKey = nextPageKey + *params.PageSize
-	} else {
-		newNextPageKey = *params.PageSize
+	var myResult logs.GetLogsOKBody
+	myResult.PageSize = *params.PageSize
+	if params.IncludeTotalCount != nil && *params.IncludeTotalCount {
+		myResult.TotalCount, err = collection.CountDocuments(ctx, searchOptions)
+		if err != nil {
+			err := fmt.Errorf("failed to count elements in logs collection: %v", err)
+			logger.Error(err.Error())
+			return nil, err
+		}
 	}
 
-	pageSize := *params.PageSize
-
-	sortOptions := options.Find().SetSort(bson.D{{"timestamp", -1}}).SetSkip(nextPageKey).SetLimit(pageSize)
-
-	totalCount, err := collection.CountDocuments(ctx, searchOptions)
-	if err != nil {
-		err := fmt.Errorf("failed to count elements in logs collection: %v", err)
-		logger.Error(err.Error())
+	myResult.NextPageKey, err = findPage(ctx, collection, searchOptions, "timestamp", *params.PageSize, params.NextPageKey, func(cur *mongo.Cursor) error {
+		var result models.LogEntry
+		if err := cur.Decode(&result); err != nil {
+			return fmt.Errorf("failed to decode log: %v", err)
+		}
+		myResult.Logs = append(myResult.Logs, &result)
+		return nil
+	})
+	if err == ErrInvalidPageKey {
 		return nil, err
-	}
-
-	cur, err := collection.Find(ctx, searchOptions, sortOptions)
-	if err != nil {
+	} else if err != nil {
 		err := fmt.Errorf("failed to find elements in logs collection: %v", err)
 		logger.Error(err.Error())
 		return nil, err
 	}
-
-	var resultLogs []*models.LogEntry
-	for cur.Next(ctx) {
-		var result models.LogEntry
-		err := cur.Decode(&result)
-		if err != nil {
-			return nil, err
-		}
-		resultLogs = append(resultLogs, &result)
-	}
-
-	var myResult logs.GetLogsOKBody
-	myResult.Logs = resultLogs
-	myResult.PageSize = pageSize
-	myResult.TotalCount = totalCount
-	if newNextPageKey < totalCount {
-		myResult.NextPageKey = strconv.FormatInt(newNextPageKey, 10)
-	}
 	return &myResult, nil
 }
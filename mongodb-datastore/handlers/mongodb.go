@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// errNotConnected is returned if a request is handled before ConnectMongoDB succeeded
+var errNotConnected = errors.New("not connected to MongoDB")
+
+var client *mongo.Client
+var clientMutex sync.RWMutex
+
+// ConnectMongoDB creates the client that is shared by all requests. The client maintains a pool of connections, whose
+// size can be set by the option maxPoolSize of the connection string (default: 100). MongoDB does not need to be
+// reachable yet, the connections are established when they are used
+func ConnectMongoDB() error {
+	c, err := mongo.NewClient(options.Client().ApplyURI(mongoDBConnection))
+	if err != nil {
+		return fmt.Errorf("failed to create mongo client: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	if err := c.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %v", err)
+	}
+
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+	client = c
+	return nil
+}
+
+// DisconnectMongoDB closes all connections of the client
+func DisconnectMongoDB() error {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+	if client == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	err := client.Disconnect(ctx)
+	client = nil
+	return err
+}
+
+// PingMongoDB checks whether the primary of MongoDB can be reached
+func PingMongoDB(ctx context.Context) error {
+	clientMutex.RLock()
+	c := client
+	clientMutex.RUnlock()
+	if c == nil {
+		return errNotConnected
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	return c.Ping(ctx, readpref.Primary())
+}
+
+// getCollection returns a collection of the keptn database using the shared client
+func getCollection(name string) (*mongo.Collection, error) {
+	clientMutex.RLock()
+	defer clientMutex.RUnlock()
+	if client == nil {
+		return nil, errNotConnected
+	}
+	return client.Database(mongoDBName).Collection(name), nil
+}
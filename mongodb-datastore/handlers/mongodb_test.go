@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keptn/keptn/mongodb-datastore/models"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/logs"
+)
+
+// TestRequestsWithoutClient checks whether requests fail with an error before the client has been connected
+func TestRequestsWithoutClient(t *testing.T) {
+	if err := SaveEvent(context.Background(), &models.KeptnContextExtendedCE{}); err != errNotConnected {
+		t.Errorf("expected SaveEvent to fail without client, got %v", err)
+	}
+	if err := SaveLog(context.Background(), []*models.LogEntry{}); err != errNotConnected {
+		t.Errorf("expected SaveLog to fail without client, got %v", err)
+	}
+	if err := PingMongoDB(context.Background()); err != errNotConnected {
+		t.Errorf("expected PingMongoDB to fail without client, got %v", err)
+	}
+}
+
+// TestRequestsWithUnreachableMongoDB checks whether errors of MongoDB are returned instead of being ignored
+func TestRequestsWithUnreachableMongoDB(t *testing.T) {
+	connection := mongoDBConnection
+	mongoDBConnection = "mongodb://127.0.0.1:1/?serverSelectionTimeoutMS=100&connectTimeoutMS=100"
+	defer func() {
+		mongoDBConnection = connection
+	}()
+
+	// the client connects lazily, hence it can be created while MongoDB is not reachable
+	if err := ConnectMongoDB(); err != nil {
+		t.Fatalf("expected the client to be created, got %v", err)
+	}
+	defer DisconnectMongoDB()
+
+	if err := PingMongoDB(context.Background()); err == nil {
+		t.Errorf("expected PingMongoDB to fail")
+	}
+
+	pageSize := int64(10)
+	if _, err := GetEvents(context.Background(), event.GetEventsParams{PageSize: &pageSize}); err == nil {
+		t.Errorf("expected GetEvents to fail")
+	}
+	if _, err := GetLogs(context.Background(), logs.GetLogsParams{PageSize: &pageSize}); err == nil {
+		t.Errorf("expected GetLogs to fail")
+	}
+
+	invalidPageKey := "20"
+	if _, err := GetEvents(context.Background(), event.GetEventsParams{PageSize: &pageSize, NextPageKey: &invalidPageKey}); err != ErrInvalidPageKey {
+		t.Errorf("expected GetEvents to reject the page key, got %v", err)
+	}
+	if _, err := GetLogs(context.Background(), logs.GetLogsParams{PageSize: &pageSize, NextPageKey: &invalidPageKey}); err != ErrInvalidPageKey {
+		t.Errorf("expected GetLogs to reject the page key, got %v", err)
+	}
+
+	// requests are bound to the context of the HTTP request
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := SaveEvent(ctx, &models.KeptnContextExtendedCE{}); err == nil {
+		t.Errorf("expected SaveEvent to fail for a canceled request")
+	}
+}
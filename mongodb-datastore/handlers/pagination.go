@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvalidPageKey is returned if a page key has not been returned by a previous request
+var ErrInvalidPageKey = errors.New("invalid page key")
+
+// findPage finds the page of documents following the page key, sorted descending by timeField and _id, and passes each
+// document to decode. The page key references the last document of the previous page, hence the page neither depends
+// on the number of preceding documents nor shifts if documents are added. The returned key of the next page is empty
+// for the last page
+func findPage(ctx context.Context, collection *mongo.Collection, filter bson.M, timeField string, pageSize int64,
+	pageKey *string, decode func(cur *mongo.Cursor) error) (string, error) {
+
+	if pageKey != nil {
+		keyTime, keyID, err := decodePageKey(*pageKey)
+		if err != nil {
+			return "", err
+		}
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{timeField: bson.M{"$lt": keyTime}},
+			bson.M{timeField: keyTime, "_id": bson.M{"$lt": keyID}},
+		}}}}
+	}
+
+	// one more document than requested is read to find out whether a next page exists
+	findOptions := options.Find().SetSort(bson.D{{timeField, -1}, {"_id", -1}}).SetLimit(pageSize + 1)
+	cur, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return "", err
+	}
+	defer cur.Close(ctx)
+
+	var last bson.Raw
+	for n := int64(0); cur.Next(ctx); n++ {
+		if n == pageSize {
+			return encodePageKey(last, timeField)
+		}
+		if err := decode(cur); err != nil {
+			return "", err
+		}
+		// the cursor reuses its buffer for the next batch
+		last = append(last[:0], cur.Current...)
+	}
+	return "", cur.Err()
+}
+
+// encodePageKey returns the opaque key referencing a document, which contains the time and the _id of the document
+func encodePageKey(doc bson.Raw, timeField string) (string, error) {
+	keyTime, err := doc.LookupErr(timeField)
+	if err != nil {
+		// documents without time are sorted last
+		keyTime = bson.RawValue{Type: bsontype.Null}
+	}
+	data, err := bson.Marshal(bson.D{{"time", keyTime}, {"_id", doc.Lookup("_id")}})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageKey returns the time and the _id of the document referenced by a page key
+func decodePageKey(pageKey string) (bson.RawValue, bson.RawValue, error) {
+	data, err := base64.RawURLEncoding.DecodeString(pageKey)
+	if err != nil {
+		return bson.RawValue{}, bson.RawValue{}, ErrInvalidPageKey
+	}
+	doc := bson.Raw(data)
+	if doc.Validate() != nil {
+		return bson.RawValue{}, bson.RawValue{}, ErrInvalidPageKey
+	}
+	keyTime, err := doc.LookupErr("time")
+	if err != nil {
+		return bson.RawValue{}, bson.RawValue{}, ErrInvalidPageKey
+	}
+	keyID, err := doc.LookupErr("_id")
+	if err != nil {
+		return bson.RawValue{}, bson.RawValue{}, ErrInvalidPageKey
+	}
+	return keyTime, keyID, nil
+}
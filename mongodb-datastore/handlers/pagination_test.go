@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestPageKey checks whether a page key references the time and the _id of a document
+func TestPageKey(t *testing.T) {
+	id := primitive.NewObjectID()
+	doc, _ := bson.Marshal(bson.D{{"_id", id}, {"time", "2019-11-20T08:00:00.000Z"}, {"type", "sh.keptn.events.tests-finished"}})
+
+	pageKey, err := encodePageKey(doc, "time")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyTime, keyID, err := decodePageKey(pageKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyTime.StringValue() != "2019-11-20T08:00:00.000Z" || keyID.ObjectID() != id {
+		t.Errorf("expected the time and _id of the document, got %v %v", keyTime, keyID)
+	}
+
+	// the timestamp of logs is stored as document
+	doc, _ = bson.Marshal(bson.D{{"_id", id}, {"timestamp", bson.D{{"data", "2019-11-20T08:00:00.000Z"}}}})
+	pageKey, _ = encodePageKey(doc, "timestamp")
+	if keyTime, _, err := decodePageKey(pageKey); err != nil || keyTime.Document().Lookup("data").StringValue() != "2019-11-20T08:00:00.000Z" {
+		t.Errorf("expected the timestamp of the document, got %v %v", keyTime, err)
+	}
+}
+
+// TestInvalidPageKey checks whether page keys that have not been returned by a request are rejected
+func TestInvalidPageKey(t *testing.T) {
+	incomplete, _ := bson.Marshal(bson.D{{"time", "2019-11-20T08:00:00.000Z"}})
+	for _, pageKey := range []string{"20", "not base64!", base64.RawURLEncoding.EncodeToString(incomplete), base64.RawURLEncoding.EncodeToString([]byte{42, 0, 0, 0, 2})} {
+		if _, _, err := decodePageKey(pageKey); err != ErrInvalidPageKey {
+			t.Errorf("expected page key %q to be invalid, got %v", pageKey, err)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	strfmt "github.com/go-openapi/strfmt"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
+
+	"github.com/keptn/keptn/mongodb-datastore/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrRetentionRunning is returned if a retention run is started while another one is in progress
+var ErrRetentionRunning = errors.New("a retention run is already in progress")
+
+const defaultRetentionInterval = time.Hour
+
+// retentionRunTimeout limits the time a retention run may take
+const retentionRunTimeout = time.Hour
+
+// maxRetentionRuns is the number of retention runs whose results are kept
+const maxRetentionRuns = 10
+
+// archiveBatchSize is the number of items that are archived and deleted at once
+const archiveBatchSize = 1000
+
+const (
+	retentionRunning   = "running"
+	retentionSucceeded = "succeeded"
+	retentionFailed    = "failed"
+)
+
+// retentionPolicy deletes the items of a collection that are older than the retention. A policy without project
+// applies to all projects without own policy
+type retentionPolicy struct {
+	collection string
+	project    string
+	retention  time.Duration
+	// config is the retention as it has been configured
+	config string
+}
+
+// String returns the policy as it is configured, e.g., events/sockshop
+func (p retentionPolicy) String() string {
+	if p.project == "" {
+		return p.collection
+	}
+	return p.collection + "/" + p.project
+}
+
+var retentionPolicies []retentionPolicy
+var retentionInterval = defaultRetentionInterval
+
+var retentionMutex sync.Mutex
+var retentionRuns []*models.RetentionRun
+var retentionInProgress bool
+
+// ConfigureRetention reads the retention policies and the interval of the retention runs from the environment
+func ConfigureRetention() error {
+	policies, err := parseRetentionPolicies(retentionConfig)
+	if err != nil {
+		return fmt.Errorf("invalid RETENTION_POLICIES: %v", err)
+	}
+	interval := defaultRetentionInterval
+	if retentionIntervalConfig != "" {
+		interval, err = time.ParseDuration(retentionIntervalConfig)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid RETENTION_INTERVAL: %s", retentionIntervalConfig)
+		}
+	}
+	retentionPolicies = policies
+	retentionInterval = interval
+	return nil
+}
+
+// parseRetentionPolicies parses policies of the form events=90d,events/sockshop=30d,logs=14d
+func parseRetentionPolicies(config string) ([]retentionPolicy, error) {
+	var policies []retentionPolicy
+	configured := map[string]bool{}
+	for _, entry := range strings.Split(config, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		keyValue := strings.SplitN(entry, "=", 2)
+		if len(keyValue) != 2 {
+			return nil, fmt.Errorf("policy %s is not of the form <collection>[/<project>]=<retention>", entry)
+		}
+		if configured[keyValue[0]] {
+			return nil, fmt.Errorf("policy %s is configured twice", keyValue[0])
+		}
+		configured[keyValue[0]] = true
+
+		collectionProject := strings.SplitN(keyValue[0], "/", 2)
+		policy := retentionPolicy{collection: collectionProject[0], config: keyValue[1]}
+		if len(collectionProject) == 2 {
+			policy.project = collectionProject[1]
+		}
+		switch {
+		case policy.collection != eventsCollectionName && policy.collection != logsCollectionName:
+			return nil, fmt.Errorf("unknown collection %s", policy.collection)
+		case policy.collection == logsCollectionName && policy.project != "":
+			return nil, errors.New("logs do not contain their project, hence they cannot be retained per project")
+		case len(collectionProject) == 2 && policy.project == "":
+			return nil, fmt.Errorf("policy %s does not contain a project", entry)
+		}
+
+		retention, err := parseRetention(keyValue[1])
+		if err != nil {
+			return nil, err
+		}
+		policy.retention = retention
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].collection != policies[j].collection {
+			return policies[i].collection < policies[j].collection
+		}
+		return policies[i].project < policies[j].project
+	})
+	return policies, nil
+}
+
+// parseRetention parses a duration, which may also be given in days (e.g., 90d). 0 keeps the items forever
+func parseRetention(retention string) (time.Duration, error) {
+	if strings.HasSuffix(retention, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(retention, "d"))
+		if err != nil || days < 0 {
+			return 0, fmt.Errorf("invalid retention %s", retention)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(retention)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid retention %s", retention)
+	}
+	return duration, nil
+}
+
+// StartRetention starts a retention run in every retention interval, if any policy has been configured
+func StartRetention() {
+	if len(retentionPolicies) == 0 {
+		return
+	}
+	go func() {
+		logger := keptnutils.NewLogger("", "", serviceName)
+		for range time.Tick(retentionInterval) {
+			if _, err := StartRetentionRun(); err != nil {
+				logger.Info(err.Error())
+			}
+		}
+	}()
+}
+
+// StartRetentionRun starts a retention run in the background and returns its status
+func StartRetentionRun() (*models.RetentionRun, error) {
+	retentionMutex.Lock()
+	defer retentionMutex.Unlock()
+	if retentionInProgress {
+		return nil, ErrRetentionRunning
+	}
+	retentionInProgress = true
+
+	now := time.Now().UTC()
+	run := &models.RetentionRun{StartTime: strfmt.DateTime(now), Status: retentionRunning}
+	retentionRuns = append([]*models.RetentionRun{run}, retentionRuns...)
+	if len(retentionRuns) > maxRetentionRuns {
+		retentionRuns = retentionRuns[:maxRetentionRuns]
+	}
+	go runRetention(run, now)
+	return copyRetentionRun(run), nil
+}
+
+// GetRetentionStatus returns the retention policies and the latest retention runs
+func GetRetentionStatus() *models.RetentionStatus {
+	status := &models.RetentionStatus{ArchiveDirectory: archiveDirectory, Interval: retentionInterval.String()}
+	for _, policy := range retentionPolicies {
+		status.Policies = append(status.Policies, &models.RetentionPolicy{
+			Collection: policy.collection,
+			Project:    policy.project,
+			Retention:  policy.config,
+		})
+	}
+
+	retentionMutex.Lock()
+	defer retentionMutex.Unlock()
+	for _, run := range retentionRuns {
+		status.Runs = append(status.Runs, copyRetentionRun(run))
+	}
+	return status
+}
+
+// copyRetentionRun copies a run, which is modified by the retention run in progress
+func copyRetentionRun(run *models.RetentionRun) *models.RetentionRun {
+	runCopy := *run
+	runCopy.Results = append([]*models.RetentionResult{}, run.Results...)
+	return &runCopy
+}
+
+// runRetention applies all policies to the items that have expired at the given time, and records the results in run
+func runRetention(run *models.RetentionRun, now time.Time) {
+	logger := keptnutils.NewLogger("", "", serviceName)
+	ctx, cancel := context.WithTimeout(context.Background(), retentionRunTimeout)
+	defer cancel()
+
+	var err error
+	for _, policy := range retentionPolicies {
+		if policy.retention == 0 {
+			continue
+		}
+		var result *models.RetentionResult
+		result, err = applyRetentionPolicy(ctx, policy, now)
+		if err != nil {
+			err = fmt.Errorf("failed to apply retention policy %s: %v", policy, err)
+			logger.Error(err.Error())
+			break
+		}
+		logger.Info(fmt.Sprintf("deleted %d expired items of %s", result.Deleted, policy))
+
+		retentionMutex.Lock()
+		run.Results = append(run.Results, result)
+		retentionMutex.Unlock()
+	}
+
+	retentionMutex.Lock()
+	defer retentionMutex.Unlock()
+	run.EndTime = strfmt.DateTime(time.Now().UTC())
+	run.Status = retentionSucceeded
+	if err != nil {
+		run.Status = retentionFailed
+		run.Message = err.Error()
+	}
+	retentionInProgress = false
+}
+
+// applyRetentionPolicy deletes the items the policy applies to that have expired at the given time. If an archive
+// directory is configured, the items are archived before
+func applyRetentionPolicy(ctx context.Context, policy retentionPolicy, now time.Time) (*models.RetentionResult, error) {
+	collection, err := getCollection(policy.collection)
+	if err != nil {
+		return nil, err
+	}
+	filter := expiredFilter(policy, now)
+	result := &models.RetentionResult{Collection: policy.collection, Project: policy.project}
+
+	if archiveDirectory == "" {
+		res, err := collection.DeleteMany(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		result.Deleted = res.DeletedCount
+		return result, nil
+	}
+
+	prefix := strings.Replace(policy.String(), "/", "-", 1) + "-" + now.Format("20060102T150405Z")
+	result.Archive, result.Deleted, err = archiveItems(ctx, collection, filter, prefix)
+	if err != nil {
+		if result.Archive != "" {
+			err = fmt.Errorf("%v (the %d items deleted before have been archived to %s)", err, result.Deleted, result.Archive)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// expiredFilter returns the filter of the items the policy applies to that have expired at the given time
+func expiredFilter(policy retentionPolicy, now time.Time) bson.M {
+	expiry := formatTime(now.Add(-policy.retention))
+	if policy.collection == logsCollectionName {
+		// the timestamp of a log is stored as document containing the formatted time
+		return bson.M{"timestamp.data": bson.M{"$lt": expiry}}
+	}
+
+	filter := bson.M{"time": bson.M{"$lt": expiry}}
+	if policy.project != "" {
+		filter["data.project"] = policy.project
+		return filter
+	}
+	var projects []string
+	for _, projectPolicy := range retentionPolicies {
+		if projectPolicy.collection == policy.collection && projectPolicy.project != "" {
+			projects = append(projects, projectPolicy.project)
+		}
+	}
+	if len(projects) > 0 {
+		filter["data.project"] = bson.M{"$nin": projects}
+	}
+	return filter
+}
+
+// archiveItems writes the items matching the filter as gzipped NDJSON to a new archive, whose name starts with prefix,
+// and deletes them. It returns the name of the archive and the number of deleted items. Each line contains an item in
+// MongoDB extended JSON, hence the archive can be restored with mongoimport. The items are archived and deleted in
+// batches, and each batch is persisted before its items are deleted, hence the archive contains all deleted items even
+// if the run fails. No file is created if no item matches
+func archiveItems(ctx context.Context, collection *mongo.Collection, filter bson.M, prefix string) (string, int64, error) {
+	cur, err := collection.Find(ctx, filter)
+	if err != nil {
+		return "", 0, err
+	}
+	defer cur.Close(ctx)
+
+	var archive *archiveFile
+	var deleted int64
+	ids := make([]interface{}, 0, archiveBatchSize)
+	for cur.Next(ctx) {
+		if archive == nil {
+			if archive, err = createArchiveFile(prefix); err != nil {
+				return "", 0, err
+			}
+			defer archive.file.Close()
+		}
+		line, err := bson.MarshalExtJSON(cur.Current, false, false)
+		if err != nil {
+			return archive.name, deleted, err
+		}
+		if _, err := archive.gz.Write(append(line, '\n')); err != nil {
+			return archive.name, deleted, err
+		}
+		var item struct {
+			ID interface{} `bson:"_id"`
+		}
+		if err := cur.Decode(&item); err != nil {
+			return archive.name, deleted, err
+		}
+		ids = append(ids, item.ID)
+
+		if len(ids) == archiveBatchSize {
+			n, err := deleteArchivedItems(ctx, collection, archive, ids)
+			deleted += n
+			if err != nil {
+				return archive.name, deleted, err
+			}
+			ids = ids[:0]
+		}
+	}
+	if err := cur.Err(); err != nil {
+		if archive == nil {
+			return "", 0, err
+		}
+		return archive.name, deleted, err
+	}
+	if archive == nil {
+		return "", 0, nil
+	}
+
+	if len(ids) > 0 {
+		n, err := deleteArchivedItems(ctx, collection, archive, ids)
+		deleted += n
+		if err != nil {
+			return archive.name, deleted, err
+		}
+	}
+	return archive.name, deleted, archive.file.Close()
+}
+
+// deleteArchivedItems persists the items that have been written to the archive and deletes them. Only archived items
+// are deleted, even if further items have expired in the meantime
+func deleteArchivedItems(ctx context.Context, collection *mongo.Collection, archive *archiveFile, ids []interface{}) (int64, error) {
+	if err := archive.flush(); err != nil {
+		return 0, err
+	}
+	res, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// archiveFile is an archive in the archive directory, which is written as a sequence of gzip members. gunzip and
+// mongoimport read such a file like a single gzipped file
+type archiveFile struct {
+	name string
+	file *os.File
+	gz   *gzip.Writer
+}
+
+// createArchiveFile creates a new archive whose name starts with prefix. Existing archives are never overwritten; if
+// an archive of the same name exists, e.g., of a run started within the same second, a counter is appended to the name
+func createArchiveFile(prefix string) (*archiveFile, error) {
+	if err := os.MkdirAll(archiveDirectory, 0755); err != nil {
+		return nil, err
+	}
+	for i := 1; ; i++ {
+		name := prefix + ".ndjson.gz"
+		if i > 1 {
+			name = fmt.Sprintf("%s-%d.ndjson.gz", prefix, i)
+		}
+		file, err := os.OpenFile(filepath.Join(archiveDirectory, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &archiveFile{name: name, file: file, gz: gzip.NewWriter(file)}, nil
+	}
+}
+
+// flush completes the current gzip member and syncs the file, hence the items written so far are persisted. The items
+// written afterwards start a new member
+func (a *archiveFile) flush() error {
+	if err := a.gz.Close(); err != nil {
+		return err
+	}
+	if err := a.file.Sync(); err != nil {
+		return err
+	}
+	a.gz.Reset(a.file)
+	return nil
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestParseRetentionPolicies checks whether policies per collection and project are parsed
+func TestParseRetentionPolicies(t *testing.T) {
+	policies, err := parseRetentionPolicies("logs=12h, events/sockshop=30d,events=90d,events/audit=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []retentionPolicy{
+		{collection: "events", retention: 90 * 24 * time.Hour, config: "90d"},
+		{collection: "events", project: "audit", retention: 0, config: "0"},
+		{collection: "events", project: "sockshop", retention: 30 * 24 * time.Hour, config: "30d"},
+		{collection: "logs", retention: 12 * time.Hour, config: "12h"},
+	}
+	if !reflect.DeepEqual(policies, expected) {
+		t.Errorf("unexpected policies %+v", policies)
+	}
+
+	if policies, err := parseRetentionPolicies(""); err != nil || len(policies) != 0 {
+		t.Errorf("expected no policies, got %+v %v", policies, err)
+	}
+	for _, config := range []string{"events", "traces=1d", "logs/sockshop=1d", "events/=1d", "events=1w", "events=-1d", "events=1d,events=2d"} {
+		if _, err := parseRetentionPolicies(config); err == nil {
+			t.Errorf("expected policies %s to be invalid", config)
+		}
+	}
+}
+
+// TestExpiredFilter checks whether a policy without project does not apply to projects with own policy
+func TestExpiredFilter(t *testing.T) {
+	policies := retentionPolicies
+	retentionPolicies, _ = parseRetentionPolicies("events=30d,events/sockshop=1d,events/audit=0,logs=1d")
+	defer func() {
+		retentionPolicies = policies
+	}()
+
+	now := time.Date(2019, 11, 20, 8, 0, 0, 0, time.UTC)
+	expected := bson.M{
+		"time":         bson.M{"$lt": "2019-10-21T08:00:00.000Z"},
+		"data.project": bson.M{"$nin": []string{"audit", "sockshop"}},
+	}
+	if filter := expiredFilter(retentionPolicies[0], now); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("unexpected filter of events %v", filter)
+	}
+	expected = bson.M{"time": bson.M{"$lt": "2019-11-19T08:00:00.000Z"}, "data.project": "sockshop"}
+	if filter := expiredFilter(retentionPolicies[2], now); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("unexpected filter of events/sockshop %v", filter)
+	}
+	expected = bson.M{"timestamp.data": bson.M{"$lt": "2019-11-19T08:00:00.000Z"}}
+	if filter := expiredFilter(retentionPolicies[3], now); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("unexpected filter of logs %v", filter)
+	}
+}
+
+// TestRetentionRunWithoutClient checks whether failed retention runs are recorded
+func TestRetentionRunWithoutClient(t *testing.T) {
+	policies := retentionPolicies
+	retentionPolicies, _ = parseRetentionPolicies("events=30d")
+	defer func() {
+		retentionPolicies = policies
+	}()
+
+	run, err := StartRetentionRun()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != retentionRunning {
+		t.Errorf("expected the run to be running, got %s", run.Status)
+	}
+	for i := 0; i < 100 && GetRetentionStatus().Runs[0].Status == retentionRunning; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	status := GetRetentionStatus()
+	if len(status.Policies) != 1 || status.Policies[0].Retention != "30d" {
+		t.Errorf("unexpected policies %+v", status.Policies)
+	}
+	if status.Runs[0].Status != retentionFailed || status.Runs[0].Message == "" {
+		t.Errorf("expected the run to fail without client, got %+v", status.Runs[0])
+	}
+}
+
+// TestArchiveFile checks whether archives of the same run time do not overwrite each other, and whether an archive
+// written in batches is read as a whole
+func TestArchiveFile(t *testing.T) {
+	directory, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	configuredDirectory := archiveDirectory
+	archiveDirectory = filepath.Join(directory, "archive")
+	defer func() {
+		archiveDirectory = configuredDirectory
+	}()
+
+	archive, err := createArchiveFile("events-20191120T080000Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"{\"id\":\"1\"}\n", "{\"id\":\"2\"}\n"} {
+		if _, err := archive.gz.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		if err := archive.flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(archiveDirectory, archive.name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "{\"id\":\"1\"}\n{\"id\":\"2\"}\n" {
+		t.Errorf("unexpected archive content %q", content)
+	}
+
+	second, err := createArchiveFile("events-20191120T080000Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.file.Close()
+	if archive.name != "events-20191120T080000Z.ndjson.gz" || second.name != "events-20191120T080000Z-2.ndjson.gz" {
+		t.Errorf("expected distinct archives, got %s and %s", archive.name, second.name)
+	}
+}
@@ -3,25 +3,33 @@
 package restapi
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	errors "github.com/go-openapi/errors"
 	runtime "github.com/go-openapi/runtime"
 	middleware "github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
+	keptnutils "github.com/keptn/go-utils/pkg/utils"
 
 	"github.com/keptn/keptn/mongodb-datastore/handlers"
 	"github.com/keptn/keptn/mongodb-datastore/models"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/health"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/logs"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/retention"
 )
 
 //go:generate swagger generate server --target ../../mongodb-datastore --name mongodb-datastore --spec ../swagger.yaml
 
+// indexRetryInterval is the time to wait before the creation of the indexes is retried
+const indexRetryInterval = 10 * time.Second
+
 func configureFlags(api *operations.MongodbDatastoreAPI) {
 	// api.CommandLineOptionsGroups = []swag.CommandLineOptionsGroup{ ... }
 }
@@ -40,41 +48,101 @@ func configureAPI(api *operations.MongodbDatastoreAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	// all requests share one client and its connection pool
+	if err := handlers.ConnectMongoDB(); err != nil {
+		keptnutils.NewLogger("", "", "mongodb-datastore").Error(err.Error())
+		os.Exit(1)
+	}
+	// indexes are created in the background, since MongoDB might not be reachable yet
+	go ensureIndexes()
+
+	// expired events and logs are deleted periodically
+	if err := handlers.ConfigureRetention(); err != nil {
+		keptnutils.NewLogger("", "", "mongodb-datastore").Error(err.Error())
+		os.Exit(1)
+	}
+	handlers.StartRetention()
+
 	api.EventSaveEventHandler = event.SaveEventHandlerFunc(func(params event.SaveEventParams) middleware.Responder {
-		if err := handlers.SaveEvent(params.Body); err != nil {
+		if err := handlers.SaveEvent(params.HTTPRequest.Context(), params.Body); err != nil {
 			return event.NewSaveEventDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
 		}
 		return event.NewSaveEventCreated()
 	})
 
 	api.EventGetEventsHandler = event.GetEventsHandlerFunc(func(params event.GetEventsParams) middleware.Responder {
-		events, err := handlers.GetEvents(params)
-		if err != nil {
+		events, err := handlers.GetEvents(params.HTTPRequest.Context(), params)
+		if err == handlers.ErrInvalidPageKey {
+			return event.NewGetEventsDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String(err.Error())})
+		} else if err != nil {
 			return event.NewGetEventsDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
 		}
 		return event.NewGetEventsOK().WithPayload(events)
 	})
 
 	api.LogsSaveLogHandler = logs.SaveLogHandlerFunc(func(params logs.SaveLogParams) middleware.Responder {
-		if err := handlers.SaveLog(params.Body); err != nil {
+		if err := handlers.SaveLog(params.HTTPRequest.Context(), params.Body); err != nil {
 			return logs.NewSaveLogDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
 		}
 		return logs.NewSaveLogCreated()
 	})
 
 	api.LogsGetLogsHandler = logs.GetLogsHandlerFunc(func(params logs.GetLogsParams) middleware.Responder {
-		mylogs, err := handlers.GetLogs(params)
-		if err != nil {
+		mylogs, err := handlers.GetLogs(params.HTTPRequest.Context(), params)
+		if err == handlers.ErrInvalidPageKey {
+			return logs.NewGetLogsDefault(400).WithPayload(&models.Error{Code: 400, Message: swag.String(err.Error())})
+		} else if err != nil {
 			return logs.NewGetLogsDefault(500).WithPayload(&models.Error{Code: 500, Message: swag.String(err.Error())})
 		}
 		return logs.NewGetLogsOK().WithPayload(mylogs)
 	})
 
-	api.ServerShutdown = func() {}
+	api.HealthGetHealthHandler = health.GetHealthHandlerFunc(func(params health.GetHealthParams) middleware.Responder {
+		return health.NewGetHealthOK().WithPayload(&models.HealthStatus{Status: "ok"})
+	})
+
+	api.HealthGetReadinessHandler = health.GetReadinessHandlerFunc(func(params health.GetReadinessParams) middleware.Responder {
+		if err := handlers.PingMongoDB(params.HTTPRequest.Context()); err != nil {
+			return health.NewGetReadinessServiceUnavailable().WithPayload(&models.HealthStatus{Status: "unavailable", Message: err.Error()})
+		}
+		return health.NewGetReadinessOK().WithPayload(&models.HealthStatus{Status: "ok"})
+	})
+
+	api.RetentionGetRetentionHandler = retention.GetRetentionHandlerFunc(func(params retention.GetRetentionParams) middleware.Responder {
+		return retention.NewGetRetentionOK().WithPayload(handlers.GetRetentionStatus())
+	})
+
+	api.RetentionRunRetentionHandler = retention.RunRetentionHandlerFunc(func(params retention.RunRetentionParams) middleware.Responder {
+		run, err := handlers.StartRetentionRun()
+		if err != nil {
+			return retention.NewRunRetentionConflict().WithPayload(&models.Error{Code: 409, Message: swag.String(err.Error())})
+		}
+		return retention.NewRunRetentionAccepted().WithPayload(run)
+	})
+
+	api.ServerShutdown = func() {
+		if err := handlers.DisconnectMongoDB(); err != nil {
+			keptnutils.NewLogger("", "", "mongodb-datastore").Error(err.Error())
+		}
+	}
 
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
+// ensureIndexes creates the indexes of MongoDB and retries until it succeeds
+func ensureIndexes() {
+	logger := keptnutils.NewLogger("", "", "mongodb-datastore")
+	for {
+		err := handlers.EnsureIndexes(context.Background())
+		if err == nil {
+			logger.Info("indexes of MongoDB are up to date")
+			return
+		}
+		logger.Error(err.Error())
+		time.Sleep(indexRetryInterval)
+	}
+}
+
 // The TLS configuration before HTTPS server starts.
 func configureTLS(tlsConfig *tls.Config) {
 	// Make all necessary changes to the TLS configuration here.
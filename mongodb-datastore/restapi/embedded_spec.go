@@ -46,19 +46,23 @@ func init() {
         "parameters": [
           {
             "type": "string",
-            "description": "keptnContext of the events to get",
+            "description": "keptnContext of the events to get, which has to match exactly",
             "name": "keptnContext",
             "in": "query"
           },
           {
-            "type": "string",
-            "description": "Type of the keptn cloud event",
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Type of the keptn cloud events. If several types are given, events of any of them are returned",
             "name": "type",
             "in": "query"
           },
           {
             "type": "string",
-            "description": "Set to load only root events",
+            "description": "Set to load only the root event, i.e., the first event, of each keptn context containing matching events",
             "name": "root",
             "in": "query"
           },
@@ -86,11 +90,62 @@ func init() {
             "name": "source",
             "in": "query"
           },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only events at or after this time are returned",
+            "name": "fromTime",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only events at or before this time are returned",
+            "name": "toTime",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "pattern": "^[^=]+=",
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Label of the events in the form key=value, which has to match data.labels.key. If several labels are given, all of them have to match",
+            "name": "label",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "pass",
+              "warning",
+              "fail"
+            ],
+            "type": "string",
+            "description": "Result of the events (data.result)",
+            "name": "result",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Deployment strategy of the events (data.deploymentstrategy), e.g., direct or blue_green_service",
+            "name": "deploymentStrategy",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Test strategy of the events (data.teststrategy), e.g., functional or performance",
+            "name": "testStrategy",
+            "in": "query"
+          },
           {
             "$ref": "#/parameters/pagesizeParam"
           },
           {
             "$ref": "#/parameters/pageParam"
+          },
+          {
+            "$ref": "#/parameters/totalCountParam"
           }
         ],
         "responses": {
@@ -106,7 +161,7 @@ func init() {
                   }
                 },
                 "nextPageKey": {
-                  "description": "Pointer to the next page",
+                  "description": "Key of the next page, empty for the last page",
                   "type": "string"
                 },
                 "pageSize": {
@@ -114,7 +169,7 @@ func init() {
                   "type": "integer"
                 },
                 "totalCount": {
-                  "description": "Total number of events",
+                  "description": "Total number of events, only returned if includeTotalCount is set",
                   "type": "integer"
                 }
               }
@@ -156,6 +211,23 @@ func init() {
         }
       }
     },
+    "/health": {
+      "get": {
+        "tags": [
+          "health"
+        ],
+        "summary": "Checks whether the service is alive, without accessing MongoDB",
+        "operationId": "getHealth",
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/HealthStatus"
+            }
+          }
+        }
+      }
+    },
     "/log": {
       "get": {
         "tags": [
@@ -175,6 +247,9 @@ func init() {
           },
           {
             "$ref": "#/parameters/pageParam"
+          },
+          {
+            "$ref": "#/parameters/totalCountParam"
           }
         ],
         "responses": {
@@ -190,7 +265,7 @@ func init() {
                   }
                 },
                 "nextPageKey": {
-                  "description": "Pointer to the next page",
+                  "description": "Key of the next page, empty for the last page",
                   "type": "string"
                 },
                 "pageSize": {
@@ -198,7 +273,7 @@ func init() {
                   "type": "integer"
                 },
                 "totalCount": {
-                  "description": "Total number of logs",
+                  "description": "Total number of logs, only returned if includeTotalCount is set",
                   "type": "integer"
                 }
               }
@@ -242,9 +317,97 @@ func init() {
           }
         }
       }
+    },
+    "/ready": {
+      "get": {
+        "tags": [
+          "health"
+        ],
+        "summary": "Checks whether the service can serve requests, i.e., whether MongoDB can be reached",
+        "operationId": "getReadiness",
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/HealthStatus"
+            }
+          },
+          "503": {
+            "description": "MongoDB cannot be reached",
+            "schema": {
+              "$ref": "#/definitions/HealthStatus"
+            }
+          }
+        }
+      }
+    },
+    "/retention": {
+      "get": {
+        "tags": [
+          "retention"
+        ],
+        "summary": "Gets the retention policies and the latest retention runs",
+        "operationId": "getRetention",
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/RetentionStatus"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/retention/run": {
+      "post": {
+        "tags": [
+          "retention"
+        ],
+        "summary": "Starts a retention run, which deletes (and archives) the events and logs that have expired",
+        "operationId": "runRetention",
+        "responses": {
+          "202": {
+            "description": "the retention run has been started",
+            "schema": {
+              "$ref": "#/definitions/RetentionRun"
+            }
+          },
+          "409": {
+            "description": "a retention run is already in progress",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
+    "HealthStatus": {
+      "type": "object",
+      "properties": {
+        "message": {
+          "description": "Reason why the service is unavailable",
+          "type": "string"
+        },
+        "status": {
+          "description": "ok if the service is healthy, unavailable otherwise",
+          "type": "string"
+        }
+      }
+    },
     "KeptnContextExtendedCE": {
       "allOf": [
         {
@@ -284,6 +447,96 @@ func init() {
         }
       }
     },
+    "RetentionPolicy": {
+      "type": "object",
+      "properties": {
+        "collection": {
+          "description": "Collection the policy applies to, i.e., events or logs",
+          "type": "string"
+        },
+        "project": {
+          "description": "Project the policy applies to. Policies without project apply to all projects without own policy",
+          "type": "string"
+        },
+        "retention": {
+          "description": "Time after which items are deleted, e.g., 90d or 12h. 0 keeps the items forever",
+          "type": "string"
+        }
+      }
+    },
+    "RetentionResult": {
+      "type": "object",
+      "properties": {
+        "archive": {
+          "description": "File the deleted items have been archived to",
+          "type": "string"
+        },
+        "collection": {
+          "type": "string"
+        },
+        "deleted": {
+          "description": "Number of deleted items",
+          "type": "integer",
+          "format": "int64"
+        },
+        "project": {
+          "type": "string"
+        }
+      }
+    },
+    "RetentionRun": {
+      "type": "object",
+      "properties": {
+        "endTime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "message": {
+          "description": "Reason why the run failed",
+          "type": "string"
+        },
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RetentionResult"
+          }
+        },
+        "startTime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "status": {
+          "description": "running, succeeded or failed",
+          "type": "string"
+        }
+      }
+    },
+    "RetentionStatus": {
+      "type": "object",
+      "properties": {
+        "archiveDirectory": {
+          "description": "Directory expired items are archived to before they are deleted. Empty if they are not archived",
+          "type": "string"
+        },
+        "interval": {
+          "description": "Interval of the retention runs",
+          "type": "string"
+        },
+        "policies": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RetentionPolicy"
+          }
+        },
+        "runs": {
+          "description": "The latest retention runs, starting with the latest one",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RetentionRun"
+          }
+        }
+      }
+    },
     "error": {
       "type": "object",
       "required": [
@@ -309,7 +562,7 @@ func init() {
   "parameters": {
     "pageParam": {
       "type": "string",
-      "description": "Key of the page to be returned",
+      "description": "Key of the page to be returned, as returned in nextPageKey of the previous page",
       "name": "nextPageKey",
       "in": "query"
     },
@@ -321,6 +574,13 @@ func init() {
       "description": "Page size to be returned",
       "name": "pageSize",
       "in": "query"
+    },
+    "totalCountParam": {
+      "type": "boolean",
+      "default": false,
+      "description": "Set to true to return the total number of matching items in totalCount",
+      "name": "includeTotalCount",
+      "in": "query"
     }
   }
 }`))
@@ -353,19 +613,23 @@ func init() {
         "parameters": [
           {
             "type": "string",
-            "description": "keptnContext of the events to get",
+            "description": "keptnContext of the events to get, which has to match exactly",
             "name": "keptnContext",
             "in": "query"
           },
           {
-            "type": "string",
-            "description": "Type of the keptn cloud event",
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Type of the keptn cloud events. If several types are given, events of any of them are returned",
             "name": "type",
             "in": "query"
           },
           {
             "type": "string",
-            "description": "Set to load only root events",
+            "description": "Set to load only the root event, i.e., the first event, of each keptn context containing matching events",
             "name": "root",
             "in": "query"
           },
@@ -393,6 +657,54 @@ func init() {
             "name": "source",
             "in": "query"
           },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only events at or after this time are returned",
+            "name": "fromTime",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only events at or before this time are returned",
+            "name": "toTime",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "pattern": "^[^=]+=",
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Label of the events in the form key=value, which has to match data.labels.key. If several labels are given, all of them have to match",
+            "name": "label",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "pass",
+              "warning",
+              "fail"
+            ],
+            "type": "string",
+            "description": "Result of the events (data.result)",
+            "name": "result",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Deployment strategy of the events (data.deploymentstrategy), e.g., direct or blue_green_service",
+            "name": "deploymentStrategy",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Test strategy of the events (data.teststrategy), e.g., functional or performance",
+            "name": "testStrategy",
+            "in": "query"
+          },
           {
             "maximum": 100,
             "minimum": 1,
@@ -404,9 +716,16 @@ func init() {
           },
           {
             "type": "string",
-            "description": "Key of the page to be returned",
+            "description": "Key of the page to be returned, as returned in nextPageKey of the previous page",
             "name": "nextPageKey",
             "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Set to true to return the total number of matching items in totalCount",
+            "name": "includeTotalCount",
+            "in": "query"
           }
         ],
         "responses": {
@@ -422,7 +741,7 @@ func init() {
                   }
                 },
                 "nextPageKey": {
-                  "description": "Pointer to the next page",
+                  "description": "Key of the next page, empty for the last page",
                   "type": "string"
                 },
                 "pageSize": {
@@ -430,7 +749,7 @@ func init() {
                   "type": "integer"
                 },
                 "totalCount": {
-                  "description": "Total number of events",
+                  "description": "Total number of events, only returned if includeTotalCount is set",
                   "type": "integer"
                 }
               }
@@ -472,6 +791,23 @@ func init() {
         }
       }
     },
+    "/health": {
+      "get": {
+        "tags": [
+          "health"
+        ],
+        "summary": "Checks whether the service is alive, without accessing MongoDB",
+        "operationId": "getHealth",
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/HealthStatus"
+            }
+          }
+        }
+      }
+    },
     "/log": {
       "get": {
         "tags": [
@@ -497,9 +833,16 @@ func init() {
           },
           {
             "type": "string",
-            "description": "Key of the page to be returned",
+            "description": "Key of the page to be returned, as returned in nextPageKey of the previous page",
             "name": "nextPageKey",
             "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Set to true to return the total number of matching items in totalCount",
+            "name": "includeTotalCount",
+            "in": "query"
           }
         ],
         "responses": {
@@ -515,7 +858,7 @@ func init() {
                   }
                 },
                 "nextPageKey": {
-                  "description": "Pointer to the next page",
+                  "description": "Key of the next page, empty for the last page",
                   "type": "string"
                 },
                 "pageSize": {
@@ -523,7 +866,7 @@ func init() {
                   "type": "integer"
                 },
                 "totalCount": {
-                  "description": "Total number of logs",
+                  "description": "Total number of logs, only returned if includeTotalCount is set",
                   "type": "integer"
                 }
               }
@@ -567,9 +910,97 @@ func init() {
           }
         }
       }
+    },
+    "/ready": {
+      "get": {
+        "tags": [
+          "health"
+        ],
+        "summary": "Checks whether the service can serve requests, i.e., whether MongoDB can be reached",
+        "operationId": "getReadiness",
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/HealthStatus"
+            }
+          },
+          "503": {
+            "description": "MongoDB cannot be reached",
+            "schema": {
+              "$ref": "#/definitions/HealthStatus"
+            }
+          }
+        }
+      }
+    },
+    "/retention": {
+      "get": {
+        "tags": [
+          "retention"
+        ],
+        "summary": "Gets the retention policies and the latest retention runs",
+        "operationId": "getRetention",
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/RetentionStatus"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/retention/run": {
+      "post": {
+        "tags": [
+          "retention"
+        ],
+        "summary": "Starts a retention run, which deletes (and archives) the events and logs that have expired",
+        "operationId": "runRetention",
+        "responses": {
+          "202": {
+            "description": "the retention run has been started",
+            "schema": {
+              "$ref": "#/definitions/RetentionRun"
+            }
+          },
+          "409": {
+            "description": "a retention run is already in progress",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
+    "HealthStatus": {
+      "type": "object",
+      "properties": {
+        "message": {
+          "description": "Reason why the service is unavailable",
+          "type": "string"
+        },
+        "status": {
+          "description": "ok if the service is healthy, unavailable otherwise",
+          "type": "string"
+        }
+      }
+    },
     "KeptnContextExtendedCE": {
       "allOf": [
         {
@@ -609,6 +1040,96 @@ func init() {
         }
       }
     },
+    "RetentionPolicy": {
+      "type": "object",
+      "properties": {
+        "collection": {
+          "description": "Collection the policy applies to, i.e., events or logs",
+          "type": "string"
+        },
+        "project": {
+          "description": "Project the policy applies to. Policies without project apply to all projects without own policy",
+          "type": "string"
+        },
+        "retention": {
+          "description": "Time after which items are deleted, e.g., 90d or 12h. 0 keeps the items forever",
+          "type": "string"
+        }
+      }
+    },
+    "RetentionResult": {
+      "type": "object",
+      "properties": {
+        "archive": {
+          "description": "File the deleted items have been archived to",
+          "type": "string"
+        },
+        "collection": {
+          "type": "string"
+        },
+        "deleted": {
+          "description": "Number of deleted items",
+          "type": "integer",
+          "format": "int64"
+        },
+        "project": {
+          "type": "string"
+        }
+      }
+    },
+    "RetentionRun": {
+      "type": "object",
+      "properties": {
+        "endTime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "message": {
+          "description": "Reason why the run failed",
+          "type": "string"
+        },
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RetentionResult"
+          }
+        },
+        "startTime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "status": {
+          "description": "running, succeeded or failed",
+          "type": "string"
+        }
+      }
+    },
+    "RetentionStatus": {
+      "type": "object",
+      "properties": {
+        "archiveDirectory": {
+          "description": "Directory expired items are archived to before they are deleted. Empty if they are not archived",
+          "type": "string"
+        },
+        "interval": {
+          "description": "Interval of the retention runs",
+          "type": "string"
+        },
+        "policies": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RetentionPolicy"
+          }
+        },
+        "runs": {
+          "description": "The latest retention runs, starting with the latest one",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RetentionRun"
+          }
+        }
+      }
+    },
     "contenttype": {
       "type": "string"
     },
@@ -698,7 +1219,7 @@ func init() {
   "parameters": {
     "pageParam": {
       "type": "string",
-      "description": "Key of the page to be returned",
+      "description": "Key of the page to be returned, as returned in nextPageKey of the previous page",
       "name": "nextPageKey",
       "in": "query"
     },
@@ -710,6 +1231,13 @@ func init() {
       "description": "Page size to be returned",
       "name": "pageSize",
       "in": "query"
+    },
+    "totalCountParam": {
+      "type": "boolean",
+      "default": false,
+      "description": "Set to true to return the total number of matching items in totalCount",
+      "name": "includeTotalCount",
+      "in": "query"
     }
   }
 }`))
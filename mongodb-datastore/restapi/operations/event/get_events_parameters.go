@@ -6,6 +6,7 @@ package event
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/go-openapi/errors"
@@ -24,10 +25,14 @@ func NewGetEventsParams() GetEventsParams {
 	var (
 		// initialize parameters with default values
 
+		includeTotalCountDefault = bool(false)
+
 		pageSizeDefault = int64(20)
 	)
 
 	return GetEventsParams{
+		IncludeTotalCount: &includeTotalCountDefault,
+
 		PageSize: &pageSizeDefault,
 	}
 }
@@ -41,11 +46,29 @@ type GetEventsParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
-	/*keptnContext of the events to get
+	/*Deployment strategy of the events (data.deploymentstrategy), e.g., direct or blue_green_service
+	  In: query
+	*/
+	DeploymentStrategy *string
+	/*Only events at or after this time are returned
+	  In: query
+	*/
+	FromTime *strfmt.DateTime
+	/*Set to true to return the total number of matching items in totalCount
+	  In: query
+	  Default: false
+	*/
+	IncludeTotalCount *bool
+	/*keptnContext of the events to get, which has to match exactly
 	  In: query
 	*/
 	KeptnContext *string
-	/*Key of the page to be returned
+	/*Label of the events in the form key=value, which has to match data.labels.key. If several labels are given, all of them have to match
+	  In: query
+	  Collection Format: multi
+	*/
+	Label []string
+	/*Key of the page to be returned, as returned in nextPageKey of the previous page
 	  In: query
 	*/
 	NextPageKey *string
@@ -60,7 +83,11 @@ type GetEventsParams struct {
 	  In: query
 	*/
 	Project *string
-	/*Set to load only root events
+	/*Result of the events (data.result)
+	  In: query
+	*/
+	Result *string
+	/*Set to load only the root event, i.e., the first event, of each keptn context containing matching events
 	  In: query
 	*/
 	Root *string
@@ -76,10 +103,19 @@ type GetEventsParams struct {
 	  In: query
 	*/
 	Stage *string
-	/*Type of the keptn cloud event
+	/*Test strategy of the events (data.teststrategy), e.g., functional or performance
+	  In: query
+	*/
+	TestStrategy *string
+	/*Only events at or before this time are returned
 	  In: query
 	*/
-	Type *string
+	ToTime *strfmt.DateTime
+	/*Type of the keptn cloud events. If several types are given, events of any of them are returned
+	  In: query
+	  Collection Format: multi
+	*/
+	Type []string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -93,11 +129,31 @@ func (o *GetEventsParams) BindRequest(r *http.Request, route *middleware.Matched
 
 	qs := runtime.Values(r.URL.Query())
 
+	qDeploymentStrategy, qhkDeploymentStrategy, _ := qs.GetOK("deploymentStrategy")
+	if err := o.bindDeploymentStrategy(qDeploymentStrategy, qhkDeploymentStrategy, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qFromTime, qhkFromTime, _ := qs.GetOK("fromTime")
+	if err := o.bindFromTime(qFromTime, qhkFromTime, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qIncludeTotalCount, qhkIncludeTotalCount, _ := qs.GetOK("includeTotalCount")
+	if err := o.bindIncludeTotalCount(qIncludeTotalCount, qhkIncludeTotalCount, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qKeptnContext, qhkKeptnContext, _ := qs.GetOK("keptnContext")
 	if err := o.bindKeptnContext(qKeptnContext, qhkKeptnContext, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qLabel, qhkLabel, _ := qs.GetOK("label")
+	if err := o.bindLabel(qLabel, qhkLabel, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qNextPageKey, qhkNextPageKey, _ := qs.GetOK("nextPageKey")
 	if err := o.bindNextPageKey(qNextPageKey, qhkNextPageKey, route.Formats); err != nil {
 		res = append(res, err)
@@ -113,6 +169,11 @@ func (o *GetEventsParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qResult, qhkResult, _ := qs.GetOK("result")
+	if err := o.bindResult(qResult, qhkResult, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qRoot, qhkRoot, _ := qs.GetOK("root")
 	if err := o.bindRoot(qRoot, qhkRoot, route.Formats); err != nil {
 		res = append(res, err)
@@ -133,6 +194,16 @@ func (o *GetEventsParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qTestStrategy, qhkTestStrategy, _ := qs.GetOK("testStrategy")
+	if err := o.bindTestStrategy(qTestStrategy, qhkTestStrategy, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qToTime, qhkToTime, _ := qs.GetOK("toTime")
+	if err := o.bindToTime(qToTime, qhkToTime, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qType, qhkType, _ := qs.GetOK("type")
 	if err := o.bindType(qType, qhkType, route.Formats); err != nil {
 		res = append(res, err)
@@ -144,6 +215,83 @@ func (o *GetEventsParams) BindRequest(r *http.Request, route *middleware.Matched
 	return nil
 }
 
+// bindDeploymentStrategy binds and validates parameter DeploymentStrategy from query.
+func (o *GetEventsParams) bindDeploymentStrategy(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.DeploymentStrategy = &raw
+
+	return nil
+}
+
+// bindFromTime binds and validates parameter FromTime from query.
+func (o *GetEventsParams) bindFromTime(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("fromTime", "query", "strfmt.DateTime", raw)
+	}
+	o.FromTime = (value.(*strfmt.DateTime))
+
+	if err := o.validateFromTime(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFromTime carries on validations for parameter FromTime
+func (o *GetEventsParams) validateFromTime(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("fromTime", "query", "date-time", o.FromTime.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
+
+// bindIncludeTotalCount binds and validates parameter IncludeTotalCount from query.
+func (o *GetEventsParams) bindIncludeTotalCount(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetEventsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("includeTotalCount", "query", "bool", raw)
+	}
+	o.IncludeTotalCount = &value
+
+	return nil
+}
+
 // bindKeptnContext binds and validates parameter KeptnContext from query.
 func (o *GetEventsParams) bindKeptnContext(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -162,6 +310,34 @@ func (o *GetEventsParams) bindKeptnContext(rawData []string, hasKey bool, format
 	return nil
 }
 
+// bindLabel binds and validates array parameter Label from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *GetEventsParams) bindLabel(rawData []string, hasKey bool, formats strfmt.Registry) error {
+
+	// CollectionFormat: multi
+	labelIC := rawData
+
+	if len(labelIC) == 0 {
+		return nil
+	}
+
+	var labelIR []string
+	for i, labelIV := range labelIC {
+		labelI := labelIV
+
+		if err := validate.Pattern(fmt.Sprintf("%s.%v", "label", i), "query", labelI, `^[^=]+=`); err != nil {
+			return err
+		}
+
+		labelIR = append(labelIR, labelI)
+	}
+
+	o.Label = labelIR
+
+	return nil
+}
+
 // bindNextPageKey binds and validates parameter NextPageKey from query.
 func (o *GetEventsParams) bindNextPageKey(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -239,6 +415,38 @@ func (o *GetEventsParams) bindProject(rawData []string, hasKey bool, formats str
 	return nil
 }
 
+// bindResult binds and validates parameter Result from query.
+func (o *GetEventsParams) bindResult(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Result = &raw
+
+	if err := o.validateResult(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateResult carries on validations for parameter Result
+func (o *GetEventsParams) validateResult(formats strfmt.Registry) error {
+
+	if err := validate.Enum("result", "query", *o.Result, []interface{}{"pass", "warning", "fail"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // bindRoot binds and validates parameter Root from query.
 func (o *GetEventsParams) bindRoot(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -311,8 +519,26 @@ func (o *GetEventsParams) bindStage(rawData []string, hasKey bool, formats strfm
 	return nil
 }
 
-// bindType binds and validates parameter Type from query.
-func (o *GetEventsParams) bindType(rawData []string, hasKey bool, formats strfmt.Registry) error {
+// bindTestStrategy binds and validates parameter TestStrategy from query.
+func (o *GetEventsParams) bindTestStrategy(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.TestStrategy = &raw
+
+	return nil
+}
+
+// bindToTime binds and validates parameter ToTime from query.
+func (o *GetEventsParams) bindToTime(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
 	if len(rawData) > 0 {
 		raw = rawData[len(rawData)-1]
@@ -324,7 +550,49 @@ func (o *GetEventsParams) bindType(rawData []string, hasKey bool, formats strfmt
 		return nil
 	}
 
-	o.Type = &raw
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("toTime", "query", "strfmt.DateTime", raw)
+	}
+	o.ToTime = (value.(*strfmt.DateTime))
+
+	if err := o.validateToTime(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateToTime carries on validations for parameter ToTime
+func (o *GetEventsParams) validateToTime(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("toTime", "query", "date-time", o.ToTime.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
+
+// bindType binds and validates array parameter Type from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *GetEventsParams) bindType(rawData []string, hasKey bool, formats strfmt.Registry) error {
+
+	// CollectionFormat: multi
+	typeVarIC := rawData
+
+	if len(typeVarIC) == 0 {
+		return nil
+	}
+
+	var typeVarIR []string
+	for _, typeVarIV := range typeVarIC {
+		typeVarI := typeVarIV
+
+		typeVarIR = append(typeVarIR, typeVarI)
+	}
+
+	o.Type = typeVarIR
 
 	return nil
 }
@@ -11,19 +11,28 @@ import (
 	golangswaggerpaths "path"
 
 	"github.com/go-openapi/swag"
+
+	strfmt "github.com/go-openapi/strfmt"
 )
 
 // GetEventsURL generates an URL for the get events operation
 type GetEventsURL struct {
-	KeptnContext *string
-	NextPageKey  *string
-	PageSize     *int64
-	Project      *string
-	Root         *string
-	Service      *string
-	Source       *string
-	Stage        *string
-	Type         *string
+	DeploymentStrategy *string
+	FromTime           *strfmt.DateTime
+	IncludeTotalCount  *bool
+	KeptnContext       *string
+	Label              []string
+	NextPageKey        *string
+	PageSize           *int64
+	Project            *string
+	Result             *string
+	Root               *string
+	Service            *string
+	Source             *string
+	Stage              *string
+	TestStrategy       *string
+	ToTime             *strfmt.DateTime
+	Type               []string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -59,6 +68,30 @@ func (o *GetEventsURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var deploymentStrategyQ string
+	if o.DeploymentStrategy != nil {
+		deploymentStrategyQ = *o.DeploymentStrategy
+	}
+	if deploymentStrategyQ != "" {
+		qs.Set("deploymentStrategy", deploymentStrategyQ)
+	}
+
+	var fromTimeQ string
+	if o.FromTime != nil {
+		fromTimeQ = o.FromTime.String()
+	}
+	if fromTimeQ != "" {
+		qs.Set("fromTime", fromTimeQ)
+	}
+
+	var includeTotalCountQ string
+	if o.IncludeTotalCount != nil {
+		includeTotalCountQ = swag.FormatBool(*o.IncludeTotalCount)
+	}
+	if includeTotalCountQ != "" {
+		qs.Set("includeTotalCount", includeTotalCountQ)
+	}
+
 	var keptnContextQ string
 	if o.KeptnContext != nil {
 		keptnContextQ = *o.KeptnContext
@@ -67,6 +100,20 @@ func (o *GetEventsURL) Build() (*url.URL, error) {
 		qs.Set("keptnContext", keptnContextQ)
 	}
 
+	var labelIR []string
+	for _, labelI := range o.Label {
+		labelIS := labelI
+		if labelIS != "" {
+			labelIR = append(labelIR, labelIS)
+		}
+	}
+
+	label := swag.JoinByFormat(labelIR, "multi")
+
+	for _, qsv := range label {
+		qs.Add("label", qsv)
+	}
+
 	var nextPageKeyQ string
 	if o.NextPageKey != nil {
 		nextPageKeyQ = *o.NextPageKey
@@ -91,6 +138,14 @@ func (o *GetEventsURL) Build() (*url.URL, error) {
 		qs.Set("project", projectQ)
 	}
 
+	var resultQ string
+	if o.Result != nil {
+		resultQ = *o.Result
+	}
+	if resultQ != "" {
+		qs.Set("result", resultQ)
+	}
+
 	var rootQ string
 	if o.Root != nil {
 		rootQ = *o.Root
@@ -123,12 +178,34 @@ func (o *GetEventsURL) Build() (*url.URL, error) {
 		qs.Set("stage", stageQ)
 	}
 
-	var typeVarQ string
-	if o.Type != nil {
-		typeVarQ = *o.Type
+	var testStrategyQ string
+	if o.TestStrategy != nil {
+		testStrategyQ = *o.TestStrategy
+	}
+	if testStrategyQ != "" {
+		qs.Set("testStrategy", testStrategyQ)
 	}
-	if typeVarQ != "" {
-		qs.Set("type", typeVarQ)
+
+	var toTimeQ string
+	if o.ToTime != nil {
+		toTimeQ = o.ToTime.String()
+	}
+	if toTimeQ != "" {
+		qs.Set("toTime", toTimeQ)
+	}
+
+	var typeVarIR []string
+	for _, typeVarI := range o.Type {
+		typeVarIS := typeVarI
+		if typeVarIS != "" {
+			typeVarIR = append(typeVarIR, typeVarIS)
+		}
+	}
+
+	typeVar := swag.JoinByFormat(typeVarIR, "multi")
+
+	for _, qsv := range typeVar {
+		qs.Add("type", qsv)
 	}
 
 	_result.RawQuery = qs.Encode()
@@ -70,13 +70,13 @@ type GetLogsOKBody struct {
 	// logs
 	Logs []*models.LogEntry `json:"logs"`
 
-	// Pointer to the next page
+	// Key of the next page, empty for the last page
 	NextPageKey string `json:"nextPageKey,omitempty"`
 
 	// Size of the returned page
 	PageSize int64 `json:"pageSize,omitempty"`
 
-	// Total number of logs
+	// Total number of logs, only returned if includeTotalCount is set
 	TotalCount int64 `json:"totalCount,omitempty"`
 }
 
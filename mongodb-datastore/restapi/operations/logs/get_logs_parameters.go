@@ -24,10 +24,14 @@ func NewGetLogsParams() GetLogsParams {
 	var (
 		// initialize parameters with default values
 
+		includeTotalCountDefault = bool(false)
+
 		pageSizeDefault = int64(20)
 	)
 
 	return GetLogsParams{
+		IncludeTotalCount: &includeTotalCountDefault,
+
 		PageSize: &pageSizeDefault,
 	}
 }
@@ -45,7 +49,12 @@ type GetLogsParams struct {
 	  In: query
 	*/
 	EventID *string
-	/*Key of the page to be returned
+	/*Set to true to return the total number of matching items in totalCount
+	  In: query
+	  Default: false
+	*/
+	IncludeTotalCount *bool
+	/*Key of the page to be returned, as returned in nextPageKey of the previous page
 	  In: query
 	*/
 	NextPageKey *string
@@ -74,6 +83,11 @@ func (o *GetLogsParams) BindRequest(r *http.Request, route *middleware.MatchedRo
 		res = append(res, err)
 	}
 
+	qIncludeTotalCount, qhkIncludeTotalCount, _ := qs.GetOK("includeTotalCount")
+	if err := o.bindIncludeTotalCount(qIncludeTotalCount, qhkIncludeTotalCount, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qNextPageKey, qhkNextPageKey, _ := qs.GetOK("nextPageKey")
 	if err := o.bindNextPageKey(qNextPageKey, qhkNextPageKey, route.Formats); err != nil {
 		res = append(res, err)
@@ -108,6 +122,29 @@ func (o *GetLogsParams) bindEventID(rawData []string, hasKey bool, formats strfm
 	return nil
 }
 
+// bindIncludeTotalCount binds and validates parameter IncludeTotalCount from query.
+func (o *GetLogsParams) bindIncludeTotalCount(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetLogsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("includeTotalCount", "query", "bool", raw)
+	}
+	o.IncludeTotalCount = &value
+
+	return nil
+}
+
 // bindNextPageKey binds and validates parameter NextPageKey from query.
 func (o *GetLogsParams) bindNextPageKey(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
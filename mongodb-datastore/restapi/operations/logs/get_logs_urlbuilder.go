@@ -15,9 +15,10 @@ import (
 
 // GetLogsURL generates an URL for the get logs operation
 type GetLogsURL struct {
-	EventID     *string
-	NextPageKey *string
-	PageSize    *int64
+	EventID           *string
+	IncludeTotalCount *bool
+	NextPageKey       *string
+	PageSize          *int64
 
 	_basePath string
 	// avoid unkeyed usage
@@ -61,6 +62,14 @@ func (o *GetLogsURL) Build() (*url.URL, error) {
 		qs.Set("eventId", eventIDQ)
 	}
 
+	var includeTotalCountQ string
+	if o.IncludeTotalCount != nil {
+		includeTotalCountQ = swag.FormatBool(*o.IncludeTotalCount)
+	}
+	if includeTotalCountQ != "" {
+		qs.Set("includeTotalCount", includeTotalCountQ)
+	}
+
 	var nextPageKeyQ string
 	if o.NextPageKey != nil {
 		nextPageKeyQ = *o.NextPageKey
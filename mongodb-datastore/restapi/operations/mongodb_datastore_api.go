@@ -20,7 +20,9 @@ import (
 	"github.com/go-openapi/swag"
 
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/health"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/logs"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/retention"
 )
 
 // NewMongodbDatastoreAPI creates a new MongodbDatastore instance
@@ -43,8 +45,16 @@ func NewMongodbDatastoreAPI(spec *loads.Document) *MongodbDatastoreAPI {
 		JSONProducer:        runtime.JSONProducer(),
 		EventGetEventsHandler: event.GetEventsHandlerFunc(func(params event.GetEventsParams) middleware.Responder {
 			return middleware.NotImplemented("operation EventGetEvents has not yet been implemented")
+		}), HealthGetHealthHandler: health.GetHealthHandlerFunc(func(params health.GetHealthParams) middleware.Responder {
+			return middleware.NotImplemented("operation HealthGetHealth has not yet been implemented")
 		}), LogsGetLogsHandler: logs.GetLogsHandlerFunc(func(params logs.GetLogsParams) middleware.Responder {
 			return middleware.NotImplemented("operation LogsGetLogs has not yet been implemented")
+		}), HealthGetReadinessHandler: health.GetReadinessHandlerFunc(func(params health.GetReadinessParams) middleware.Responder {
+			return middleware.NotImplemented("operation HealthGetReadiness has not yet been implemented")
+		}), RetentionGetRetentionHandler: retention.GetRetentionHandlerFunc(func(params retention.GetRetentionParams) middleware.Responder {
+			return middleware.NotImplemented("operation RetentionGetRetention has not yet been implemented")
+		}), RetentionRunRetentionHandler: retention.RunRetentionHandlerFunc(func(params retention.RunRetentionParams) middleware.Responder {
+			return middleware.NotImplemented("operation RetentionRunRetention has not yet been implemented")
 		}), EventSaveEventHandler: event.SaveEventHandlerFunc(func(params event.SaveEventParams) middleware.Responder {
 			return middleware.NotImplemented("operation EventSaveEvent has not yet been implemented")
 		}), LogsSaveLogHandler: logs.SaveLogHandlerFunc(func(params logs.SaveLogParams) middleware.Responder {
@@ -85,8 +95,16 @@ type MongodbDatastoreAPI struct {
 
 	// EventGetEventsHandler sets the operation handler for the get events operation
 	EventGetEventsHandler event.GetEventsHandler
+	// HealthGetHealthHandler sets the operation handler for the get health operation
+	HealthGetHealthHandler health.GetHealthHandler
 	// LogsGetLogsHandler sets the operation handler for the get logs operation
 	LogsGetLogsHandler logs.GetLogsHandler
+	// HealthGetReadinessHandler sets the operation handler for the get readiness operation
+	HealthGetReadinessHandler health.GetReadinessHandler
+	// RetentionGetRetentionHandler sets the operation handler for the get retention operation
+	RetentionGetRetentionHandler retention.GetRetentionHandler
+	// RetentionRunRetentionHandler sets the operation handler for the run retention operation
+	RetentionRunRetentionHandler retention.RunRetentionHandler
 	// EventSaveEventHandler sets the operation handler for the save event operation
 	EventSaveEventHandler event.SaveEventHandler
 	// LogsSaveLogHandler sets the operation handler for the save log operation
@@ -162,10 +180,26 @@ func (o *MongodbDatastoreAPI) Validate() error {
 		unregistered = append(unregistered, "event.GetEventsHandler")
 	}
 
+	if o.HealthGetHealthHandler == nil {
+		unregistered = append(unregistered, "health.GetHealthHandler")
+	}
+
 	if o.LogsGetLogsHandler == nil {
 		unregistered = append(unregistered, "logs.GetLogsHandler")
 	}
 
+	if o.HealthGetReadinessHandler == nil {
+		unregistered = append(unregistered, "health.GetReadinessHandler")
+	}
+
+	if o.RetentionGetRetentionHandler == nil {
+		unregistered = append(unregistered, "retention.GetRetentionHandler")
+	}
+
+	if o.RetentionRunRetentionHandler == nil {
+		unregistered = append(unregistered, "retention.RunRetentionHandler")
+	}
+
 	if o.EventSaveEventHandler == nil {
 		unregistered = append(unregistered, "event.SaveEventHandler")
 	}
@@ -275,11 +309,31 @@ func (o *MongodbDatastoreAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/event"] = event.NewGetEvents(o.context, o.EventGetEventsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/health"] = health.NewGetHealth(o.context, o.HealthGetHealthHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/log"] = logs.NewGetLogs(o.context, o.LogsGetLogsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/ready"] = health.NewGetReadiness(o.context, o.HealthGetReadinessHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/retention"] = retention.NewGetRetention(o.context, o.RetentionGetRetentionHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/retention/run"] = retention.NewRunRetention(o.context, o.RetentionRunRetentionHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}